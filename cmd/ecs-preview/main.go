@@ -85,6 +85,7 @@ func buildRootCmd() *cobra.Command {
 	cmd.AddCommand(cli.BuildLogCmd())
 	cmd.AddCommand(cli.BuildVariableCmd())
 	cmd.AddCommand(cli.BuildSecretCmd())
+	cmd.AddCommand(cli.BuildManifestCmd())
 
 	// "Settings" command group.
 	cmd.AddCommand(cli.BuildVersionCmd())
@@ -5,7 +5,7 @@ go 1.13
 require (
 	github.com/AlecAivazis/survey/v2 v2.0.5
 	github.com/Netflix/go-expect v0.0.0-20190729225929-0e00d9168667 // indirect
-	github.com/aws/aws-sdk-go v1.27.1
+	github.com/aws/aws-sdk-go v1.34.0
 	github.com/briandowns/spinner v1.8.0
 	github.com/datadotworld/dev-tools v0.0.0-20200123221603-f137cd5b107a
 	github.com/fatih/color v1.8.0
@@ -0,0 +1,59 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package archer
+
+import "time"
+
+// Deployment represents a successful deployment of an application to an environment.
+type Deployment struct {
+	Project      string    `json:"project"`      // Name of the project the application belongs to.
+	App          string    `json:"app"`          // Name of the deployed application.
+	Env          string    `json:"env"`          // Name of the environment the application was deployed to.
+	ID           int       `json:"id"`           // Sequence number of the deployment of the application in the environment, starting at 1.
+	ImageTag     string    `json:"imageTag"`     // Tag of the deployed image.
+	ImageDigest  string    `json:"imageDigest"`  // Digest of the deployed image, empty for images deployed from their location.
+	ManifestHash string    `json:"manifestHash"` // SHA-256 hash of the deployed manifest.
+	Manifest     string    `json:"manifest"`     // Interpolated manifest the application's stack was rendered from.
+	GitCommit    string    `json:"gitCommit"`    // Commit of the workspace at the time of the deployment, if any.
+	User         string    `json:"user"`         // ARN of the identity that deployed the application.
+	Time         time.Time `json:"time"`         // Time at which the deployment completed.
+}
+
+// DeploymentStore can List and Create deployments in an underlying project management store.
+type DeploymentStore interface {
+	DeploymentLister
+	DeploymentCreator
+}
+
+// DeploymentLister fetches and returns the deployments of an application to an environment, oldest first.
+type DeploymentLister interface {
+	ListDeployments(projectName, appName, envName string) ([]*Deployment, error)
+}
+
+// DeploymentCreator records a deployment in the underlying project management store and assigns its ID.
+type DeploymentCreator interface {
+	CreateDeployment(d *Deployment) error
+}
+
+// DeploymentLock prevents an application from being deployed to an environment by several people at once.
+type DeploymentLock struct {
+	Project    string        `json:"project"`    // Name of the project the application belongs to.
+	App        string        `json:"app"`        // Name of the application being deployed.
+	Env        string        `json:"env"`        // Name of the environment the application is deployed to.
+	Owner      string        `json:"owner"`      // ARN of the identity deploying the application.
+	AcquiredAt time.Time     `json:"acquiredAt"` // Time at which the deployment started.
+	TTL        time.Duration `json:"ttl"`        // Duration after which the lock is considered stale, in case its owner never released it.
+}
+
+// Expired returns true if the lock's TTL has elapsed at the given time.
+func (l *DeploymentLock) Expired(now time.Time) bool {
+	return now.After(l.AcquiredAt.Add(l.TTL))
+}
+
+// DeploymentLocker acquires, fetches and releases the deployment locks of applications in an underlying project management store.
+type DeploymentLocker interface {
+	AcquireDeploymentLock(lock *DeploymentLock) error
+	GetDeploymentLock(projectName, appName, envName string) (*DeploymentLock, error)
+	ReleaseDeploymentLock(projectName, appName, envName string) error
+}
@@ -7,5 +7,6 @@ package archer
 type Manifest interface {
 	Marshal() ([]byte, error)
 	DockerfilePath() string
+	ImageLocation() string
 	AppName() string
 }
@@ -3,10 +3,22 @@
 
 package archer
 
+import "time"
+
+// Secret is the metadata of a secret, it never holds the secret's value
+type Secret struct {
+	Name         string    `json:"name"`    // Name of the secret in the underlying secret management store.
+	Version      int64     `json:"version"` // Incremented each time the value changes, 0 if the store doesn't number versions.
+	LastModified time.Time `json:"lastModified"`
+}
+
 // Secretsmanager can manage secrets in an underlying secret management store
 type SecretsManager interface {
 	SecretCreator
 	SecretDeleter
+	SecretLister
+	SecretGetter
+	SecretUpdater
 }
 
 // SecretCreator creates a secret in the underlying secret management store
@@ -18,3 +30,35 @@ type SecretCreator interface {
 type SecretDeleter interface {
 	DeleteSecret(secretName string) error
 }
+
+// SecretLister lists the secrets whose names start with a path in the underlying secret management store
+type SecretLister interface {
+	ListSecrets(path string) ([]*Secret, error)
+}
+
+// SecretGetter gets the metadata or the value of a secret in the underlying secret management store
+type SecretGetter interface {
+	GetSecret(secretName string) (*Secret, error)
+	GetSecretValue(secretName string) (string, error)
+}
+
+// SecretUpdater replaces the value of an existing secret in the underlying secret management store
+type SecretUpdater interface {
+	UpdateSecret(secretName, secretString string) error
+}
+
+// SecretRotator rotates a secret with the rotation function configured in the underlying secret management store
+type SecretRotator interface {
+	RotateSecret(secretName string) error
+}
+
+// SecretForceDeleter deletes a secret right away in an underlying secret management store that otherwise keeps
+// deleted secrets for a recovery window
+type SecretForceDeleter interface {
+	ForceDeleteSecret(secretName string) error
+}
+
+// SecretBackends returns the secret management store of a backend, such as SSM Parameter Store or Secrets Manager
+type SecretBackends interface {
+	SecretBackend(name string) (SecretsManager, error)
+}
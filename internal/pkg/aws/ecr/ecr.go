@@ -134,6 +134,26 @@ func (s Service) ListImages(repoName string) ([]Image, error) {
 	return images, nil
 }
 
+// ImageDigest calls the ECR DescribeImages API and returns the digest of the image with
+// the input tag in the input ECR repository name.
+func (s Service) ImageDigest(repoName, tag string) (string, error) {
+	resp, err := s.ecr.DescribeImages(&ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repoName),
+		ImageIds: []*ecr.ImageIdentifier{
+			{
+				ImageTag: aws.String(tag),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("ecr repo %s describe image with tag %s: %w", repoName, tag, err)
+	}
+	if len(resp.ImageDetails) == 0 {
+		return "", fmt.Errorf("no image with tag %s found in ecr repo %s", tag, repoName)
+	}
+	return aws.StringValue(resp.ImageDetails[0].ImageDigest), nil
+}
+
 // DeleteImages calls the ECR BatchDeleteImage API with the input image list and repository name.
 func (s Service) DeleteImages(images []Image, repoName string) error {
 	if len(images) == 0 {
@@ -259,6 +259,72 @@ func TestListImages(t *testing.T) {
 	}
 }
 
+func TestImageDigest(t *testing.T) {
+	mockRepoName := "mockRepoName"
+	mockTag := "mockTag"
+	mockError := errors.New("mockError")
+	mockDigest := "sha256:mockDigest"
+
+	tests := map[string]struct {
+		mockECRClient func(m *mocks.MockecrClient)
+
+		wantDigest string
+		wantError  error
+	}{
+		"should wrap error returned by ECR DescribeImages": {
+			mockECRClient: func(m *mocks.MockecrClient) {
+				m.EXPECT().DescribeImages(gomock.Any()).Return(nil, mockError)
+			},
+			wantError: fmt.Errorf("ecr repo %s describe image with tag %s: %w", mockRepoName, mockTag, mockError),
+		},
+		"should return error if the image is not found": {
+			mockECRClient: func(m *mocks.MockecrClient) {
+				m.EXPECT().DescribeImages(gomock.Any()).Return(&ecr.DescribeImagesOutput{}, nil)
+			},
+			wantError: fmt.Errorf("no image with tag %s found in ecr repo %s", mockTag, mockRepoName),
+		},
+		"should return the image digest": {
+			mockECRClient: func(m *mocks.MockecrClient) {
+				m.EXPECT().DescribeImages(&ecr.DescribeImagesInput{
+					RepositoryName: aws.String(mockRepoName),
+					ImageIds: []*ecr.ImageIdentifier{
+						{
+							ImageTag: aws.String(mockTag),
+						},
+					},
+				}).Return(&ecr.DescribeImagesOutput{
+					ImageDetails: []*ecr.ImageDetail{
+						{
+							ImageDigest: aws.String(mockDigest),
+						},
+					},
+				}, nil)
+			},
+			wantDigest: mockDigest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockECRAPI := mocks.NewMockecrClient(ctrl)
+			tc.mockECRClient(mockECRAPI)
+
+			service := Service{
+				mockECRAPI,
+			}
+
+			gotDigest, gotError := service.ImageDigest(mockRepoName, mockTag)
+
+			require.Equal(t, tc.wantDigest, gotDigest)
+			require.Equal(t, tc.wantError, gotError)
+		})
+	}
+}
+
 func TestDeleteImages(t *testing.T) {
 	mockRepoName := "mockRepoName"
 	mockError := errors.New("mockError")
@@ -0,0 +1,263 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ecs contains utility functions to follow the deployments of ECS services and run one-off tasks.
+package ecs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+const (
+	defaultPollInterval = 10 * time.Second
+	maxFailedTasks      = 3   // Number of failed tasks after which a deployment won't reach a steady state.
+	describeTasksLimit  = 100 // Maximum number of tasks that can be described at once.
+
+	deploymentStatusPrimary = "PRIMARY"
+)
+
+type ecsClient interface {
+	DescribeServices(*ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error)
+	ListTasks(*ecs.ListTasksInput) (*ecs.ListTasksOutput, error)
+	DescribeTasks(*ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error)
+	DescribeTaskDefinition(*ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error)
+	RegisterTaskDefinition(*ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error)
+	DeregisterTaskDefinition(*ecs.DeregisterTaskDefinitionInput) (*ecs.DeregisterTaskDefinitionOutput, error)
+	RunTask(*ecs.RunTaskInput) (*ecs.RunTaskOutput, error)
+	StopTask(*ecs.StopTaskInput) (*ecs.StopTaskOutput, error)
+	UpdateService(*ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error)
+}
+
+type elbClient interface {
+	DescribeTargetHealth(*elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error)
+}
+
+// Service wraps AWS ECS and Elastic Load Balancing clients.
+type Service struct {
+	ecs ecsClient
+	elb elbClient
+
+	pollInterval time.Duration
+}
+
+// New returns a Service configured against the input session.
+func New(s *session.Session) Service {
+	return Service{
+		ecs:          ecs.New(s),
+		elb:          elbv2.New(s),
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// ErrServiceNotStable occurs when the tasks of a service's deployment don't reach a steady state.
+type ErrServiceNotStable struct {
+	Service          string
+	Cause            string   // Why the deployment is considered failed.
+	StoppedReasons   []string // Reasons why the tasks of the deployment stopped.
+	UnhealthyTargets []string // Reasons why the tasks of the deployment are unhealthy in the load balancer.
+}
+
+func (e *ErrServiceNotStable) Error() string {
+	return fmt.Sprintf("service %s did not reach a steady state: %s", e.Service, e.Cause)
+}
+
+// WaitForSteadyState polls the service until its latest deployment runs the desired number of tasks,
+// the tasks of its previous deployments are stopped, and its targets in the load balancer are healthy.
+//
+// If the tasks of the deployment keep failing or the timeout expires, returns an ErrServiceNotStable.
+func (s Service) WaitForSteadyState(cluster, service string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		svc, err := s.describeService(cluster, service)
+		if err != nil {
+			return err
+		}
+		primary := primaryDeployment(svc)
+		if primary == nil {
+			return fmt.Errorf("service %s has no primary deployment", service)
+		}
+		stopped, err := s.stoppedTasks(cluster, service, aws.StringValue(primary.Id))
+		if err != nil {
+			return err
+		}
+		if failed := failedTasks(stopped); failed >= maxFailedTasks {
+			return &ErrServiceNotStable{
+				Service:        service,
+				Cause:          fmt.Sprintf("%d tasks failed", failed),
+				StoppedReasons: stoppedReasons(stopped),
+			}
+		}
+		unhealthy, err := s.unhealthyTargets(svc.LoadBalancers)
+		if err != nil {
+			return err
+		}
+		if len(svc.Deployments) == 1 && aws.Int64Value(primary.RunningCount) == aws.Int64Value(primary.DesiredCount) && len(unhealthy) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return &ErrServiceNotStable{
+				Service:          service,
+				Cause:            fmt.Sprintf("timed out after %s", timeout),
+				StoppedReasons:   stoppedReasons(stopped),
+				UnhealthyTargets: unhealthy,
+			}
+		}
+		time.Sleep(s.pollInterval)
+	}
+}
+
+// RestartService starts a new deployment of the service with the same task definition, so that its tasks are
+// replaced in a rolling update and read the current values of their secrets.
+func (s Service) RestartService(cluster, service string) error {
+	_, err := s.ecs.UpdateService(&ecs.UpdateServiceInput{
+		Cluster:            aws.String(cluster),
+		Service:            aws.String(service),
+		ForceNewDeployment: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("restart service %s: %w", service, err)
+	}
+	return nil
+}
+
+func (s Service) describeService(cluster, service string) (*ecs.Service, error) {
+	out, err := s.ecs.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: aws.StringSlice([]string{service}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe service %s: %w", service, err)
+	}
+	if len(out.Services) == 0 {
+		return nil, fmt.Errorf("service %s not found in cluster %s", service, cluster)
+	}
+	return out.Services[0], nil
+}
+
+// stoppedTasks returns the stopped tasks that were started by the deployment.
+func (s Service) stoppedTasks(cluster, service, deploymentID string) ([]*ecs.Task, error) {
+	var arns []*string
+	var nextToken *string
+	for {
+		out, err := s.ecs.ListTasks(&ecs.ListTasksInput{
+			Cluster:       aws.String(cluster),
+			ServiceName:   aws.String(service),
+			DesiredStatus: aws.String(ecs.DesiredStatusStopped),
+			NextToken:     nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list stopped tasks of service %s: %w", service, err)
+		}
+		arns = append(arns, out.TaskArns...)
+		nextToken = out.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+
+	var tasks []*ecs.Task
+	for start := 0; start < len(arns); start += describeTasksLimit {
+		end := start + describeTasksLimit
+		if end > len(arns) {
+			end = len(arns)
+		}
+		out, err := s.ecs.DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   arns[start:end],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe stopped tasks of service %s: %w", service, err)
+		}
+		for _, task := range out.Tasks {
+			// The tasks of a service are started by its deployments.
+			if aws.StringValue(task.StartedBy) == deploymentID {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// unhealthyTargets returns the reasons why targets of the load balancers aren't healthy.
+func (s Service) unhealthyTargets(lbs []*ecs.LoadBalancer) ([]string, error) {
+	var reasons []string
+	for _, lb := range lbs {
+		if lb.TargetGroupArn == nil {
+			continue
+		}
+		out, err := s.elb.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: lb.TargetGroupArn,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe health of targets in %s: %w", aws.StringValue(lb.TargetGroupArn), err)
+		}
+		for _, desc := range out.TargetHealthDescriptions {
+			state := aws.StringValue(desc.TargetHealth.State)
+			// Draining targets belong to the tasks being replaced.
+			if state == elbv2.TargetHealthStateEnumHealthy || state == elbv2.TargetHealthStateEnumDraining {
+				continue
+			}
+			reason := fmt.Sprintf("target %s is %s", aws.StringValue(desc.Target.Id), state)
+			if description := aws.StringValue(desc.TargetHealth.Description); description != "" {
+				reason = fmt.Sprintf("%s: %s", reason, description)
+			}
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons, nil
+}
+
+func primaryDeployment(svc *ecs.Service) *ecs.Deployment {
+	for _, deployment := range svc.Deployments {
+		if aws.StringValue(deployment.Status) == deploymentStatusPrimary {
+			return deployment
+		}
+	}
+	return nil
+}
+
+// failedTasks returns the number of tasks that stopped because they failed to start or their essential container exited.
+func failedTasks(tasks []*ecs.Task) int {
+	var failed int
+	for _, task := range tasks {
+		switch aws.StringValue(task.StopCode) {
+		case ecs.TaskStopCodeTaskFailedToStart, ecs.TaskStopCodeEssentialContainerExited:
+			failed++
+		}
+	}
+	return failed
+}
+
+// stoppedReasons returns the unique reasons why the tasks and their containers stopped.
+func stoppedReasons(tasks []*ecs.Task) []string {
+	var reasons []string
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		var details []string
+		for _, container := range task.Containers {
+			if reason := aws.StringValue(container.Reason); reason != "" {
+				details = append(details, fmt.Sprintf("container %s: %s", aws.StringValue(container.Name), reason))
+				continue
+			}
+			if code := aws.Int64Value(container.ExitCode); code != 0 {
+				details = append(details, fmt.Sprintf("container %s exited with code %d", aws.StringValue(container.Name), code))
+			}
+		}
+		reason := aws.StringValue(task.StoppedReason)
+		if len(details) > 0 {
+			reason = fmt.Sprintf("%s (%s)", reason, strings.Join(details, ", "))
+		}
+		if !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
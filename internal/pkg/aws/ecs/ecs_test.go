@@ -0,0 +1,215 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ecs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_WaitForSteadyState(t *testing.T) {
+	stableService := &ecs.DescribeServicesOutput{
+		Services: []*ecs.Service{
+			{
+				Deployments: []*ecs.Deployment{
+					{Id: aws.String("ecs-svc/2"), Status: aws.String("PRIMARY"), DesiredCount: aws.Int64(2), RunningCount: aws.Int64(2)},
+				},
+				LoadBalancers: []*ecs.LoadBalancer{
+					{TargetGroupArn: aws.String("tg")},
+				},
+			},
+		},
+	}
+	rollingService := &ecs.DescribeServicesOutput{
+		Services: []*ecs.Service{
+			{
+				Deployments: []*ecs.Deployment{
+					{Id: aws.String("ecs-svc/2"), Status: aws.String("PRIMARY"), DesiredCount: aws.Int64(2), RunningCount: aws.Int64(1)},
+					{Id: aws.String("ecs-svc/1"), Status: aws.String("ACTIVE"), DesiredCount: aws.Int64(2), RunningCount: aws.Int64(2)},
+				},
+			},
+		},
+	}
+	crashedTask := &ecs.Task{
+		StartedBy:     aws.String("ecs-svc/2"),
+		StopCode:      aws.String(ecs.TaskStopCodeEssentialContainerExited),
+		StoppedReason: aws.String("Essential container in task exited"),
+		Containers: []*ecs.Container{
+			{Name: aws.String("frontend"), ExitCode: aws.Int64(1)},
+		},
+	}
+
+	testCases := map[string]struct {
+		inTimeout time.Duration
+
+		mockECS func(m *mocks.MockecsClient)
+		mockELB func(m *mocks.MockelbClient)
+
+		wantedErr error
+	}{
+		"stable after a rolling update": {
+			inTimeout: time.Minute,
+			mockECS: func(m *mocks.MockecsClient) {
+				gomock.InOrder(
+					m.EXPECT().DescribeServices(&ecs.DescribeServicesInput{
+						Cluster:  aws.String("cluster"),
+						Services: aws.StringSlice([]string{"frontend"}),
+					}).Return(rollingService, nil),
+					m.EXPECT().DescribeServices(gomock.Any()).Return(stableService, nil),
+				)
+				m.EXPECT().ListTasks(gomock.Any()).Return(&ecs.ListTasksOutput{}, nil).Times(2)
+			},
+			mockELB: func(m *mocks.MockelbClient) {
+				m.EXPECT().DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String("tg"),
+				}).Return(&elbv2.DescribeTargetHealthOutput{
+					TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+						{Target: &elbv2.TargetDescription{Id: aws.String("10.0.0.1")}, TargetHealth: &elbv2.TargetHealth{State: aws.String("healthy")}},
+						{Target: &elbv2.TargetDescription{Id: aws.String("10.0.0.2")}, TargetHealth: &elbv2.TargetHealth{State: aws.String("draining")}},
+					},
+				}, nil)
+			},
+		},
+		"crash-looping tasks": {
+			inTimeout: time.Minute,
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(rollingService, nil)
+				m.EXPECT().ListTasks(&ecs.ListTasksInput{
+					Cluster:       aws.String("cluster"),
+					ServiceName:   aws.String("frontend"),
+					DesiredStatus: aws.String("STOPPED"),
+				}).Return(&ecs.ListTasksOutput{
+					TaskArns: aws.StringSlice([]string{"task1", "task2", "task3", "task4"}),
+				}, nil)
+				m.EXPECT().DescribeTasks(gomock.Any()).Return(&ecs.DescribeTasksOutput{
+					Tasks: []*ecs.Task{
+						crashedTask,
+						crashedTask,
+						crashedTask,
+						{StartedBy: aws.String("ecs-svc/1"), StopCode: aws.String(ecs.TaskStopCodeUserInitiated)},
+					},
+				}, nil)
+			},
+			mockELB: func(m *mocks.MockelbClient) {},
+			wantedErr: &ErrServiceNotStable{
+				Service:        "frontend",
+				Cause:          "3 tasks failed",
+				StoppedReasons: []string{"Essential container in task exited (container frontend exited with code 1)"},
+			},
+		},
+		"unhealthy targets until the timeout": {
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(stableService, nil)
+				m.EXPECT().ListTasks(gomock.Any()).Return(&ecs.ListTasksOutput{}, nil)
+			},
+			mockELB: func(m *mocks.MockelbClient) {
+				m.EXPECT().DescribeTargetHealth(gomock.Any()).Return(&elbv2.DescribeTargetHealthOutput{
+					TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+						{
+							Target:       &elbv2.TargetDescription{Id: aws.String("10.0.0.1")},
+							TargetHealth: &elbv2.TargetHealth{State: aws.String("unhealthy"), Description: aws.String("Health checks failed with these codes: [404]")},
+						},
+					},
+				}, nil)
+			},
+			wantedErr: &ErrServiceNotStable{
+				Service:          "frontend",
+				Cause:            "timed out after 0s",
+				UnhealthyTargets: []string{"target 10.0.0.1 is unhealthy: Health checks failed with these codes: [404]"},
+			},
+		},
+		"error describing the service": {
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			mockELB:   func(m *mocks.MockelbClient) {},
+			wantedErr: errors.New("describe service frontend: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockECS := mocks.NewMockecsClient(ctrl)
+			mockELB := mocks.NewMockelbClient(ctrl)
+			tc.mockECS(mockECS)
+			tc.mockELB(mockELB)
+			service := Service{
+				ecs: mockECS,
+				elb: mockELB,
+			}
+
+			// WHEN
+			err := service.WaitForSteadyState("cluster", "frontend", tc.inTimeout)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				var notStable *ErrServiceNotStable
+				if errors.As(tc.wantedErr, &notStable) {
+					require.Equal(t, tc.wantedErr, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RestartService(t *testing.T) {
+	testCases := map[string]struct {
+		mockECS func(m *mocks.MockecsClient)
+
+		wantedErr error
+	}{
+		"forces a new deployment": {
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().UpdateService(&ecs.UpdateServiceInput{
+					Cluster:            aws.String("cluster"),
+					Service:            aws.String("frontend"),
+					ForceNewDeployment: aws.Bool(true),
+				}).Return(&ecs.UpdateServiceOutput{}, nil)
+			},
+		},
+		"error updating the service": {
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().UpdateService(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("restart service frontend: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockECS := mocks.NewMockecsClient(ctrl)
+			tc.mockECS(mockECS)
+			service := Service{
+				ecs: mockECS,
+			}
+
+			// WHEN
+			err := service.RestartService("cluster", "frontend")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/pkg/aws/ecs/ecs.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	ecs "github.com/aws/aws-sdk-go/service/ecs"
+	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockecsClient is a mock of ecsClient interface
+type MockecsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockecsClientMockRecorder
+}
+
+// MockecsClientMockRecorder is the mock recorder for MockecsClient
+type MockecsClientMockRecorder struct {
+	mock *MockecsClient
+}
+
+// NewMockecsClient creates a new mock instance
+func NewMockecsClient(ctrl *gomock.Controller) *MockecsClient {
+	mock := &MockecsClient{ctrl: ctrl}
+	mock.recorder = &MockecsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockecsClient) EXPECT() *MockecsClientMockRecorder {
+	return m.recorder
+}
+
+// DescribeServices mocks base method
+func (m *MockecsClient) DescribeServices(arg0 *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeServices", arg0)
+	ret0, _ := ret[0].(*ecs.DescribeServicesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeServices indicates an expected call of DescribeServices
+func (mr *MockecsClientMockRecorder) DescribeServices(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeServices", reflect.TypeOf((*MockecsClient)(nil).DescribeServices), arg0)
+}
+
+// ListTasks mocks base method
+func (m *MockecsClient) ListTasks(arg0 *ecs.ListTasksInput) (*ecs.ListTasksOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", arg0)
+	ret0, _ := ret[0].(*ecs.ListTasksOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks
+func (mr *MockecsClientMockRecorder) ListTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockecsClient)(nil).ListTasks), arg0)
+}
+
+// DescribeTasks mocks base method
+func (m *MockecsClient) DescribeTasks(arg0 *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTasks", arg0)
+	ret0, _ := ret[0].(*ecs.DescribeTasksOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTasks indicates an expected call of DescribeTasks
+func (mr *MockecsClientMockRecorder) DescribeTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTasks", reflect.TypeOf((*MockecsClient)(nil).DescribeTasks), arg0)
+}
+
+// DescribeTaskDefinition mocks base method
+func (m *MockecsClient) DescribeTaskDefinition(arg0 *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTaskDefinition", arg0)
+	ret0, _ := ret[0].(*ecs.DescribeTaskDefinitionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTaskDefinition indicates an expected call of DescribeTaskDefinition
+func (mr *MockecsClientMockRecorder) DescribeTaskDefinition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTaskDefinition", reflect.TypeOf((*MockecsClient)(nil).DescribeTaskDefinition), arg0)
+}
+
+// RegisterTaskDefinition mocks base method
+func (m *MockecsClient) RegisterTaskDefinition(arg0 *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterTaskDefinition", arg0)
+	ret0, _ := ret[0].(*ecs.RegisterTaskDefinitionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterTaskDefinition indicates an expected call of RegisterTaskDefinition
+func (mr *MockecsClientMockRecorder) RegisterTaskDefinition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTaskDefinition", reflect.TypeOf((*MockecsClient)(nil).RegisterTaskDefinition), arg0)
+}
+
+// DeregisterTaskDefinition mocks base method
+func (m *MockecsClient) DeregisterTaskDefinition(arg0 *ecs.DeregisterTaskDefinitionInput) (*ecs.DeregisterTaskDefinitionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterTaskDefinition", arg0)
+	ret0, _ := ret[0].(*ecs.DeregisterTaskDefinitionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeregisterTaskDefinition indicates an expected call of DeregisterTaskDefinition
+func (mr *MockecsClientMockRecorder) DeregisterTaskDefinition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterTaskDefinition", reflect.TypeOf((*MockecsClient)(nil).DeregisterTaskDefinition), arg0)
+}
+
+// RunTask mocks base method
+func (m *MockecsClient) RunTask(arg0 *ecs.RunTaskInput) (*ecs.RunTaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTask", arg0)
+	ret0, _ := ret[0].(*ecs.RunTaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunTask indicates an expected call of RunTask
+func (mr *MockecsClientMockRecorder) RunTask(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTask", reflect.TypeOf((*MockecsClient)(nil).RunTask), arg0)
+}
+
+// StopTask mocks base method
+func (m *MockecsClient) StopTask(arg0 *ecs.StopTaskInput) (*ecs.StopTaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTask", arg0)
+	ret0, _ := ret[0].(*ecs.StopTaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTask indicates an expected call of StopTask
+func (mr *MockecsClientMockRecorder) StopTask(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTask", reflect.TypeOf((*MockecsClient)(nil).StopTask), arg0)
+}
+
+// UpdateService mocks base method
+func (m *MockecsClient) UpdateService(arg0 *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateService", arg0)
+	ret0, _ := ret[0].(*ecs.UpdateServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateService indicates an expected call of UpdateService
+func (mr *MockecsClientMockRecorder) UpdateService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockecsClient)(nil).UpdateService), arg0)
+}
+
+// MockelbClient is a mock of elbClient interface
+type MockelbClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockelbClientMockRecorder
+}
+
+// MockelbClientMockRecorder is the mock recorder for MockelbClient
+type MockelbClientMockRecorder struct {
+	mock *MockelbClient
+}
+
+// NewMockelbClient creates a new mock instance
+func NewMockelbClient(ctrl *gomock.Controller) *MockelbClient {
+	mock := &MockelbClient{ctrl: ctrl}
+	mock.recorder = &MockelbClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockelbClient) EXPECT() *MockelbClientMockRecorder {
+	return m.recorder
+}
+
+// DescribeTargetHealth mocks base method
+func (m *MockelbClient) DescribeTargetHealth(arg0 *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTargetHealth", arg0)
+	ret0, _ := ret[0].(*elbv2.DescribeTargetHealthOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTargetHealth indicates an expected call of DescribeTargetHealth
+func (mr *MockelbClientMockRecorder) DescribeTargetHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetHealth", reflect.TypeOf((*MockelbClient)(nil).DescribeTargetHealth), arg0)
+}
@@ -0,0 +1,263 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ecs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+const (
+	taskStartedBy        = "dw_run.sh" // Marks the one-off tasks started by the CLI.
+	taskStatusStopped    = "STOPPED"
+	taskStopTimeoutCause = "Timed out"
+
+	// Suffix of the family of the task definitions registered to run one-off tasks with another image.
+	// They are kept out of the service's family, which is managed by CloudFormation.
+	oneOffTaskFamilySuffix = "-one-off"
+)
+
+// RunTaskInput holds the configuration of a one-off task run like the tasks of a service.
+type RunTaskInput struct {
+	Cluster    string
+	Service    string          // Name of the service whose task definition and network configuration are used.
+	Container  string          // Name of the container running the command.
+	Containers []TaskContainer // Containers replacing the ones with the same name in the service's task definition.
+	Command    []string        // Command overriding the one of the container.
+	Timeout    time.Duration   // Duration after which the task is stopped.
+}
+
+// TaskContainer holds the configuration of a container of a one-off task, for example rendered for a new deployment of the service.
+type TaskContainer struct {
+	Name                 string
+	Image                string
+	Environment          map[string]string // Values of the environment variables by name.
+	InheritedEnvironment []string          // Names of the environment variables whose values are copied from the service's task definition.
+	Secrets              map[string]string // ARNs of the secrets by the name of the environment variable they are injected in.
+}
+
+// Task is a one-off task that stopped.
+type Task struct {
+	ID        string
+	StartedAt time.Time
+}
+
+// ErrTaskFailed occurs when the container of a one-off task doesn't exit successfully.
+type ErrTaskFailed struct {
+	TaskID string
+	Reason string
+}
+
+func (e *ErrTaskFailed) Error() string {
+	return fmt.Sprintf("task %s failed: %s", e.TaskID, e.Reason)
+}
+
+// RunTask runs a one-off task with the task definition and network configuration of a service, and waits for it to stop.
+// The task runs a copy of the service's task definition where the input containers replace the ones with the same name,
+// registered in a separate family for the duration of the task.
+//
+// If the container doesn't exit with a zero code, returns an ErrTaskFailed along with the task.
+func (s Service) RunTask(in RunTaskInput) (task *Task, err error) {
+	svc, err := s.describeService(in.Cluster, in.Service)
+	if err != nil {
+		return nil, err
+	}
+	taskDef, err := s.registerOneOffTaskDefinition(aws.StringValue(svc.TaskDefinition), in.Container, in.Containers)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, deregErr := s.ecs.DeregisterTaskDefinition(&ecs.DeregisterTaskDefinitionInput{
+			TaskDefinition: aws.String(taskDef),
+		}); deregErr != nil && err == nil {
+			err = fmt.Errorf("deregister task definition %s: %w", taskDef, deregErr)
+		}
+	}()
+	out, err := s.ecs.RunTask(&ecs.RunTaskInput{
+		Cluster:              aws.String(in.Cluster),
+		TaskDefinition:       aws.String(taskDef),
+		LaunchType:           svc.LaunchType,
+		PlatformVersion:      svc.PlatformVersion,
+		NetworkConfiguration: svc.NetworkConfiguration,
+		Overrides: &ecs.TaskOverride{
+			ContainerOverrides: []*ecs.ContainerOverride{
+				{
+					Name:    aws.String(in.Container),
+					Command: aws.StringSlice(in.Command),
+				},
+			},
+		},
+		StartedBy: aws.String(taskStartedBy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run task of service %s: %w", in.Service, err)
+	}
+	if len(out.Tasks) == 0 {
+		return nil, fmt.Errorf("run task of service %s: %s", in.Service, failureReasons(out.Failures))
+	}
+	arn := aws.StringValue(out.Tasks[0].TaskArn)
+	task = &Task{
+		ID:        arn[strings.LastIndex(arn, "/")+1:],
+		StartedAt: time.Now(),
+	}
+
+	stopped, err := s.waitForTaskToStop(in.Cluster, arn, in.Timeout)
+	if err != nil {
+		return task, err
+	}
+	for _, container := range stopped.Containers {
+		if aws.StringValue(container.Name) != in.Container {
+			continue
+		}
+		if container.ExitCode == nil {
+			return task, &ErrTaskFailed{TaskID: task.ID, Reason: stoppedReasons([]*ecs.Task{stopped})[0]}
+		}
+		if code := aws.Int64Value(container.ExitCode); code != 0 {
+			return task, &ErrTaskFailed{TaskID: task.ID, Reason: fmt.Sprintf("container %s exited with code %d", in.Container, code)}
+		}
+	}
+	return task, nil
+}
+
+// failureReasons describes why no task was started.
+func failureReasons(failures []*ecs.Failure) string {
+	if len(failures) == 0 {
+		return "no task was started"
+	}
+	var reasons []string
+	for _, failure := range failures {
+		reason := aws.StringValue(failure.Reason)
+		if detail := aws.StringValue(failure.Detail); detail != "" {
+			reason = fmt.Sprintf("%s (%s)", reason, detail)
+		}
+		reasons = append(reasons, reason)
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// registerOneOffTaskDefinition registers a copy of the task definition where the containers replace the ones with the
+// same name, and returns the ARN of the copy. The copy must run the container of the command.
+// Containers missing from the task definition are left out, since the task definition can't configure them.
+func (s Service) registerOneOffTaskDefinition(taskDefARN, container string, containers []TaskContainer) (string, error) {
+	out, err := s.ecs.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(taskDefARN),
+	})
+	if err != nil {
+		return "", fmt.Errorf("describe task definition %s: %w", taskDefARN, err)
+	}
+	def := out.TaskDefinition
+	var found bool
+	for _, c := range def.ContainerDefinitions {
+		if aws.StringValue(c.Name) == container {
+			found = true
+		}
+		for _, replacement := range containers {
+			if replacement.Name == aws.StringValue(c.Name) {
+				replaceContainer(c, replacement)
+			}
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("container %s not found in task definition %s", container, taskDefARN)
+	}
+
+	registered, err := s.ecs.RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		Family:                  aws.String(aws.StringValue(def.Family) + oneOffTaskFamilySuffix),
+		ContainerDefinitions:    def.ContainerDefinitions,
+		Cpu:                     def.Cpu,
+		Memory:                  def.Memory,
+		NetworkMode:             def.NetworkMode,
+		ExecutionRoleArn:        def.ExecutionRoleArn,
+		TaskRoleArn:             def.TaskRoleArn,
+		RequiresCompatibilities: def.RequiresCompatibilities,
+		Volumes:                 def.Volumes,
+		PlacementConstraints:    def.PlacementConstraints,
+		ProxyConfiguration:      def.ProxyConfiguration,
+		IpcMode:                 def.IpcMode,
+		PidMode:                 def.PidMode,
+	})
+	if err != nil {
+		return "", fmt.Errorf("register one-off task definition of %s: %w", taskDefARN, err)
+	}
+	return aws.StringValue(registered.TaskDefinition.TaskDefinitionArn), nil
+}
+
+// replaceContainer sets the image, environment variables and secrets of the container definition to the ones of the container.
+// The inherited environment variables keep their value in the container definition.
+func replaceContainer(def *ecs.ContainerDefinition, container TaskContainer) {
+	inherited := make(map[string]string)
+	for _, v := range def.Environment {
+		inherited[aws.StringValue(v.Name)] = aws.StringValue(v.Value)
+	}
+	env := make(map[string]string)
+	for name, value := range container.Environment {
+		env[name] = value
+	}
+	for _, name := range container.InheritedEnvironment {
+		if value, ok := inherited[name]; ok {
+			env[name] = value
+		}
+	}
+
+	def.Image = aws.String(container.Image)
+	def.Environment = nil
+	for _, name := range sortedKeys(env) {
+		def.Environment = append(def.Environment, &ecs.KeyValuePair{
+			Name:  aws.String(name),
+			Value: aws.String(env[name]),
+		})
+	}
+	def.Secrets = nil
+	for _, name := range sortedKeys(container.Secrets) {
+		def.Secrets = append(def.Secrets, &ecs.Secret{
+			Name:      aws.String(name),
+			ValueFrom: aws.String(container.Secrets[name]),
+		})
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// waitForTaskToStop polls the task until it stops, or stops it once the timeout expires.
+func (s Service) waitForTaskToStop(cluster, taskARN string, timeout time.Duration) (*ecs.Task, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		out, err := s.ecs.DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   aws.StringSlice([]string{taskARN}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe task %s: %w", taskARN, err)
+		}
+		if len(out.Tasks) == 0 {
+			return nil, fmt.Errorf("task %s not found in cluster %s", taskARN, cluster)
+		}
+		if task := out.Tasks[0]; aws.StringValue(task.LastStatus) == taskStatusStopped {
+			return task, nil
+		}
+		if time.Now().After(deadline) {
+			if _, err := s.ecs.StopTask(&ecs.StopTaskInput{
+				Cluster: aws.String(cluster),
+				Task:    aws.String(taskARN),
+				Reason:  aws.String(taskStopTimeoutCause),
+			}); err != nil {
+				return nil, fmt.Errorf("stop task %s: %w", taskARN, err)
+			}
+			return nil, fmt.Errorf("task %s did not stop after %s", taskARN, timeout)
+		}
+		time.Sleep(s.pollInterval)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ecs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_RunTask(t *testing.T) {
+	const (
+		newImage = "1234.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:v2"
+		oldImage = "1234.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:v1"
+	)
+	networkConf := &ecs.NetworkConfiguration{
+		AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
+			Subnets: aws.StringSlice([]string{"subnet-1"}),
+		},
+	}
+	service := &ecs.DescribeServicesOutput{
+		Services: []*ecs.Service{
+			{
+				TaskDefinition:       aws.String("arn:aws:ecs:us-west-2:1234:task-definition/frontend:1"),
+				LaunchType:           aws.String(ecs.LaunchTypeFargate),
+				NetworkConfiguration: networkConf,
+			},
+		},
+	}
+	taskDefinition := &ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family: aws.String("frontend"),
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Name:  aws.String("frontend"),
+					Image: aws.String(oldImage),
+					Environment: []*ecs.KeyValuePair{
+						{Name: aws.String("ECS_CLI_APP_NAME"), Value: aws.String("frontend")},
+						{Name: aws.String("LOG_LEVEL"), Value: aws.String("info")},
+					},
+					Secrets: []*ecs.Secret{
+						{Name: aws.String("API_KEY"), ValueFrom: aws.String("arn:aws:ssm:us-west-2:1234:parameter/api-key")},
+					},
+				},
+			},
+		},
+	}
+	registered := &ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{TaskDefinitionArn: aws.String("arn:aws:ecs:us-west-2:1234:task-definition/frontend-one-off:1")},
+	}
+	deregister := &ecs.DeregisterTaskDefinitionInput{
+		TaskDefinition: aws.String("arn:aws:ecs:us-west-2:1234:task-definition/frontend-one-off:1"),
+	}
+	runTask := &ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String("arn:aws:ecs:us-west-2:1234:task/cluster/abc123")}},
+	}
+	stoppedTask := func(exitCode int64) *ecs.DescribeTasksOutput {
+		return &ecs.DescribeTasksOutput{
+			Tasks: []*ecs.Task{
+				{
+					LastStatus: aws.String("STOPPED"),
+					Containers: []*ecs.Container{
+						{Name: aws.String("frontend"), ExitCode: aws.Int64(exitCode)},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		inContainer string
+		mockECS     func(m *mocks.MockecsClient)
+
+		wantedTaskID string
+		wantedErr    error
+	}{
+		"registers a task definition with the new containers outside of the service's family": {
+			inContainer: "frontend",
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(service, nil)
+				m.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(taskDefinition, nil)
+				m.EXPECT().RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+					Family: aws.String("frontend-one-off"),
+					ContainerDefinitions: []*ecs.ContainerDefinition{
+						{
+							Name:  aws.String("frontend"),
+							Image: aws.String(newImage),
+							Environment: []*ecs.KeyValuePair{
+								{Name: aws.String("ECS_CLI_APP_NAME"), Value: aws.String("frontend")},
+								{Name: aws.String("LOG_LEVEL"), Value: aws.String("debug")},
+							},
+							Secrets: []*ecs.Secret{
+								{Name: aws.String("GITHUB_TOKEN"), ValueFrom: aws.String("arn:aws:ssm:us-west-2:1234:parameter/github-token")},
+							},
+						},
+					},
+				}).Return(registered, nil)
+				m.EXPECT().RunTask(&ecs.RunTaskInput{
+					Cluster:              aws.String("cluster"),
+					TaskDefinition:       aws.String("arn:aws:ecs:us-west-2:1234:task-definition/frontend-one-off:1"),
+					LaunchType:           aws.String(ecs.LaunchTypeFargate),
+					NetworkConfiguration: networkConf,
+					Overrides: &ecs.TaskOverride{
+						ContainerOverrides: []*ecs.ContainerOverride{
+							{Name: aws.String("frontend"), Command: aws.StringSlice([]string{"./migrate"})},
+						},
+					},
+					StartedBy: aws.String("dw_run.sh"),
+				}).Return(runTask, nil)
+				m.EXPECT().DescribeTasks(gomock.Any()).Return(stoppedTask(0), nil)
+				m.EXPECT().DeregisterTaskDefinition(deregister).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+			},
+			wantedTaskID: "abc123",
+		},
+		"container not found": {
+			inContainer: "api",
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(service, nil)
+				m.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(taskDefinition, nil)
+			},
+			wantedErr: errors.New("container api not found in task definition arn:aws:ecs:us-west-2:1234:task-definition/frontend:1"),
+		},
+		"deregisters the task definition when the task fails to start": {
+			inContainer: "frontend",
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(service, nil)
+				m.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(taskDefinition, nil)
+				m.EXPECT().RegisterTaskDefinition(gomock.Any()).Return(registered, nil)
+				m.EXPECT().RunTask(gomock.Any()).Return(nil, errors.New("some error"))
+				m.EXPECT().DeregisterTaskDefinition(deregister).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+			},
+			wantedErr: errors.New("run task of service frontend: some error"),
+		},
+		"no task started": {
+			inContainer: "frontend",
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(service, nil)
+				m.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(taskDefinition, nil)
+				m.EXPECT().RegisterTaskDefinition(gomock.Any()).Return(registered, nil)
+				m.EXPECT().RunTask(gomock.Any()).Return(&ecs.RunTaskOutput{
+					Failures: []*ecs.Failure{
+						{Arn: aws.String("arn:aws:ecs:us-west-2:1234:container-instance/abc"), Reason: aws.String("RESOURCE:MEMORY")},
+						{Reason: aws.String("MISSING"), Detail: aws.String("capacity provider unavailable")},
+					},
+				}, nil)
+				m.EXPECT().DeregisterTaskDefinition(deregister).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+			},
+			wantedErr: errors.New("run task of service frontend: RESOURCE:MEMORY, MISSING (capacity provider unavailable)"),
+		},
+		"container exits with an error": {
+			inContainer: "frontend",
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(service, nil)
+				m.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(taskDefinition, nil)
+				m.EXPECT().RegisterTaskDefinition(gomock.Any()).Return(registered, nil)
+				m.EXPECT().RunTask(gomock.Any()).Return(runTask, nil)
+				m.EXPECT().DescribeTasks(gomock.Any()).Return(stoppedTask(1), nil)
+				m.EXPECT().DeregisterTaskDefinition(deregister).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+			},
+			wantedTaskID: "abc123",
+			wantedErr:    &ErrTaskFailed{TaskID: "abc123", Reason: "container frontend exited with code 1"},
+		},
+		"with deregister error": {
+			inContainer: "frontend",
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().DescribeServices(gomock.Any()).Return(service, nil)
+				m.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(taskDefinition, nil)
+				m.EXPECT().RegisterTaskDefinition(gomock.Any()).Return(registered, nil)
+				m.EXPECT().RunTask(gomock.Any()).Return(runTask, nil)
+				m.EXPECT().DescribeTasks(gomock.Any()).Return(stoppedTask(0), nil)
+				m.EXPECT().DeregisterTaskDefinition(deregister).Return(nil, errors.New("some error"))
+			},
+			wantedTaskID: "abc123",
+			wantedErr:    errors.New("deregister task definition arn:aws:ecs:us-west-2:1234:task-definition/frontend-one-off:1: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockECS := mocks.NewMockecsClient(ctrl)
+			tc.mockECS(mockECS)
+			service := Service{
+				ecs: mockECS,
+			}
+
+			// WHEN
+			task, err := service.RunTask(RunTaskInput{
+				Cluster:   "cluster",
+				Service:   "frontend",
+				Container: tc.inContainer,
+				Containers: []TaskContainer{
+					{
+						Name:                 "frontend",
+						Image:                newImage,
+						Environment:          map[string]string{"LOG_LEVEL": "debug"},
+						InheritedEnvironment: []string{"ECS_CLI_APP_NAME", "DB_HOST"},
+						Secrets:              map[string]string{"GITHUB_TOKEN": "arn:aws:ssm:us-west-2:1234:parameter/github-token"},
+					},
+					{
+						Name:  "nginx",
+						Image: "nginx",
+					},
+				},
+				Command: []string{"./migrate"},
+				Timeout: time.Minute,
+			})
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.wantedTaskID != "" {
+				require.Equal(t, tc.wantedTaskID, task.ID)
+			}
+		})
+	}
+}
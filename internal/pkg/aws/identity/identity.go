@@ -27,6 +27,7 @@ func New(s *session.Session) Service {
 // Caller holds information about a calling entity.
 type Caller struct {
 	RootUserARN string
+	ARN         string
 	Account     string
 	UserID      string
 }
@@ -41,6 +42,7 @@ func (s Service) Get() (Caller, error) {
 
 	return Caller{
 		RootUserARN: fmt.Sprintf("arn:aws:iam::%s:root", *out.Account),
+		ARN:         *out.Arn,
 		Account:     *out.Account,
 		UserID:      *out.UserId,
 	}, nil
@@ -52,6 +52,7 @@ func TestGetCallerArn(t *testing.T) {
 			wantIdentity: Caller{
 				Account:     mockAccount,
 				RootUserARN: fmt.Sprintf("arn:aws:iam::%s:root", mockAccount),
+				ARN:         mockARN,
 				UserID:      mockUserID,
 			},
 		},
@@ -5,6 +5,7 @@ package docker
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
@@ -27,11 +28,49 @@ func New() Service {
 	}
 }
 
-// Build will run a `docker build` command with the input uri, tag, and Dockerfile image path.
-func (s Service) Build(uri, imageTag, path string) error {
-	imageName := imageName(uri, imageTag)
+// BuildArguments holds the arguments of a `docker build` command.
+type BuildArguments struct {
+	URI        string            // URI of the repository the image is tagged for.
+	ImageTag   string            // Tag of the image.
+	Dockerfile string            // Path to the Dockerfile.
+	Context    string            // Path to the build context directory.
+	Target     string            // Stage to build in a multi-stage Dockerfile.
+	CacheFrom  []string          // Images to use as cache sources.
+	Args       map[string]string // Values of the build-time variables.
+}
+
+// Build will run a `docker build` command with the input build arguments.
+// The images to use as cache sources are pulled first, since the builder only uses local images as cache.
+// Images that can't be pulled, for example before the first push, are skipped.
+func (s Service) Build(in *BuildArguments) error {
+	for _, image := range in.CacheFrom {
+		if err := s.runner.Run("docker", []string{"pull", image}); err != nil {
+			log.Warningf("Failed to pull the cache image %s, building without it.\n", image)
+		}
+	}
+
+	args := []string{"build", "-t", imageName(in.URI, in.ImageTag)}
+	if in.Dockerfile != "" {
+		args = append(args, "-f", in.Dockerfile)
+	}
+	if in.Target != "" {
+		args = append(args, "--target", in.Target)
+	}
+	for _, image := range in.CacheFrom {
+		args = append(args, "--cache-from", image)
+	}
+	// Sort the build args so that the command is the same across runs.
+	keys := make([]string, 0, len(in.Args))
+	for k := range in.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", k, in.Args[k]))
+	}
+	args = append(args, in.Context)
 
-	err := s.runner.Run("docker", []string{"build", "-t", imageName, path})
+	err := s.runner.Run("docker", args)
 
 	if err != nil {
 		return fmt.Errorf("building image: %w", err)
@@ -71,6 +110,28 @@ func (s Service) Push(uri, imageTag string) error {
 	return nil
 }
 
+// Pull will run `docker pull` command against the repository URI with the input uri and image tag.
+func (s Service) Pull(uri, imageTag string) error {
+	err := s.runner.Run("docker", []string{"pull", imageName(uri, imageTag)})
+
+	if err != nil {
+		return fmt.Errorf("docker pull: %w", err)
+	}
+
+	return nil
+}
+
+// Tag will run `docker tag` command to give the image with the input uri and image tag the same tag in the target repository URI.
+func (s Service) Tag(uri, imageTag, targetURI string) error {
+	err := s.runner.Run("docker", []string{"tag", imageName(uri, imageTag), imageName(targetURI, imageTag)})
+
+	if err != nil {
+		return fmt.Errorf("docker tag: %w", err)
+	}
+
+	return nil
+}
+
 func imageName(uri, tag string) string {
 	return fmt.Sprintf("%s:%s", uri, tag)
 }
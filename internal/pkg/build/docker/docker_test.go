@@ -18,28 +18,69 @@ func TestBuild(t *testing.T) {
 
 	mockURI := "mockURI"
 	mockImageTag := "mockImageTag"
-	mockPath := "mockPath"
+	mockContext := "mockContext"
 
 	var mockRunner *mocks.Mockrunner
 
 	tests := map[string]struct {
+		in         *BuildArguments
 		setupMocks func(controller *gomock.Controller)
 
 		want error
 	}{
 		"wrap error returned from Run()": {
+			in: &BuildArguments{
+				URI:      mockURI,
+				ImageTag: mockImageTag,
+				Context:  mockContext,
+			},
 			setupMocks: func(controller *gomock.Controller) {
 				mockRunner = mocks.NewMockrunner(controller)
 
-				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), mockPath}).Return(mockError)
+				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), mockContext}).Return(mockError)
 			},
 			want: fmt.Errorf("building image: %w", mockError),
 		},
 		"happy path": {
+			in: &BuildArguments{
+				URI:      mockURI,
+				ImageTag: mockImageTag,
+				Context:  mockContext,
+			},
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), mockContext}).Return(nil)
+			},
+		},
+		"with all the build options, pulling the cache images that exist": {
+			in: &BuildArguments{
+				URI:        mockURI,
+				ImageTag:   mockImageTag,
+				Dockerfile: "mockContext/api/Dockerfile",
+				Context:    mockContext,
+				Target:     "release",
+				CacheFrom:  []string{"mockURI:latest", "mockURI:previous"},
+				Args: map[string]string{
+					"VERSION":   "1.2.3",
+					"NPM_TOKEN": "secret",
+				},
+			},
 			setupMocks: func(controller *gomock.Controller) {
 				mockRunner = mocks.NewMockrunner(controller)
 
-				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), mockPath}).Return(nil)
+				gomock.InOrder(
+					mockRunner.EXPECT().Run("docker", []string{"pull", "mockURI:latest"}).Return(nil),
+					mockRunner.EXPECT().Run("docker", []string{"pull", "mockURI:previous"}).Return(mockError),
+					mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag),
+						"-f", "mockContext/api/Dockerfile",
+						"--target", "release",
+						"--cache-from", "mockURI:latest",
+						"--cache-from", "mockURI:previous",
+						"--build-arg", "NPM_TOKEN=secret",
+						"--build-arg", "VERSION=1.2.3",
+						mockContext}).Return(nil),
+				)
 			},
 		},
 	}
@@ -52,7 +93,7 @@ func TestBuild(t *testing.T) {
 				runner: mockRunner,
 			}
 
-			got := s.Build(mockURI, mockImageTag, mockPath)
+			got := s.Build(test.in)
 
 			require.Equal(t, test.want, got)
 		})
@@ -151,3 +192,96 @@ func TestPush(t *testing.T) {
 		})
 	}
 }
+
+func TestPull(t *testing.T) {
+	mockError := errors.New("mockError")
+
+	mockURI := "mockURI"
+	mockImageTag := "mockImageTag"
+
+	var mockRunner *mocks.Mockrunner
+
+	tests := map[string]struct {
+		setupMocks func(controller *gomock.Controller)
+
+		want error
+	}{
+		"wrap error returned from Run()": {
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"pull", imageName(mockURI, mockImageTag)}).Return(mockError)
+			},
+			want: fmt.Errorf("docker pull: %w", mockError),
+		},
+		"happy path": {
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"pull", imageName(mockURI, mockImageTag)}).Return(nil)
+			},
+			want: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			test.setupMocks(controller)
+			s := Service{
+				runner: mockRunner,
+			}
+
+			got := s.Pull(mockURI, mockImageTag)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestTag(t *testing.T) {
+	mockError := errors.New("mockError")
+
+	mockURI := "mockURI"
+	mockImageTag := "mockImageTag"
+	mockTargetURI := "mockTargetURI"
+
+	var mockRunner *mocks.Mockrunner
+
+	tests := map[string]struct {
+		setupMocks func(controller *gomock.Controller)
+
+		want error
+	}{
+		"wrap error returned from Run()": {
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"tag", imageName(mockURI, mockImageTag), imageName(mockTargetURI, mockImageTag)}).Return(mockError)
+			},
+			want: fmt.Errorf("docker tag: %w", mockError),
+		},
+		"happy path": {
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"tag", imageName(mockURI, mockImageTag), imageName(mockTargetURI, mockImageTag)}).Return(nil)
+			},
+			want: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			test.setupMocks(controller)
+			s := Service{
+				runner: mockRunner,
+			}
+
+			got := s.Tag(mockURI, mockImageTag, mockTargetURI)
+
+			require.Equal(t, test.want, got)
+		})
+	}
+}
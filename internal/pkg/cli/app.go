@@ -26,8 +26,13 @@ An application represents an Amazon ECS service or task.`,
 	cmd.AddCommand(BuildAppListCmd())
 	cmd.AddCommand(BuildAppPackageCmd())
 	cmd.AddCommand(BuildAppDeployCmd())
+	cmd.AddCommand(BuildAppPromoteCmd())
+	cmd.AddCommand(BuildAppRollbackCmd())
+	cmd.AddCommand(BuildAppHistoryCmd())
+	cmd.AddCommand(BuildAppLockCmd())
 	cmd.AddCommand(BuildAppDeleteCmd())
 	cmd.AddCommand(BuildAppShowCmd())
+	cmd.AddCommand(BuildAppValidateCmd())
 
 	cmd.SetUsageTemplate(template.Usage)
 
@@ -5,15 +5,24 @@ package cli
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/identity"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/build/docker"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
@@ -24,8 +33,21 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultSteadyStateTimeout = 10 * time.Minute
+	recentLogLines            = 10 // Number of log lines to show when the tasks of an application don't reach a steady state.
+	deploymentLockTTL         = time.Hour
+	defaultHookTimeout        = 10 * time.Minute
+
+	// Logical IDs of the resources holding an application's tasks.
+	envClusterLogicalID = "Cluster"
+	appServiceLogicalID = "Service"
 )
 
 const (
@@ -38,22 +60,39 @@ var (
 
 type appDeployOpts struct {
 	*GlobalOpts
-	AppName  string
-	EnvName  string
-	ImageTag string
+	AppName           string
+	EnvName           string
+	ImageTag          string
+	DryRun            bool
+	ShouldOutputJSON  bool
+	RollbackOnFailure bool
+	All               bool // Deploy every application in the workspace.
+	MaxParallel       int  // Maximum number of deployments running at the same time when deploying several apps or environments.
+	Force             bool // Deploy even if the environment is locked by another deployment.
+	SkipPreDeployHook bool // Deploy without running the pre-deploy hook of the manifest.
 
 	projectService     projectService
 	workspaceService   archer.Workspace
 	ecrService         ecrService
+	ecsService         ecsService
 	dockerService      dockerService
 	runner             runner
 	appPackageCfClient projectResourcesGetter
 	appDeployCfClient  cloudformation.CloudFormation
 	sessProvider       sessionProvider
+	deployStore        deploymentStore
+	locker             deploymentLocker
+	logGetter          archer.LogGetter
+	identity           identityService
 
 	spinner progress
+	w       io.Writer
 
 	targetEnvironment *archer.Environment
+	manifest          []byte // Interpolated manifest to deploy, read from the workspace if empty.
+	gitCommit         string // Commit recorded with the deployment, read from the workspace's repository if empty.
+	imageDigest       string // Digest of the image deployed instead of its tag, if set.
+	inBatch           bool   // Deployed alongside other apps or environments, the results are summarized once all of them are done.
 }
 
 func (opts *appDeployOpts) String() string {
@@ -66,6 +105,16 @@ func (opts *appDeployOpts) init() error {
 		return fmt.Errorf("create project service: %w", err)
 	}
 	opts.projectService = projectService
+	opts.deployStore = projectService
+	opts.locker = projectService
+	opts.logGetter = projectService
+
+	// The caller owns the deployment locks and the recorded deployments.
+	defaultSess, err := session.NewProvider().Default()
+	if err != nil {
+		return fmt.Errorf("create default session: %w", err)
+	}
+	opts.identity = identity.New(defaultSess)
 
 	workspaceService, err := workspace.New()
 	if err != nil {
@@ -80,6 +129,18 @@ func (opts *appDeployOpts) Validate() error {
 	if opts.ProjectName() == "" {
 		return errNoProjectInWorkspace
 	}
+	if opts.ShouldOutputJSON && !opts.DryRun {
+		return errJSONWithoutDryRun
+	}
+	if opts.All && opts.AppName != "" {
+		return errors.New("`--all` cannot be used with `--name`")
+	}
+	if opts.DryRun && opts.isBatch() {
+		return errors.New("`--dry-run` can only be used to deploy one application to one environment")
+	}
+	if opts.isBatch() && opts.MaxParallel < 1 {
+		return fmt.Errorf("`--%s` must be at least 1", maxParallelFlag)
+	}
 	if opts.AppName != "" {
 		if err := opts.validateAppName(); err != nil {
 			return err
@@ -95,8 +156,11 @@ func (opts *appDeployOpts) Validate() error {
 
 // Ask prompts the user for any required fields that are not provided.
 func (opts *appDeployOpts) Ask() error {
-	if err := opts.askAppName(); err != nil {
-		return err
+	// Every application in the workspace is deployed with --all, so there is none to select.
+	if !opts.All {
+		if err := opts.askAppName(); err != nil {
+			return err
+		}
 	}
 	if err := opts.askEnvName(); err != nil {
 		return err
@@ -108,7 +172,13 @@ func (opts *appDeployOpts) Ask() error {
 }
 
 // Execute builds and pushes the container image for the application,
+// and deploys the application's stack to the environment.
+// In a dry run, it only shows the changes that the deployment would make to the stack.
+// When several applications or environments are selected, they are deployed concurrently.
 func (opts *appDeployOpts) Execute() error {
+	if opts.isBatch() {
+		return opts.executeBatch()
+	}
 	env, err := opts.targetEnv()
 	if err != nil {
 		return err
@@ -118,40 +188,87 @@ func (opts *appDeployOpts) Execute() error {
 	if err := opts.configureClients(); err != nil {
 		return err
 	}
+	if opts.DryRun {
+		// The change set only references the image tag, so the image doesn't need to be pushed.
+		return opts.diffStack()
+	}
 
-	repoName := fmt.Sprintf("%s/%s", opts.projectName, opts.AppName)
-
-	uri, err := opts.ecrService.GetRepository(repoName)
+	release, err := opts.acquireLock()
 	if err != nil {
-		return fmt.Errorf("get ECR repository URI: %w", err)
+		return err
 	}
+	defer release()
 
-	appDockerfilePath, err := opts.getAppDockerfilePath()
+	mf, err := opts.getAppManifest()
 	if err != nil {
 		return err
 	}
-
-	if err := opts.dockerService.Build(uri, opts.ImageTag, appDockerfilePath); err != nil {
-		return fmt.Errorf("build Dockerfile at %s with tag %s: %w", appDockerfilePath, opts.ImageTag, err)
+	// Images deployed from a location are already built, so there is nothing to push.
+	if mf.ImageLocation() == "" {
+		if err := opts.buildAndPushImage(mf); err != nil {
+			return err
+		}
 	}
 
-	auth, err := opts.ecrService.GetECRAuth()
+	return opts.deployStack()
+}
 
+// acquireLock locks the deployments of the app to the target environment, and returns a function to release the lock.
+// While another deployment holds the lock, the environment is only deployed to with --force, which takes the lock over.
+func (opts *appDeployOpts) acquireLock() (func(), error) {
+	caller, err := opts.identity.Get()
 	if err != nil {
-		return fmt.Errorf("get ECR auth data: %w", err)
+		return nil, fmt.Errorf("get identity: %w", err)
+	}
+	lock := &archer.DeploymentLock{
+		Project:    opts.ProjectName(),
+		App:        opts.AppName,
+		Env:        opts.targetEnvironment.Name,
+		Owner:      caller.ARN,
+		AcquiredAt: time.Now().UTC(),
+		TTL:        deploymentLockTTL,
+	}
+	err = opts.locker.AcquireDeploymentLock(lock)
+	var locked *store.ErrDeploymentLocked
+	if errors.As(err, &locked) {
+		if !opts.Force {
+			return nil, fmt.Errorf("%w, use --%s to deploy to environment %s anyway", err, forceFlag, opts.targetEnvironment.Name)
+		}
+		log.Warningf("Taking over the deployment lock: %v\n", err)
+		if err := opts.locker.ReleaseDeploymentLock(lock.Project, lock.App, lock.Env); err != nil {
+			return nil, err
+		}
+		err = opts.locker.AcquireDeploymentLock(lock)
 	}
-
-	opts.dockerService.Login(uri, auth.Username, auth.Password)
-
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return func() {
+		opts.releaseLock(lock)
+	}, nil
+}
 
-	if err = opts.dockerService.Push(uri, opts.ImageTag); err != nil {
-		return err
+// releaseLock releases the lock unless another deployment took it over.
+func (opts *appDeployOpts) releaseLock(lock *archer.DeploymentLock) {
+	current, err := opts.locker.GetDeploymentLock(lock.Project, lock.App, lock.Env)
+	if err == nil && (current == nil || current.Owner != lock.Owner || !current.AcquiredAt.Equal(lock.AcquiredAt)) {
+		return
+	}
+	if err == nil {
+		err = opts.locker.ReleaseDeploymentLock(lock.Project, lock.App, lock.Env)
 	}
+	if err != nil {
+		log.Warningf("Failed to release the deployment lock of %s in %s, release it with %s: %v\n", opts.AppName, lock.Env,
+			color.HighlightCode(fmt.Sprintf("dw_run.sh app lock release --name %s --env %s", opts.AppName, lock.Env)), err)
+	}
+}
 
+// deployStack deploys the app's CloudFormation stack to the target environment with the image tag.
+func (opts *appDeployOpts) deployStack() error {
 	template, err := opts.getAppDeployTemplate()
+	if err != nil {
+		return err
+	}
 
 	id, err := uuid.NewRandom()
 	if err != nil {
@@ -160,22 +277,534 @@ func (opts *appDeployOpts) Execute() error {
 	stackName := stack.NameForApp(opts.ProjectName(), opts.targetEnvironment.Name, opts.AppName)
 	changeSetName := fmt.Sprintf("%s-%s", stackName, id)
 
+	resourceCounts, err := countAppResources(template)
+	if err != nil {
+		return err
+	}
+	mf, err := manifest.UnmarshalApp(opts.manifest)
+	if err != nil {
+		return fmt.Errorf("unmarshal app manifest: %w", err)
+	}
+	var hooks manifest.DeployHooks
+	if conf, ok := mf.(deployHooksConfigurer); ok {
+		hooks = conf.DeployHooks(opts.targetEnvironment.Name)
+	}
+	var previousTemplate string
+	if opts.RollbackOnFailure || (hooks.PreDeploy != nil && !opts.SkipPreDeployHook) {
+		previousTemplate, err = opts.previousTemplate(stackName)
+		if err != nil {
+			return err
+		}
+	}
+	if err := opts.runPreDeployHook(hooks.PreDeploy, template, stackName, previousTemplate); err != nil {
+		return err
+	}
+	deployedAt := time.Now()
+
 	opts.spinner.Start(
 		fmt.Sprintf("Deploying %s to %s.",
 			fmt.Sprintf("%s:%s", color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.ImageTag)),
 			color.HighlightUserInput(opts.targetEnvironment.Name)))
 
+	stackEvents, responses := opts.appDeployCfClient.StreamAppDeployment(template, stackName, changeSetName, opts.targetEnvironment.ExecutionRoleARN, opts.stackTags())
+	var lastEvents []deploy.ResourceEvent
+	for events := range stackEvents {
+		lastEvents = events
+		opts.spinner.Events(humanizeAppEvents(events, resourceCounts))
+	}
+	if err := <-responses; err != nil {
+		opts.spinner.Stop("Error!")
+		if failed, ok := firstFailedResource(lastEvents); ok {
+			return fmt.Errorf("deploy application: %s (%s) failed: %s", failed.LogicalName, failed.Type, failed.StatusReason)
+		}
+		return fmt.Errorf("deploy application: %w", err)
+	}
+	opts.spinner.Stop("")
+
+	err = opts.waitForSteadyState(stackName, deployedAt)
+	if err == nil && hooks.PostDeploy != nil {
+		err = opts.runHook(postDeployHook, hooks.PostDeploy, template, stackName)
+	}
+	if err != nil {
+		if opts.RollbackOnFailure {
+			if rollbackErr := opts.rollbackStack(stackName, previousTemplate); rollbackErr != nil {
+				log.Errorf("Failed to roll back %s: %v\n", opts.AppName, rollbackErr)
+			}
+		}
+		return err
+	}
+
+	if err := opts.recordDeployment(); err != nil {
+		// The application is deployed, only rolling back to this deployment won't be possible.
+		log.Warningf("Failed to record the deployment in the application's history: %v\n", err)
+	}
+	if opts.inBatch {
+		return nil
+	}
+	return opts.showAppURI()
+}
+
+// previousTemplate returns the template the app's stack is deployed with, or an empty string if the app isn't deployed yet.
+func (opts *appDeployOpts) previousTemplate(stackName string) (string, error) {
+	template, err := opts.appDeployCfClient.StackTemplate(stackName)
+	if err != nil {
+		var notFound *cloudformation.ErrStackNotFound
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return template, nil
+}
+
+// deploymentConfigurer is implemented by the manifests of apps whose tasks run continuously in an ECS service.
+type deploymentConfigurer interface {
+	DeploymentConfig(envName string) manifest.DeploymentConfig
+}
+
+// waitForSteadyState waits until the tasks of the app's service are running and healthy.
+// If they don't get there, shows why the tasks stopped and the last lines of the app's logs since the deployment.
+func (opts *appDeployOpts) waitForSteadyState(stackName string, deployedAt time.Time) error {
+	mf, err := manifest.UnmarshalApp(opts.manifest)
+	if err != nil {
+		return fmt.Errorf("unmarshal app manifest: %w", err)
+	}
+	conf, ok := mf.(deploymentConfigurer)
+	if !ok {
+		// Jobs don't run continuously, so there is nothing to wait for.
+		return nil
+	}
+	timeout := defaultSteadyStateTimeout
+	if seconds := conf.DeploymentConfig(opts.targetEnvironment.Name).Timeout; seconds != 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	cluster, service, err := opts.serviceIDs(stackName)
+	if err != nil {
+		return err
+	}
+
+	opts.spinner.Start(fmt.Sprintf("Waiting for the tasks of %s to reach a steady state in %s.",
+		color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name)))
+	if err := opts.ecsService.WaitForSteadyState(cluster, service, timeout); err != nil {
+		opts.spinner.Stop("Error!")
+		var notStable *ecs.ErrServiceNotStable
+		if errors.As(err, &notStable) {
+			for _, reason := range notStable.StoppedReasons {
+				log.Warningf("Stopped task: %s\n", reason)
+			}
+			for _, reason := range notStable.UnhealthyTargets {
+				log.Warningf("Unhealthy %s\n", reason)
+			}
+			opts.showRecentLogs(deployedAt)
+		}
+		return fmt.Errorf("wait for the tasks of %s to reach a steady state: %w", opts.AppName, err)
+	}
+	opts.spinner.Stop("")
+	return nil
+}
+
+// serviceIDs returns the names of the cluster and the service running the tasks of the app.
+func (opts *appDeployOpts) serviceIDs(stackName string) (cluster, service string, err error) {
+	cluster, err = opts.appDeployCfClient.StackResourceID(stack.NameForEnv(opts.ProjectName(), opts.targetEnvironment.Name), envClusterLogicalID)
+	if err != nil {
+		return "", "", err
+	}
+	service, err = opts.appDeployCfClient.StackResourceID(stackName, appServiceLogicalID)
+	if err != nil {
+		return "", "", err
+	}
+	return cluster, service, nil
+}
+
+// Names of the hooks run while deploying an app.
+const (
+	preDeployHook  = "pre-deploy"
+	postDeployHook = "post-deploy"
+)
+
+// deployHooksConfigurer is implemented by the manifests of apps that can run one-off tasks while being deployed.
+type deployHooksConfigurer interface {
+	DeployHooks(envName string) manifest.DeployHooks
+}
+
+// runPreDeployHook runs the pre-deploy hook before the app's stack is updated, unless it is skipped with --skip-pre-deploy-hook.
+// The hook runs like the tasks of the app's service, so before the first deployment the stack is created without any task.
+func (opts *appDeployOpts) runPreDeployHook(hook *manifest.DeployHook, template, stackName, previousTemplate string) error {
+	if hook == nil || opts.SkipPreDeployHook {
+		return nil
+	}
+	if previousTemplate == "" {
+		if err := opts.createServiceWithoutTasks(template, stackName); err != nil {
+			return err
+		}
+	}
+	return opts.runHook(preDeployHook, hook, template, stackName)
+}
+
+// createServiceWithoutTasks creates the app's stack with the template, but with a service that doesn't run any task.
+func (opts *appDeployOpts) createServiceWithoutTasks(template, stackName string) error {
+	template, err := withoutTasks(template)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate random id for changeSet: %w", err)
+	}
+	opts.spinner.Start(fmt.Sprintf("Creating the service of %s in %s to run its %s hook.",
+		color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name), preDeployHook))
+	if err := opts.appDeployCfClient.DeployApp(template, stackName, fmt.Sprintf("%s-%s", stackName, id), opts.targetEnvironment.ExecutionRoleARN, opts.stackTags()); err != nil {
+		opts.spinner.Stop("Error!")
+		return fmt.Errorf("create service of application: %w", err)
+	}
+	opts.spinner.Stop("")
+	return nil
+}
+
+// runHook runs a one-off task with the hook's command and the containers rendered in the app's template,
+// like the tasks of the app's service. If the task fails, shows its logs.
+func (opts *appDeployOpts) runHook(name string, hook *manifest.DeployHook, template, stackName string) error {
+	containers, err := opts.hookContainers(template)
+	if err != nil {
+		return err
+	}
+	cluster, service, err := opts.serviceIDs(stackName)
+	if err != nil {
+		return err
+	}
+	timeout := defaultHookTimeout
+	if hook.Timeout != 0 {
+		timeout = time.Duration(hook.Timeout) * time.Second
+	}
+
+	opts.spinner.Start(fmt.Sprintf("Running the %s hook of %s in %s.",
+		name, color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name)))
+	task, err := opts.ecsService.RunTask(ecs.RunTaskInput{
+		Cluster:    cluster,
+		Service:    service,
+		Container:  opts.AppName,
+		Containers: containers,
+		Command:    hook.Command,
+		Timeout:    timeout,
+	})
+	if err != nil {
+		opts.spinner.Stop("Error!")
+		if task != nil {
+			opts.showTaskLogs(task)
+		}
+		return fmt.Errorf("run %s hook of %s: %w", name, opts.AppName, err)
+	}
+	opts.spinner.Stop("")
+	return nil
+}
+
+// appTemplate holds the parameters and the containers of an app's template.
+type appTemplate struct {
+	Parameters map[string]struct {
+		Default string `yaml:"Default"`
+	} `yaml:"Parameters"`
+	Resources struct {
+		TaskDefinition struct {
+			Properties struct {
+				ContainerDefinitions []struct {
+					Name        yaml.Node   `yaml:"Name"`
+					Image       yaml.Node   `yaml:"Image"`
+					Environment []yaml.Node `yaml:"Environment"`
+					Secrets     []struct {
+						Name      string    `yaml:"Name"`
+						ValueFrom yaml.Node `yaml:"ValueFrom"`
+					} `yaml:"Secrets"`
+				} `yaml:"ContainerDefinitions"`
+			} `yaml:"Properties"`
+		} `yaml:"TaskDefinition"`
+	} `yaml:"Resources"`
+}
+
+// hookContainers returns the containers of the task definition rendered in the app's template.
+// The environment variables set from the resources of the stack keep the values of the service's task definition.
+func (opts *appDeployOpts) hookContainers(template string) ([]ecs.TaskContainer, error) {
+	var tpl appTemplate
+	if err := yaml.Unmarshal([]byte(template), &tpl); err != nil {
+		return nil, fmt.Errorf("unmarshal application template: %w", err)
+	}
+	partition := endpoints.AwsPartitionID
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), opts.targetEnvironment.Region); ok {
+		partition = p.ID()
+	}
+	values := map[string]string{
+		"AWS::AccountId": opts.targetEnvironment.AccountID,
+		"AWS::Partition": partition,
+		"AWS::Region":    opts.targetEnvironment.Region,
+	}
+	for name, param := range tpl.Parameters {
+		values[name] = param.Default
+	}
+
+	var containers []ecs.TaskContainer
+	for _, def := range tpl.Resources.TaskDefinition.Properties.ContainerDefinitions {
+		name, ok := resolveTemplateValue(&def.Name, values)
+		if !ok {
+			return nil, fmt.Errorf("resolve name of container %s in application template", def.Name.Value)
+		}
+		image, ok := resolveTemplateValue(&def.Image, values)
+		if !ok {
+			return nil, fmt.Errorf("resolve image of container %s in application template", name)
+		}
+		container := ecs.TaskContainer{
+			Name:        name,
+			Image:       image,
+			Environment: make(map[string]string),
+			Secrets:     make(map[string]string),
+		}
+		for _, v := range def.Environment {
+			// Conditional variables are set from the resources of the stack, such as the endpoint of its database.
+			if v.Tag == "!If" && len(v.Content) > 1 {
+				v = *v.Content[1]
+			}
+			var env struct {
+				Name  string    `yaml:"Name"`
+				Value yaml.Node `yaml:"Value"`
+			}
+			if err := v.Decode(&env); err != nil {
+				return nil, fmt.Errorf("decode environment variable of container %s in application template: %w", name, err)
+			}
+			value, ok := resolveTemplateValue(&env.Value, values)
+			if !ok {
+				container.InheritedEnvironment = append(container.InheritedEnvironment, env.Name)
+				continue
+			}
+			container.Environment[env.Name] = value
+		}
+		for _, secret := range def.Secrets {
+			valueFrom, ok := resolveTemplateValue(&secret.ValueFrom, values)
+			if !ok {
+				return nil, fmt.Errorf("resolve secret %s of container %s in application template", secret.Name, name)
+			}
+			container.Secrets[secret.Name] = valueFrom
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// templateSubVariable matches the variables substituted by the Fn::Sub function of CloudFormation.
+var templateSubVariable = regexp.MustCompile(`\$\{([^}!]+)\}`)
+
+// resolveTemplateValue returns the value of a node of a template, if it's a scalar or it only refers to the values,
+// which hold the parameters of the template and pseudo parameters by name.
+// Returns false if the value is set from the resources of the stack.
+func resolveTemplateValue(node *yaml.Node, values map[string]string) (string, bool) {
+	if node.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	switch node.Tag {
+	case "!Ref":
+		value, ok := values[node.Value]
+		return value, ok
+	case "!Sub":
+		resolved := true
+		value := templateSubVariable.ReplaceAllStringFunc(node.Value, func(variable string) string {
+			value, ok := values[templateSubVariable.FindStringSubmatch(variable)[1]]
+			if !ok {
+				resolved = false
+			}
+			return value
+		})
+		return value, resolved
+	}
+	if !strings.HasPrefix(node.ShortTag(), "!!") {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// withoutTasks returns the app's template with a service that doesn't run any task, even when it's scaled.
+func withoutTasks(template string) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &doc); err != nil {
+		return "", fmt.Errorf("unmarshal application template: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return "", errors.New("application template is empty")
+	}
+	root := doc.Content[0]
+	if count := mappingValue(mappingValue(mappingValue(root, "Parameters"), "TaskCount"), "Default"); count != nil {
+		count.Value = "0"
+	}
+	if resources := mappingValue(root, "Resources"); resources != nil {
+		for i := 1; i < len(resources.Content); i += 2 {
+			resource := resources.Content[i]
+			if typ := mappingValue(resource, "Type"); typ == nil || typ.Value != "AWS::ApplicationAutoScaling::ScalableTarget" {
+				continue
+			}
+			if min := mappingValue(mappingValue(resource, "Properties"), "MinCapacity"); min != nil {
+				min.Tag, min.Value = "!!int", "0"
+			}
+		}
+	}
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return "", fmt.Errorf("marshal application template: %w", err)
+	}
+	return string(out), nil
+}
+
+// mappingValue returns the value of the key in the mapping node, or nil if the node isn't a mapping or doesn't have the key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// showTaskLogs shows the logs of a one-off task of the app.
+func (opts *appDeployOpts) showTaskLogs(task *ecs.Task) {
+	logID := fmt.Sprintf("%s-%s-%s", opts.ProjectName(), opts.targetEnvironment.Name, opts.AppName)
+	entries, _, err := opts.logGetter.GetLog(logID, task.StartedAt.UnixNano()/1e6)
+	if err != nil {
+		log.Warningf("Failed to get the logs of task %s: %v\n", task.ID, err)
+		return
+	}
+	if entries == nil {
+		return
+	}
+	var shown bool
+	for _, entry := range *entries {
+		// The streams of a task are named after its ID.
+		if entry.StreamName != task.ID {
+			continue
+		}
+		if !shown {
+			log.Infof("Logs of task %s:\n", task.ID)
+			shown = true
+		}
+		log.Infof("  %s\n", entry.Message)
+	}
+}
+
+// showRecentLogs shows the last lines of the app's logs since the deployment.
+func (opts *appDeployOpts) showRecentLogs(since time.Time) {
+	logID := fmt.Sprintf("%s-%s-%s", opts.ProjectName(), opts.targetEnvironment.Name, opts.AppName)
+	entries, _, err := opts.logGetter.GetLog(logID, since.UnixNano()/1e6)
+	if err != nil {
+		log.Warningf("Failed to get the logs of %s: %v\n", opts.AppName, err)
+		return
+	}
+	if entries == nil || len(*entries) == 0 {
+		return
+	}
+	lines := *entries
+	if len(lines) > recentLogLines {
+		lines = lines[len(lines)-recentLogLines:]
+	}
+	log.Infof("Last log lines of %s:\n", opts.AppName)
+	for _, entry := range lines {
+		log.Infof("  %s %s\n", color.HighlightLogStreamName(entry.StreamName), entry.Message)
+	}
+}
+
+// rollbackStack re-deploys the app's stack with the template it had before the deployment.
+func (opts *appDeployOpts) rollbackStack(stackName, template string) error {
+	if template == "" {
+		log.Warningf("There is no previous deployment of %s to %s to roll back to.\n", opts.AppName, opts.targetEnvironment.Name)
+		return nil
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate random id for changeSet: %w", err)
+	}
+	opts.spinner.Start(fmt.Sprintf("Rolling back %s in %s to its previous deployment.",
+		color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name)))
+	if err := opts.appDeployCfClient.DeployApp(template, stackName, fmt.Sprintf("%s-%s", stackName, id), opts.targetEnvironment.ExecutionRoleARN, opts.stackTags()); err != nil {
+		opts.spinner.Stop("Error!")
+		return fmt.Errorf("roll back application: %w", err)
+	}
+	opts.spinner.Stop(log.Ssuccessf("Rolled back %s in %s to its previous deployment.",
+		color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name)))
+	return nil
+}
+
+// diffStack writes the changes that deploying the app's stack to the target environment would make.
+func (opts *appDeployOpts) diffStack() error {
+	template, err := opts.getAppDeployTemplate()
+	if err != nil {
+		return err
+	}
+	stackName := stack.NameForApp(opts.ProjectName(), opts.targetEnvironment.Name, opts.AppName)
+
+	opts.spinner.Start(fmt.Sprintf("Proposing changes to %s in %s.",
+		color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name)))
+	changes, err := opts.appDeployCfClient.DiffApp(template, stackName, opts.targetEnvironment.ExecutionRoleARN, opts.stackTags())
+	if err != nil {
+		opts.spinner.Stop("Error!")
+		return fmt.Errorf("diff application: %w", err)
+	}
+	opts.spinner.Stop("")
+	return writeResourceChanges(opts.w, stackName, changes, opts.ShouldOutputJSON)
+}
+
+func (opts *appDeployOpts) stackTags() map[string]string {
 	// TODO Use the Tags() method defined in deploy/cloudformation/stack/lb_fargate_app.go
-	tags := map[string]string{
+	return map[string]string{
 		stack.ProjectTagKey: opts.ProjectName(),
 		stack.EnvTagKey:     opts.targetEnvironment.Name,
 		stack.AppTagKey:     opts.AppName,
 	}
-	if err := opts.applyAppDeployTemplate(template, stackName, changeSetName, opts.targetEnvironment.ExecutionRoleARN, tags); err != nil {
-		opts.spinner.Stop("Error!")
-		return err
+}
+
+// recordDeployment adds the deployed image and manifest to the history of the app in the target environment.
+func (opts *appDeployOpts) recordDeployment() error {
+	mf, err := manifest.UnmarshalApp(opts.manifest)
+	if err != nil {
+		return fmt.Errorf("unmarshal app manifest: %w", err)
+	}
+	digest := opts.imageDigest
+	if mf.ImageLocation() == "" && digest == "" {
+		digest, err = opts.ecrService.ImageDigest(fmt.Sprintf("%s/%s", opts.ProjectName(), opts.AppName), opts.ImageTag)
+		if err != nil {
+			return fmt.Errorf("get digest of image with tag %s: %w", opts.ImageTag, err)
+		}
+	}
+	caller, err := opts.identity.Get()
+	if err != nil {
+		return fmt.Errorf("get identity: %w", err)
+	}
+	commit := opts.gitCommit
+	if commit == "" {
+		// The commit is left empty if the workspace isn't a git repository.
+		commit, _ = getCommit(opts.runner)
+	}
+	hash := sha256.Sum256(opts.manifest)
+	return opts.deployStore.CreateDeployment(&archer.Deployment{
+		Project:      opts.ProjectName(),
+		App:          opts.AppName,
+		Env:          opts.targetEnvironment.Name,
+		ImageTag:     opts.ImageTag,
+		ImageDigest:  digest,
+		ManifestHash: hex.EncodeToString(hash[:]),
+		Manifest:     string(opts.manifest),
+		GitCommit:    commit,
+		User:         caller.ARN,
+		Time:         time.Now().UTC(),
+	})
+}
+
+// showAppURI logs where the deployed application can be reached.
+// Only load balanced web applications are reachable from the internet, for other types only the success message is logged.
+func (opts *appDeployOpts) showAppURI() error {
+	app, err := opts.projectService.GetApplication(opts.ProjectName(), opts.AppName)
+	if err != nil {
+		return fmt.Errorf("get application %s from metadata store: %w", opts.AppName, err)
+	}
+	if app.Type != manifest.LoadBalancedWebApplication {
+		log.Successf("Deployed %s to %s\n", color.HighlightUserInput(opts.AppName), color.HighlightUserInput(opts.targetEnvironment.Name))
+		return nil
 	}
-	opts.spinner.Stop("")
 
 	identifier, err := describe.NewWebAppDescriber(opts.ProjectName(), opts.AppName)
 	if err != nil {
@@ -212,20 +841,39 @@ func (opts *appDeployOpts) validateAppName() error {
 }
 
 func (opts *appDeployOpts) validateEnvName() error {
-	if _, err := opts.targetEnv(); err != nil {
-		return err
+	for _, name := range opts.envNames() {
+		if _, err := opts.getEnvironment(name); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (opts *appDeployOpts) targetEnv() (*archer.Environment, error) {
-	env, err := opts.projectService.GetEnvironment(opts.ProjectName(), opts.EnvName)
+	return opts.getEnvironment(opts.EnvName)
+}
+
+func (opts *appDeployOpts) getEnvironment(name string) (*archer.Environment, error) {
+	env, err := opts.projectService.GetEnvironment(opts.ProjectName(), name)
 	if err != nil {
-		return nil, fmt.Errorf("get environment %s from metadata store: %w", opts.EnvName, err)
+		return nil, fmt.Errorf("get environment %s from metadata store: %w", name, err)
 	}
 	return env, nil
 }
 
+// envNames returns the names of the environments to deploy to, separated by commas in the --env flag.
+func (opts *appDeployOpts) envNames() []string {
+	if opts.EnvName == "" {
+		return nil
+	}
+	return strings.Split(opts.EnvName, ",")
+}
+
+// isBatch returns true if several applications or environments are deployed at once.
+func (opts *appDeployOpts) isBatch() bool {
+	return opts.All || len(opts.envNames()) > 1
+}
+
 func (opts *appDeployOpts) workspaceAppNames() ([]string, error) {
 	apps, err := opts.workspaceService.Apps()
 	if err != nil {
@@ -337,8 +985,9 @@ func (o *appDeployOpts) configureClients() error {
 	// ECR client against tools account profile AND target environment region
 	o.ecrService = ecr.New(defaultSessEnvRegion)
 
-	// app deploy CF client against env account profile AND target environment region
+	// app deploy CF and ECS clients against env account profile AND target environment region
 	o.appDeployCfClient = cloudformation.New(envSession)
+	o.ecsService = ecs.New(envSession)
 
 	// app package CF client against tools account
 	appPackageCfSess, err := o.sessProvider.Default()
@@ -360,30 +1009,140 @@ func (opts *appDeployOpts) getAppDeployTemplate() (string, error) {
 		paramsWriter: ioutil.Discard,
 		store:        opts.projectService,
 		describer:    opts.appPackageCfClient,
+		envDescriber: describe.NewEnvDescriber(opts.ProjectName()),
 		ws:           opts.workspaceService,
+		manifest:     opts.manifest,
+		digest:       opts.imageDigest,
 		GlobalOpts:   opts.GlobalOpts,
 	}
 
 	if err := appPackage.Execute(); err != nil {
 		return "", fmt.Errorf("package application: %w", err)
 	}
+	opts.manifest = appPackage.manifest
 	return buffer.String(), nil
 }
 
-func (opts *appDeployOpts) applyAppDeployTemplate(template, stackName, changeSetName, cfExecutionRole string, tags map[string]string) error {
-	if err := opts.appDeployCfClient.DeployApp(template, stackName, changeSetName, cfExecutionRole, tags); err != nil {
-		return fmt.Errorf("deploy application: %w", err)
+// appResourceTypes holds the types of the resources whose progress is displayed under each row while deploying an application.
+var appResourceTypes = map[termprogress.Text][]string{
+	textLogGroup:     {"AWS::Logs::LogGroup"},
+	textDatabase:     {"AWS::RDS::DBCluster", "AWS::RDS::DBInstance"},
+	textTargetGroup:  {"AWS::ElasticLoadBalancingV2::TargetGroup"},
+	textListenerRule: {"AWS::ElasticLoadBalancingV2::ListenerRule"},
+	textECSService:   {"AWS::ECS::Service"},
+}
+
+// countAppResources returns the number of resources of the template displayed under each row while deploying an application.
+// Rows without any resource in the template are left out.
+func countAppResources(template string) (map[termprogress.Text]int, error) {
+	var tpl struct {
+		Resources map[string]struct {
+			Type string `yaml:"Type"`
+		} `yaml:"Resources"`
 	}
-	return nil
+	if err := yaml.Unmarshal([]byte(template), &tpl); err != nil {
+		return nil, fmt.Errorf("unmarshal application template: %w", err)
+	}
+	counts := make(map[termprogress.Text]int)
+	for _, resource := range tpl.Resources {
+		for text, types := range appResourceTypes {
+			if contains(resource.Type, types) {
+				counts[text]++
+			}
+		}
+	}
+	return counts, nil
 }
 
-func (opts *appDeployOpts) getAppDockerfilePath() (string, error) {
+// humanizeAppEvents groups the resource events of an application's deployment under the rows of the resources in its template.
+func humanizeAppEvents(resourceEvents []deploy.ResourceEvent, resourceCounts map[termprogress.Text]int) []termprogress.TabRow {
+	matcher := make(map[termprogress.Text]termprogress.ResourceMatcher)
+	wantedCounts := make(map[termprogress.Text]int) // HumanizeResourceEvents decrements the counts.
+	for text, count := range resourceCounts {
+		types := appResourceTypes[text]
+		matcher[text] = func(resource deploy.Resource) bool {
+			return contains(resource.Type, types)
+		}
+		wantedCounts[text] = count
+	}
+	return termprogress.HumanizeResourceEvents(appProgressOrder, resourceEvents, matcher, wantedCounts)
+}
+
+// firstFailedResource returns the event of the first resource that failed during a deployment.
+// The failures of the stack itself and of the resources cancelled because of another failure are not root causes, so they are skipped.
+func firstFailedResource(resourceEvents []deploy.ResourceEvent) (deploy.ResourceEvent, bool) {
+	for _, event := range resourceEvents {
+		if !strings.HasSuffix(event.Status, "FAILED") || event.Type == "AWS::CloudFormation::Stack" {
+			continue
+		}
+		if event.StatusReason == "" || strings.Contains(strings.ToLower(event.StatusReason), "cancelled") {
+			continue
+		}
+		return event, true
+	}
+	return deploy.ResourceEvent{}, false
+}
+
+// imageBuildConfigurer is implemented by the manifests of apps whose image can be built from a Dockerfile.
+type imageBuildConfigurer interface {
+	BuildConfig() *manifest.DockerBuildArgs
+}
+
+// buildAndPushImage builds the app's image with the options from its manifest and pushes it to the app's ECR repository.
+func (opts *appDeployOpts) buildAndPushImage(mf archer.Manifest) error {
+	var buildConf *manifest.DockerBuildArgs
+	if m, ok := mf.(imageBuildConfigurer); ok {
+		buildConf = m.BuildConfig()
+	}
+	if buildConf == nil {
+		return fmt.Errorf("manifest of %s must have either an image build or location", opts.AppName)
+	}
+
+	uri, err := opts.repositoryURI()
+	if err != nil {
+		return err
+	}
+
+	// Log in before building so that the images to use as cache sources can be pulled from the repository.
+	auth, err := opts.ecrService.GetECRAuth()
+	if err != nil {
+		return fmt.Errorf("get ECR auth data: %w", err)
+	}
+
+	if err := opts.dockerService.Login(uri, auth.Username, auth.Password); err != nil {
+		return err
+	}
+
+	if err := opts.dockerService.Build(&docker.BuildArguments{
+		URI:        uri,
+		ImageTag:   opts.ImageTag,
+		Dockerfile: buildConf.Dockerfile,
+		Context:    buildConf.Context,
+		Target:     buildConf.Target,
+		CacheFrom:  buildConf.CacheFrom,
+		Args:       buildConf.Args,
+	}); err != nil {
+		return fmt.Errorf("build Dockerfile at %s with tag %s: %w", buildConf.Dockerfile, opts.ImageTag, err)
+	}
+
+	return opts.dockerService.Push(uri, opts.ImageTag)
+}
+
+func (opts *appDeployOpts) repositoryURI() (string, error) {
+	uri, err := opts.ecrService.GetRepository(fmt.Sprintf("%s/%s", opts.projectName, opts.AppName))
+	if err != nil {
+		return "", fmt.Errorf("get ECR repository URI: %w", err)
+	}
+	return uri, nil
+}
+
+func (opts *appDeployOpts) getAppManifest() (archer.Manifest, error) {
 	manifestFileNames, err := opts.workspaceService.ListManifestFiles()
 	if err != nil {
-		return "", fmt.Errorf("list local manifest files: %w", err)
+		return nil, fmt.Errorf("list local manifest files: %w", err)
 	}
 	if len(manifestFileNames) == 0 {
-		return "", errNoLocalManifestsFound
+		return nil, errNoLocalManifestsFound
 	}
 
 	var targetManifestFile string
@@ -394,20 +1153,28 @@ func (opts *appDeployOpts) getAppDockerfilePath() (string, error) {
 		}
 	}
 	if targetManifestFile == "" {
-		return "", fmt.Errorf("couldn't find local manifest %s", opts.AppName)
+		return nil, fmt.Errorf("couldn't find local manifest %s", opts.AppName)
 	}
 
 	manifestBytes, err := opts.workspaceService.ReadFile(targetManifestFile)
 	if err != nil {
-		return "", fmt.Errorf("read manifest file %s: %w", targetManifestFile, err)
+		return nil, fmt.Errorf("read manifest file %s: %w", targetManifestFile, err)
+	}
+	warnOutdatedManifest(targetManifestFile, manifestBytes)
+	manifestBytes, err = manifest.NewInterpolator(opts.ProjectName(), opts.targetEnvironment.Name, opts.AppName).Interpolate(manifestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("interpolate manifest %s: %w", targetManifestFile, err)
+	}
+	if err := manifest.ValidateApp(manifestBytes); err != nil {
+		return nil, fmt.Errorf("validate manifest %s: %w", targetManifestFile, err)
 	}
 
 	mf, err := manifest.UnmarshalApp(manifestBytes)
 	if err != nil {
-		return "", fmt.Errorf("unmarshal app manifest: %w", err)
+		return nil, fmt.Errorf("unmarshal app manifest: %w", err)
 	}
 
-	return strings.TrimSuffix(mf.DockerfilePath(), "/Dockerfile"), nil
+	return mf, nil
 }
 
 // BuildAppDeployCmd builds the `app deploy` subcommand.
@@ -418,6 +1185,7 @@ func BuildAppDeployCmd() *cobra.Command {
 		dockerService: docker.New(),
 		runner:        command.New(),
 		sessProvider:  session.NewProvider(),
+		w:             os.Stdout,
 	}
 
 	cmd := &cobra.Command{
@@ -426,7 +1194,16 @@ func BuildAppDeployCmd() *cobra.Command {
 		Long:  `Deploys an application to an environment.`,
 		Example: `
   Deploys an application named "frontend" to the "dev" environment.
-  /code $ dw_run.sh app deploy --name frontend --env dev`,
+  /code $ dw_run.sh app deploy --name frontend --env dev
+
+  Shows the changes that deploying "frontend" to the "prod" environment would make, without deploying it.
+  /code $ dw_run.sh app deploy --name frontend --env prod --dry-run
+
+  Deploys "frontend" to the "prod" environment, and re-deploys the previous template if the new tasks fail.
+  /code $ dw_run.sh app deploy --name frontend --env prod --rollback-on-failure
+
+  Deploys every application in the workspace to the "test" and "staging" environments, at most 2 at a time.
+  /code $ dw_run.sh app deploy --all --env test,staging --max-parallel 2`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			if err := opts.init(); err != nil {
 				return err
@@ -443,7 +1220,8 @@ func BuildAppDeployCmd() *cobra.Command {
 			return nil
 		}),
 		PostRunE: func(cmd *cobra.Command, args []string) error {
-			if opts.targetEnvironment.Name == "prod" {
+			// Batches of deployments don't have a single target environment.
+			if !opts.DryRun && opts.targetEnvironment != nil && opts.targetEnvironment.Name == "prod" {
 				log.Infoln()
 				log.Infoln("Recommended follow-up actions:")
 				for _, followup := range opts.RecommendedActions() {
@@ -454,8 +1232,15 @@ func BuildAppDeployCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
-	cmd.Flags().StringVarP(&opts.EnvName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&opts.EnvName, envFlag, envFlagShort, "", deployEnvsFlagDescription)
 	cmd.Flags().StringVar(&opts.ImageTag, imageTagFlag, "", imageTagFlagDescription)
+	cmd.Flags().BoolVar(&opts.DryRun, dryRunFlag, false, dryRunFlagDescription)
+	cmd.Flags().BoolVar(&opts.ShouldOutputJSON, jsonFlag, false, dryRunJSONFlagDescription)
+	cmd.Flags().BoolVar(&opts.RollbackOnFailure, rollbackOnFailureFlag, false, rollbackOnFailureFlagDescription)
+	cmd.Flags().BoolVar(&opts.All, allAppsFlag, false, allAppsFlagDescription)
+	cmd.Flags().IntVar(&opts.MaxParallel, maxParallelFlag, defaultMaxParallelDeployments, maxParallelFlagDescription)
+	cmd.Flags().BoolVar(&opts.Force, forceFlag, false, forceDeployFlagDescription)
+	cmd.Flags().BoolVar(&opts.SkipPreDeployHook, skipPreDeployHookFlag, false, skipPreDeployHookFlagDescription)
 
 	return cmd
 }
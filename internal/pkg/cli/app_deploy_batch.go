@@ -0,0 +1,233 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+)
+
+const (
+	defaultMaxParallelDeployments = 4
+)
+
+// batchDeployment is the deployment of one application to one environment as part of a batch.
+type batchDeployment struct {
+	opts *appDeployOpts
+	row  *progressRow
+	err  error
+}
+
+// executeBatch deploys every selected application to every selected environment.
+// The image of each application is built once, then the deployments run concurrently, at most MaxParallel at a time.
+func (opts *appDeployOpts) executeBatch() error {
+	appNames := []string{opts.AppName}
+	if opts.All {
+		names, err := opts.workspaceAppNames()
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return errors.New("no applications found in the workspace")
+		}
+		appNames = names
+	}
+	var envs []*archer.Environment
+	for _, name := range opts.envNames() {
+		env, err := opts.getEnvironment(name)
+		if err != nil {
+			return err
+		}
+		envs = append(envs, env)
+	}
+	if opts.gitCommit == "" {
+		// Every deployment records the same commit, so the repository is only read once.
+		opts.gitCommit, _ = getCommit(opts.runner)
+	}
+
+	display := newMultiProgress(opts.spinner)
+	var deployments []*batchDeployment
+	for _, appName := range appNames {
+		for _, env := range envs {
+			row := display.Row(fmt.Sprintf("%s\t%s", appName, env.Name))
+			deployment := &batchDeployment{
+				opts: opts.batchDeploymentOpts(appName, env, row),
+				row:  row,
+			}
+			if err := deployment.opts.configureClients(); err != nil {
+				return fmt.Errorf("configure clients for %s in %s: %w", appName, env.Name, err)
+			}
+			// Lock before building so that concurrent deployments don't overwrite the image.
+			if release, err := deployment.opts.acquireLock(); err != nil {
+				deployment.err = err
+			} else {
+				defer release()
+			}
+			deployments = append(deployments, deployment)
+		}
+	}
+
+	for _, appName := range appNames {
+		var appDeployments []*batchDeployment
+		for _, deployment := range deployments {
+			if deployment.opts.AppName == appName && deployment.err == nil {
+				appDeployments = append(appDeployments, deployment)
+			}
+		}
+		if err := buildBatchImage(appDeployments); err != nil {
+			for _, deployment := range appDeployments {
+				deployment.err = err
+			}
+		}
+	}
+
+	var envNames []string
+	for _, env := range envs {
+		envNames = append(envNames, env.Name)
+	}
+	display.Start(fmt.Sprintf("Deploying %s to %s.",
+		color.HighlightUserInput(strings.Join(appNames, ", ")), color.HighlightUserInput(strings.Join(envNames, ", "))))
+	runBatchDeployments(deployments, opts.MaxParallel)
+	display.Stop("")
+	return opts.writeBatchSummary(deployments)
+}
+
+// batchDeploymentOpts returns the options to deploy an application to an environment as part of a batch.
+func (opts *appDeployOpts) batchDeploymentOpts(appName string, env *archer.Environment, row progress) *appDeployOpts {
+	return &appDeployOpts{
+		GlobalOpts:        opts.GlobalOpts,
+		AppName:           appName,
+		EnvName:           env.Name,
+		ImageTag:          opts.ImageTag,
+		RollbackOnFailure: opts.RollbackOnFailure,
+		Force:             opts.Force,
+		SkipPreDeployHook: opts.SkipPreDeployHook,
+
+		projectService:   opts.projectService,
+		workspaceService: opts.workspaceService,
+		dockerService:    opts.dockerService,
+		runner:           opts.runner,
+		sessProvider:     opts.sessProvider,
+		deployStore:      opts.deployStore,
+		logGetter:        opts.logGetter,
+		locker:           opts.locker,
+		identity:         opts.identity,
+
+		spinner: row,
+		w:       opts.w,
+
+		targetEnvironment: env,
+		gitCommit:         opts.gitCommit,
+		inBatch:           true,
+	}
+}
+
+// buildBatchImage builds the image of an application once and pushes it to the repository of each of its deployments.
+// Environments in the same region share a repository, so the image is only pushed again to the repositories of other regions.
+func buildBatchImage(deployments []*batchDeployment) error {
+	if len(deployments) == 0 {
+		return nil
+	}
+	builder := deployments[0].opts
+	mf, err := builder.getAppManifest()
+	if err != nil {
+		return err
+	}
+	if mf.ImageLocation() != "" {
+		// Images deployed from a location are already built.
+		return nil
+	}
+	log.Infof("Building the image of %s.\n", color.HighlightUserInput(builder.AppName))
+	if err := builder.buildAndPushImage(mf); err != nil {
+		return err
+	}
+	builtURI, err := builder.repositoryURI()
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments[1:] {
+		if err := deployment.opts.pushBuiltImage(builtURI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushBuiltImage pushes an image already built with the app's image tag to the app's repository, if it isn't there yet.
+func (opts *appDeployOpts) pushBuiltImage(builtURI string) error {
+	uri, err := opts.repositoryURI()
+	if err != nil {
+		return err
+	}
+	if uri == builtURI {
+		return nil
+	}
+	auth, err := opts.ecrService.GetECRAuth()
+	if err != nil {
+		return fmt.Errorf("get ECR auth data: %w", err)
+	}
+	if err := opts.dockerService.Login(uri, auth.Username, auth.Password); err != nil {
+		return err
+	}
+	if err := opts.dockerService.Tag(builtURI, opts.ImageTag, uri); err != nil {
+		return err
+	}
+	return opts.dockerService.Push(uri, opts.ImageTag)
+}
+
+// runBatchDeployments deploys the stacks of the deployments that haven't failed yet, at most workers at a time.
+func runBatchDeployments(deployments []*batchDeployment, workers int) {
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for _, deployment := range deployments {
+		if deployment.err != nil {
+			deployment.row.Stop(color.Red.Sprint("Failed"))
+			continue
+		}
+		wg.Add(1)
+		go func(deployment *batchDeployment) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := deployment.opts.deployStack(); err != nil {
+				deployment.err = err
+				deployment.row.Stop(color.Red.Sprint("Failed"))
+				return
+			}
+			deployment.row.Stop(color.Green.Sprint("Deployed"))
+		}(deployment)
+	}
+	wg.Wait()
+}
+
+// writeBatchSummary writes the result of each deployment in a table.
+// It returns an error if any of the deployments failed.
+func (opts *appDeployOpts) writeBatchSummary(deployments []*batchDeployment) error {
+	writer := tabwriter.NewWriter(opts.w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "%s\t%s\t%s\n", "Application", "Environment", "Result")
+	var failed int
+	for _, deployment := range deployments {
+		result := "Deployed"
+		if deployment.err != nil {
+			failed++
+			result = fmt.Sprintf("Failed: %v", deployment.err)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", deployment.opts.AppName, deployment.opts.EnvName, result)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deployments failed", failed, len(deployments))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+)
+
+func TestAppDeployOpts_pushBuiltImage(t *testing.T) {
+	const builtURI = "1234.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend"
+	testCases := map[string]struct {
+		mockECR    func(m *climocks.MockecrService)
+		mockDocker func(m *climocks.MockdockerService)
+
+		wantedError error
+	}{
+		"repository in the same region": {
+			mockECR: func(m *climocks.MockecrService) {
+				m.EXPECT().GetRepository("phonetool/frontend").Return(builtURI, nil)
+			},
+			mockDocker: func(m *climocks.MockdockerService) {},
+		},
+		"repository in another region": {
+			mockECR: func(m *climocks.MockecrService) {
+				m.EXPECT().GetRepository("phonetool/frontend").Return("1234.dkr.ecr.eu-west-1.amazonaws.com/phonetool/frontend", nil)
+				m.EXPECT().GetECRAuth().Return(ecr.Auth{Username: "AWS", Password: "secret"}, nil)
+			},
+			mockDocker: func(m *climocks.MockdockerService) {
+				gomock.InOrder(
+					m.EXPECT().Login("1234.dkr.ecr.eu-west-1.amazonaws.com/phonetool/frontend", "AWS", "secret").Return(nil),
+					m.EXPECT().Tag(builtURI, "v1.0", "1234.dkr.ecr.eu-west-1.amazonaws.com/phonetool/frontend").Return(nil),
+					m.EXPECT().Push("1234.dkr.ecr.eu-west-1.amazonaws.com/phonetool/frontend", "v1.0").Return(nil),
+				)
+			},
+		},
+		"with repository error": {
+			mockECR: func(m *climocks.MockecrService) {
+				m.EXPECT().GetRepository("phonetool/frontend").Return("", errors.New("some error"))
+			},
+			mockDocker:  func(m *climocks.MockdockerService) {},
+			wantedError: errors.New("get ECR repository URI: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockECR := climocks.NewMockecrService(ctrl)
+			mockDocker := climocks.NewMockdockerService(ctrl)
+			tc.mockECR(mockECR)
+			tc.mockDocker(mockDocker)
+			opts := appDeployOpts{
+				GlobalOpts: &GlobalOpts{
+					projectName: "phonetool",
+				},
+				AppName:       "frontend",
+				ImageTag:      "v1.0",
+				ecrService:    mockECR,
+				dockerService: mockDocker,
+			}
+
+			// WHEN
+			err := opts.pushBuiltImage(builtURI)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAppDeployOpts_writeBatchSummary(t *testing.T) {
+	testCases := map[string]struct {
+		inDeployments []*batchDeployment
+
+		wantedContent string
+		wantedError   error
+	}{
+		"all deployments succeeded": {
+			inDeployments: []*batchDeployment{
+				{opts: &appDeployOpts{AppName: "frontend", EnvName: "test"}},
+				{opts: &appDeployOpts{AppName: "backend", EnvName: "test"}},
+			},
+			wantedContent: "Application         Environment         Result\n" +
+				"frontend            test                Deployed\n" +
+				"backend             test                Deployed\n",
+		},
+		"some deployments failed": {
+			inDeployments: []*batchDeployment{
+				{opts: &appDeployOpts{AppName: "frontend", EnvName: "test"}},
+				{opts: &appDeployOpts{AppName: "frontend", EnvName: "prod"}, err: errors.New("deploy application: some error")},
+			},
+			wantedContent: "Application         Environment         Result\n" +
+				"frontend            test                Deployed\n" +
+				"frontend            prod                Failed: deploy application: some error\n",
+			wantedError: errors.New("1 of 2 deployments failed"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			b := &bytes.Buffer{}
+			opts := appDeployOpts{
+				w: b,
+			}
+
+			// WHEN
+			err := opts.writeBatchSummary(tc.inDeployments)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedContent, b.String())
+		})
+	}
+}
+
+func TestMultiProgress(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSpinner := climocks.NewMockprogress(ctrl)
+	gomock.InOrder(
+		mockSpinner.EXPECT().Start("Deploying."),
+		mockSpinner.EXPECT().Events([]termprogress.TabRow{
+			"  frontend\ttest\tPending",
+			"  frontend\tprod\tPending",
+		}),
+		mockSpinner.EXPECT().Events([]termprogress.TabRow{
+			"  frontend\ttest\tDeploying frontend to test.",
+			"  frontend\tprod\tPending",
+		}),
+		mockSpinner.EXPECT().Events([]termprogress.TabRow{
+			"  frontend\ttest\tDeployed",
+			"  frontend\tprod\tPending",
+		}),
+		mockSpinner.EXPECT().Stop(""),
+	)
+	display := newMultiProgress(mockSpinner)
+	test := display.Row("frontend\ttest")
+	display.Row("frontend\tprod")
+
+	// WHEN
+	display.Start("Deploying.")
+	test.Start("Deploying frontend to test.")
+	test.Stop("")
+	test.Stop("Deployed")
+	display.Stop("")
+}
@@ -7,9 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/identity"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
@@ -22,6 +28,8 @@ func TestAppDeployOpts_Validate(t *testing.T) {
 		inProjectName string
 		inAppName     string
 		inEnvName     string
+		inAll         bool
+		inDryRun      bool
 
 		mockWs    func(m *mocks.MockWorkspace)
 		mockStore func(m *climocks.MockprojectService)
@@ -34,6 +42,36 @@ func TestAppDeployOpts_Validate(t *testing.T) {
 
 			wantedError: errNoProjectInWorkspace,
 		},
+		"with --all and --name": {
+			inProjectName: "phonetool",
+			inAppName:     "frontend",
+			inAll:         true,
+			mockWs:        func(m *mocks.MockWorkspace) {},
+			mockStore:     func(m *climocks.MockprojectService) {},
+
+			wantedError: errors.New("`--all` cannot be used with `--name`"),
+		},
+		"with --dry-run and several environments": {
+			inProjectName: "phonetool",
+			inEnvName:     "test,prod",
+			inDryRun:      true,
+			mockWs:        func(m *mocks.MockWorkspace) {},
+			mockStore:     func(m *climocks.MockprojectService) {},
+
+			wantedError: errors.New("`--dry-run` can only be used to deploy one application to one environment"),
+		},
+		"with an unknown environment among several": {
+			inProjectName: "phonetool",
+			inEnvName:     "test,prod",
+			inAll:         true,
+			mockWs:        func(m *mocks.MockWorkspace) {},
+			mockStore: func(m *climocks.MockprojectService) {
+				m.EXPECT().GetEnvironment("phonetool", "test").Return(&archer.Environment{Name: "test"}, nil)
+				m.EXPECT().GetEnvironment("phonetool", "prod").Return(nil, errors.New("unknown env"))
+			},
+
+			wantedError: errors.New("get environment prod from metadata store: unknown env"),
+		},
 		"with workspace error": {
 			inProjectName: "phonetool",
 			inAppName:     "frontend",
@@ -100,6 +138,9 @@ func TestAppDeployOpts_Validate(t *testing.T) {
 				},
 				AppName:          tc.inAppName,
 				EnvName:          tc.inEnvName,
+				All:              tc.inAll,
+				DryRun:           tc.inDryRun,
+				MaxParallel:      defaultMaxParallelDeployments,
 				workspaceService: mockWs,
 				projectService:   mockStore,
 			}
@@ -299,7 +340,7 @@ func TestAppDeployOpts_Ask(t *testing.T) {
 	}
 }
 
-func TestAppDeployOpts_getAppDockerfilePath(t *testing.T) {
+func TestAppDeployOpts_getAppManifest(t *testing.T) {
 	var mockWorkspace *mocks.MockWorkspace
 
 	mockError := errors.New("mockError")
@@ -312,13 +353,19 @@ type: 'Load Balanced Web App'
 image:
   build: appA/Dockerfile
 `)
+	mockPrebuiltManifest := []byte(`name: appA
+type: 'Load Balanced Web App'
+image:
+  location: public.ecr.aws/nginx/nginx:latest
+`)
 
 	tests := map[string]struct {
 		inputApp   string
 		setupMocks func(controller *gomock.Controller)
 
-		wantPath string
-		wantErr  error
+		wantDockerfilePath string
+		wantImageLocation  string
+		wantErr            error
 	}{
 		"should wrap error returned from workspaceService ListManifestFiles()": {
 			setupMocks: func(controller *gomock.Controller) {
@@ -326,8 +373,7 @@ image:
 
 				mockWorkspace.EXPECT().ListManifestFiles().Times(1).Return(nil, mockError)
 			},
-			wantPath: "",
-			wantErr:  fmt.Errorf("list local manifest files: %w", mockError),
+			wantErr: fmt.Errorf("list local manifest files: %w", mockError),
 		},
 		"should return error if list of manifest files returned from workspaceService is empty": {
 			setupMocks: func(controller *gomock.Controller) {
@@ -335,8 +381,7 @@ image:
 
 				mockWorkspace.EXPECT().ListManifestFiles().Times(1).Return([]string{}, nil)
 			},
-			wantPath: "",
-			wantErr:  errNoLocalManifestsFound,
+			wantErr: errNoLocalManifestsFound,
 		},
 		"should return error if unable to match input app with local manifests": {
 			inputApp: "appC",
@@ -345,8 +390,7 @@ image:
 
 				mockWorkspace.EXPECT().ListManifestFiles().Times(1).Return(mockManifestList, nil)
 			},
-			wantPath: "",
-			wantErr:  fmt.Errorf("couldn't find local manifest %s", "appC"),
+			wantErr: fmt.Errorf("couldn't find local manifest %s", "appC"),
 		},
 		"should return error if workspaceService ReadFile returns error": {
 			inputApp: "appA",
@@ -358,10 +402,9 @@ image:
 					mockWorkspace.EXPECT().ReadFile("appA").Times(1).Return(nil, mockError),
 				)
 			},
-			wantPath: "",
-			wantErr:  fmt.Errorf("read manifest file %s: %w", "appA", mockError),
+			wantErr: fmt.Errorf("read manifest file %s: %w", "appA", mockError),
 		},
-		"should trim the manifest DockerfilePath if it contains /Dockerfile": {
+		"should return the manifest with its DockerfilePath": {
 			inputApp: "appA",
 			setupMocks: func(controller *gomock.Controller) {
 				mockWorkspace = mocks.NewMockWorkspace(controller)
@@ -371,8 +414,19 @@ image:
 					mockWorkspace.EXPECT().ReadFile("appA").Times(1).Return(mockManifest, nil),
 				)
 			},
-			wantPath: "appA",
-			wantErr:  nil,
+			wantDockerfilePath: "appA/Dockerfile",
+		},
+		"should return the manifest with its ImageLocation": {
+			inputApp: "appA",
+			setupMocks: func(controller *gomock.Controller) {
+				mockWorkspace = mocks.NewMockWorkspace(controller)
+
+				gomock.InOrder(
+					mockWorkspace.EXPECT().ListManifestFiles().Times(1).Return(mockManifestList, nil),
+					mockWorkspace.EXPECT().ReadFile("appA").Times(1).Return(mockPrebuiltManifest, nil),
+				)
+			},
+			wantImageLocation: "public.ecr.aws/nginx/nginx:latest",
 		},
 	}
 
@@ -382,14 +436,21 @@ image:
 			defer ctrl.Finish()
 			test.setupMocks(ctrl)
 			opts := appDeployOpts{
-				AppName:          test.inputApp,
-				workspaceService: mockWorkspace,
+				GlobalOpts: &GlobalOpts{
+					projectName: "phonetool",
+				},
+				AppName:           test.inputApp,
+				workspaceService:  mockWorkspace,
+				targetEnvironment: &archer.Environment{Name: "test"},
 			}
 
-			gotPath, gotErr := opts.getAppDockerfilePath()
+			gotManifest, gotErr := opts.getAppManifest()
 
-			require.Equal(t, test.wantPath, gotPath)
 			require.Equal(t, test.wantErr, gotErr)
+			if gotErr == nil {
+				require.Equal(t, test.wantDockerfilePath, gotManifest.DockerfilePath())
+				require.Equal(t, test.wantImageLocation, gotManifest.ImageLocation())
+			}
 		})
 	}
 }
@@ -463,3 +524,345 @@ func TestAppDeployOpts_askImageTag(t *testing.T) {
 		})
 	}
 }
+
+func TestCountAppResources(t *testing.T) {
+	// GIVEN
+	template := `Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: !Sub '/ecs/${ProjectName}-${EnvName}-${AppName}'
+  Service:
+    Type: AWS::ECS::Service
+    Properties:
+      Cluster: !ImportValue
+        'Fn::Sub': '${ProjectName}-${EnvName}-ClusterId'
+  ListenerRule0:
+    Type: AWS::ElasticLoadBalancingV2::ListenerRule
+    Properties:
+      ListenerArn: !Ref Listener
+  ListenerRule1:
+    Type: AWS::ElasticLoadBalancingV2::ListenerRule
+    Properties:
+      ListenerArn: !Ref Listener
+  ExecutionRole:
+    Type: AWS::IAM::Role`
+
+	// WHEN
+	counts, err := countAppResources(template)
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, map[termprogress.Text]int{
+		textLogGroup:     1,
+		textECSService:   1,
+		textListenerRule: 2,
+	}, counts)
+}
+
+func TestFirstFailedResource(t *testing.T) {
+	testCases := map[string]struct {
+		inEvents []deploy.ResourceEvent
+
+		wantedEvent deploy.ResourceEvent
+		wantedOK    bool
+	}{
+		"skips cancelled resources and the stack": {
+			inEvents: []deploy.ResourceEvent{
+				{Resource: deploy.Resource{LogicalName: "LogGroup", Type: "AWS::Logs::LogGroup"}, Status: "CREATE_COMPLETE"},
+				{Resource: deploy.Resource{LogicalName: "TargetGroup", Type: "AWS::ElasticLoadBalancingV2::TargetGroup"}, Status: "CREATE_FAILED", StatusReason: "Resource creation cancelled"},
+				{Resource: deploy.Resource{LogicalName: "Service", Type: "AWS::ECS::Service"}, Status: "CREATE_FAILED", StatusReason: "Invalid request provided: CreateService error: Container port 80 is not valid"},
+				{Resource: deploy.Resource{LogicalName: "phonetool-test-frontend", Type: "AWS::CloudFormation::Stack"}, Status: "ROLLBACK_IN_PROGRESS", StatusReason: "The following resource(s) failed to create: [Service]"},
+			},
+			wantedEvent: deploy.ResourceEvent{Resource: deploy.Resource{LogicalName: "Service", Type: "AWS::ECS::Service"}, Status: "CREATE_FAILED", StatusReason: "Invalid request provided: CreateService error: Container port 80 is not valid"},
+			wantedOK:    true,
+		},
+		"no failed resource": {
+			inEvents: []deploy.ResourceEvent{
+				{Resource: deploy.Resource{LogicalName: "LogGroup", Type: "AWS::Logs::LogGroup"}, Status: "CREATE_COMPLETE"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			event, ok := firstFailedResource(tc.inEvents)
+
+			// THEN
+			require.Equal(t, tc.wantedOK, ok)
+			require.Equal(t, tc.wantedEvent, event)
+		})
+	}
+}
+
+func TestAppDeployOpts_acquireLock(t *testing.T) {
+	heldLock := &archer.DeploymentLock{
+		Project:    "phonetool",
+		App:        "frontend",
+		Env:        "prod",
+		Owner:      "arn:aws:iam::1234:user/alice",
+		AcquiredAt: time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC),
+		TTL:        time.Hour,
+	}
+	testCases := map[string]struct {
+		inProd  bool
+		inForce bool
+
+		mockLocker func(m *climocks.MockdeploymentLocker)
+
+		wantedError error
+	}{
+		"not locked": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(nil)
+			},
+		},
+		"refuses to deploy to a locked production environment": {
+			inProd: true,
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(&store.ErrDeploymentLocked{Lock: heldLock})
+			},
+			wantedError: fmt.Errorf("application frontend is being deployed to environment prod by arn:aws:iam::1234:user/alice since %s, use --force to deploy to environment prod anyway",
+				heldLock.AcquiredAt.Local().Format(time.RFC3339)),
+		},
+		"refuses to deploy to other locked environments": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(&store.ErrDeploymentLocked{Lock: heldLock})
+			},
+			wantedError: fmt.Errorf("application frontend is being deployed to environment prod by arn:aws:iam::1234:user/alice since %s, use --force to deploy to environment prod anyway",
+				heldLock.AcquiredAt.Local().Format(time.RFC3339)),
+		},
+		"takes over the lock of a production environment with --force": {
+			inProd:  true,
+			inForce: true,
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				gomock.InOrder(
+					m.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(&store.ErrDeploymentLocked{Lock: heldLock}),
+					m.EXPECT().ReleaseDeploymentLock("phonetool", "frontend", "prod").Return(nil),
+					m.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(nil),
+				)
+			},
+		},
+		"with store error": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(errors.New("some error"))
+			},
+			wantedError: errors.New("some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockLocker := climocks.NewMockdeploymentLocker(ctrl)
+			mockIdentity := climocks.NewMockidentityService(ctrl)
+			mockIdentity.EXPECT().Get().Return(identity.Caller{ARN: "arn:aws:iam::1234:user/bob"}, nil)
+			tc.mockLocker(mockLocker)
+			opts := appDeployOpts{
+				GlobalOpts: &GlobalOpts{
+					projectName: "phonetool",
+				},
+				AppName:           "frontend",
+				Force:             tc.inForce,
+				locker:            mockLocker,
+				identity:          mockIdentity,
+				targetEnvironment: &archer.Environment{Name: "prod", Prod: tc.inProd},
+			}
+
+			// WHEN
+			release, err := opts.acquireLock()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, release)
+		})
+	}
+}
+
+func TestAppDeployOpts_runPreDeployHook(t *testing.T) {
+	hook := &manifest.DeployHook{Command: []string{"./migrate"}}
+	testCases := map[string]struct {
+		inHook             *manifest.DeployHook
+		inSkip             bool
+		inPreviousTemplate string
+
+		wantedError error
+	}{
+		"no hook": {},
+		"skipped hook": {
+			inHook: hook,
+			inSkip: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			opts := appDeployOpts{
+				GlobalOpts: &GlobalOpts{
+					projectName: "phonetool",
+				},
+				AppName:           "frontend",
+				SkipPreDeployHook: tc.inSkip,
+				targetEnvironment: &archer.Environment{Name: "test"},
+			}
+
+			// WHEN
+			err := opts.runPreDeployHook(tc.inHook, "", "phonetool-test-frontend", tc.inPreviousTemplate)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAppDeployOpts_hookContainers(t *testing.T) {
+	template := `Parameters:
+  ProjectName:
+    Type: String
+    Default: phonetool
+  EnvName:
+    Type: String
+    Default: test
+  AppName:
+    Type: String
+    Default: frontend
+  ContainerImage:
+    Type: String
+    Default: 1234.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend@sha256:abc
+Resources:
+  TaskDefinition:
+    Type: AWS::ECS::TaskDefinition
+    Properties:
+      ContainerDefinitions:
+        - Name: !Ref AppName
+          Image: !Ref ContainerImage
+          Environment:
+          - !If
+            - Database
+            - Name: DB_HOST
+              Value: !GetAtt RDSDatabase.Endpoint.Address
+            - !Ref "AWS::NoValue"
+          - Name: ECS_CLI_APP_NAME
+            Value: !Sub '${AppName}'
+          - Name: ECS_CLI_LB_DNS
+            Value:
+              Fn::ImportValue:
+                !Sub "${ProjectName}-${EnvName}-PublicLoadBalancerDNS"
+          - Name: WORKERS
+            Value: 4
+          Secrets:
+          - Name: GITHUB_TOKEN
+            ValueFrom: !Sub 'arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/github-token'
+          - Name: API_KEY
+            ValueFrom: arn:aws:secretsmanager:us-west-2:1234:secret:api-key
+        - Name: nginx
+          Image: nginx
+`
+	testCases := map[string]struct {
+		inTemplate string
+
+		wantedContainers []ecs.TaskContainer
+		wantedError      error
+	}{
+		"resolves the containers of the template": {
+			inTemplate: template,
+			wantedContainers: []ecs.TaskContainer{
+				{
+					Name:                 "frontend",
+					Image:                "1234.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend@sha256:abc",
+					Environment:          map[string]string{"ECS_CLI_APP_NAME": "frontend", "WORKERS": "4"},
+					InheritedEnvironment: []string{"DB_HOST", "ECS_CLI_LB_DNS"},
+					Secrets: map[string]string{
+						"GITHUB_TOKEN": "arn:aws:ssm:us-west-2:1234:parameter/github-token",
+						"API_KEY":      "arn:aws:secretsmanager:us-west-2:1234:secret:api-key",
+					},
+				},
+				{
+					Name:        "nginx",
+					Image:       "nginx",
+					Environment: map[string]string{},
+					Secrets:     map[string]string{},
+				},
+			},
+		},
+		"unresolved secret": {
+			inTemplate: `Resources:
+  TaskDefinition:
+    Properties:
+      ContainerDefinitions:
+        - Name: frontend
+          Image: nginx
+          Secrets:
+          - Name: GITHUB_TOKEN
+            ValueFrom: !Ref GitHubToken
+`,
+			wantedError: errors.New("resolve secret GITHUB_TOKEN of container frontend in application template"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			opts := appDeployOpts{
+				targetEnvironment: &archer.Environment{Name: "test", Region: "us-west-2", AccountID: "1234"},
+			}
+
+			// WHEN
+			containers, err := opts.hookContainers(tc.inTemplate)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContainers, containers)
+		})
+	}
+}
+
+func TestWithoutTasks(t *testing.T) {
+	// GIVEN
+	template := `Parameters:
+  TaskCount:
+    Type: Number
+    Default: 3
+Resources:
+  ScalableTarget:
+    Type: AWS::ApplicationAutoScaling::ScalableTarget
+    Properties:
+      MinCapacity: 2
+      MaxCapacity: 10
+      ResourceId: !Join ['/', [service, !Ref Cluster, !GetAtt Service.Name]]
+`
+
+	// WHEN
+	got, err := withoutTasks(template)
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, `Parameters:
+    TaskCount:
+        Type: Number
+        Default: 0
+Resources:
+    ScalableTarget:
+        Type: AWS::ApplicationAutoScaling::ScalableTarget
+        Properties:
+            MinCapacity: 0
+            MaxCapacity: 10
+            ResourceId: !Join ['/', [service, !Ref Cluster, !GetAtt Service.Name]]
+`, got)
+}
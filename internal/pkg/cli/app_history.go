@@ -0,0 +1,115 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/spf13/cobra"
+)
+
+// Number of characters of the digests, hashes and commits shown in the history.
+const shortIDLength = 12
+
+// historyAppOpts holds the fields to list the deployments of an application to an environment.
+type historyAppOpts struct {
+	appDeployOpts
+	ShouldOutputJSON bool
+
+	w io.Writer
+}
+
+// Ask prompts the user for any required fields that are not provided.
+func (o *historyAppOpts) Ask() error {
+	if err := o.askAppName(); err != nil {
+		return err
+	}
+	return o.askEnvName()
+}
+
+// Execute writes the deployments of the application to the environment, latest first.
+func (o *historyAppOpts) Execute() error {
+	deployments, err := o.deployStore.ListDeployments(o.ProjectName(), o.AppName, o.EnvName)
+	if err != nil {
+		return err
+	}
+	// Show the latest deployment first.
+	for i, j := 0, len(deployments)-1; i < j; i, j = i+1, j-1 {
+		deployments[i], deployments[j] = deployments[j], deployments[i]
+	}
+
+	if o.ShouldOutputJSON {
+		data, err := json.Marshal(struct {
+			Deployments []*archer.Deployment `json:"deployments"`
+		}{Deployments: deployments})
+		if err != nil {
+			return fmt.Errorf("marshal deployments: %w", err)
+		}
+		fmt.Fprintf(o.w, "%s\n", data)
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(o.w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "#", "Tag", "Digest", "Manifest", "Commit", "User", "Deployed")
+	for _, d := range deployments {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", d.ID, d.ImageTag, shortID(strings.TrimPrefix(d.ImageDigest, "sha256:")),
+			shortID(d.ManifestHash), shortID(d.GitCommit), d.User, d.Time.Local().Format(time.RFC3339))
+	}
+	return writer.Flush()
+}
+
+// shortID truncates an identifier to shortIDLength characters, or returns "-" if it's empty.
+func shortID(id string) string {
+	if id == "" {
+		return "-"
+	}
+	if len(id) > shortIDLength {
+		return id[:shortIDLength]
+	}
+	return id
+}
+
+// BuildAppHistoryCmd builds the `app history` subcommand.
+func BuildAppHistoryCmd() *cobra.Command {
+	opts := &historyAppOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts: NewGlobalOpts(),
+		},
+		w: os.Stdout,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Lists the deployments of an application to an environment.",
+		Long: `Lists the deployments of an application to an environment, latest first.
+An application can be rolled back to any of them with "app rollback".`,
+		Example: `
+  Lists the deployments of the "frontend" application to the "prod" environment.
+  /code $ dw_run.sh app history --name frontend --env prod`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.init(); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.EnvName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().BoolVar(&opts.ShouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+
+	return cmd
+}
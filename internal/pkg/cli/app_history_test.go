@@ -0,0 +1,82 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+)
+
+func TestHistoryAppOpts_Execute(t *testing.T) {
+	deployedAt := time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		inJSON bool
+
+		mockStore func(m *climocks.MockdeploymentStore)
+
+		wantedContent string
+		wantedError   error
+	}{
+		"with store error": {
+			mockStore: func(m *climocks.MockdeploymentStore) {
+				m.EXPECT().ListDeployments("phonetool", "frontend", "prod").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("some error"),
+		},
+		"json output lists the latest deployment first": {
+			inJSON: true,
+			mockStore: func(m *climocks.MockdeploymentStore) {
+				m.EXPECT().ListDeployments("phonetool", "frontend", "prod").Return([]*archer.Deployment{
+					{ID: 1, ImageTag: "v1.0", Time: deployedAt},
+					{ID: 2, ImageTag: "v1.1", Time: deployedAt},
+				}, nil)
+			},
+			wantedContent: `{"deployments":[` +
+				`{"project":"","app":"","env":"","id":2,"imageTag":"v1.1","imageDigest":"","manifestHash":"","manifest":"","gitCommit":"","user":"","time":"2020-03-01T10:00:00Z"},` +
+				`{"project":"","app":"","env":"","id":1,"imageTag":"v1.0","imageDigest":"","manifestHash":"","manifest":"","gitCommit":"","user":"","time":"2020-03-01T10:00:00Z"}]}` + "\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockStore := climocks.NewMockdeploymentStore(ctrl)
+			tc.mockStore(mockStore)
+			b := &bytes.Buffer{}
+			opts := historyAppOpts{
+				appDeployOpts: appDeployOpts{
+					GlobalOpts: &GlobalOpts{
+						projectName: "phonetool",
+					},
+					AppName:     "frontend",
+					EnvName:     "prod",
+					deployStore: mockStore,
+				},
+				ShouldOutputJSON: tc.inJSON,
+				w:                b,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedContent, b.String())
+			}
+		})
+	}
+}
@@ -162,11 +162,11 @@ func (opts *InitAppOpts) getPort() error {
 }
 
 func (opts *InitAppOpts) createManifest() (string, error) {
-	manifest, err := manifest.CreateApp(opts.AppName, opts.AppType, opts.DockerfilePath, opts.Port)
+	mft, err := manifest.CreateApp(opts.AppName, opts.AppType, opts.DockerfilePath, opts.Port)
 	if err != nil {
 		return "", fmt.Errorf("generate a manifest: %w", err)
 	}
-	manifestBytes, err := manifest.Marshal()
+	manifestBytes, err := mft.Marshal()
 	if err != nil {
 		return "", fmt.Errorf("marshal manifest: %w", err)
 	}
@@ -175,6 +175,13 @@ func (opts *InitAppOpts) createManifest() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("write manifest for app %s: %w", opts.AppName, err)
 	}
+	schema, err := manifest.AppSchema()
+	if err != nil {
+		return "", fmt.Errorf("generate manifest schema: %w", err)
+	}
+	if _, err := opts.manifestWriter.WriteFile(schema, manifest.AppSchemaFileName); err != nil {
+		return "", fmt.Errorf("write manifest schema: %w", err)
+	}
 	wkdir, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("get working directory: %w", err)
@@ -193,7 +193,7 @@ func TestAppInitOpts_Validate(t *testing.T) {
 		"invalid app type": {
 			inProjectName: "phonetool",
 			inAppType:     "TestAppType",
-			wantedErr:     errors.New(`invalid app type TestAppType: must be one of "Load Balanced Web App"`),
+			wantedErr:     errors.New(`invalid app type TestAppType: must be one of "Load Balanced Web App", "Backend App", "Scheduled Job", "Worker Service"`),
 		},
 		"invalid app name": {
 			inProjectName: "phonetool",
@@ -273,6 +273,7 @@ func TestAppInitOpts_Execute(t *testing.T) {
 				manifestFile := "/frontend-app.yml"
 				m.EXPECT().AppManifestFileName("frontend").Return(manifestFile)
 				m.EXPECT().WriteFile(gomock.Any(), manifestFile).Return("/frontend", nil)
+				m.EXPECT().WriteFile(gomock.Any(), manifest.AppSchemaFileName).Return("/app.schema.json", nil)
 			},
 			mockAppStore: func(m *mocks.MockApplicationStore) {
 				m.EXPECT().GetApplication("project", "frontend").Return(nil, &store.ErrNoSuchApplication{})
@@ -313,6 +314,7 @@ func TestAppInitOpts_Execute(t *testing.T) {
 				manifestFile := "/frontend-app.yml"
 				m.EXPECT().AppManifestFileName("frontend").Return(manifestFile)
 				m.EXPECT().WriteFile(gomock.Any(), manifestFile).Return("/frontend", nil)
+				m.EXPECT().WriteFile(gomock.Any(), manifest.AppSchemaFileName).Return("/app.schema.json", nil)
 			},
 			mockAppStore: func(m *mocks.MockApplicationStore) {
 				m.EXPECT().GetApplication("project", "frontend").Return(nil, &store.ErrNoSuchApplication{})
@@ -334,6 +336,7 @@ func TestAppInitOpts_Execute(t *testing.T) {
 				manifestFile := "/frontend-app.yml"
 				m.EXPECT().AppManifestFileName("frontend").Return(manifestFile)
 				m.EXPECT().WriteFile(gomock.Any(), manifestFile).Return("/frontend", nil)
+				m.EXPECT().WriteFile(gomock.Any(), manifest.AppSchemaFileName).Return("/app.schema.json", nil)
 			},
 			mockAppStore: func(m *mocks.MockApplicationStore) {
 				m.EXPECT().GetApplication("project", "frontend").Return(nil, &store.ErrNoSuchApplication{})
@@ -408,6 +411,7 @@ func TestAppInitOpts_Execute(t *testing.T) {
 				manifestFile := "/frontend-app.yml"
 				m.EXPECT().AppManifestFileName("frontend").Return(manifestFile)
 				m.EXPECT().WriteFile(gomock.Any(), manifestFile).Return("/frontend", nil)
+				m.EXPECT().WriteFile(gomock.Any(), manifest.AppSchemaFileName).Return("/app.schema.json", nil)
 			},
 			mockProgress: func(m *climocks.Mockprogress) {
 				m.EXPECT().Start(gomock.Any())
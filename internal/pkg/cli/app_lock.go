@@ -0,0 +1,193 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/cmd/ecs-preview/template"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fmtReleaseLockPrompt = "Are you sure you want to release the deployment lock of %s in %s held by %s since %s?"
+)
+
+var errLockReleaseCancelled = errors.New("lock release cancelled - no changes made")
+
+// statusLockOpts holds the fields to show the deployment lock of an application in an environment.
+type statusLockOpts struct {
+	appDeployOpts
+
+	w io.Writer
+}
+
+// Ask prompts the user for any required fields that are not provided.
+func (o *statusLockOpts) Ask() error {
+	if err := o.askAppName(); err != nil {
+		return err
+	}
+	return o.askEnvName()
+}
+
+// Execute writes who holds the deployment lock of the application in the environment, and until when.
+func (o *statusLockOpts) Execute() error {
+	lock, err := o.locker.GetDeploymentLock(o.ProjectName(), o.AppName, o.EnvName)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		fmt.Fprintf(o.w, "Application %s is not locked in environment %s.\n", o.AppName, o.EnvName)
+		return nil
+	}
+	fmt.Fprintf(o.w, "Application %s is locked in environment %s by %s since %s.\n",
+		lock.App, lock.Env, lock.Owner, lock.AcquiredAt.Local().Format(time.RFC3339))
+	expiresAt := lock.AcquiredAt.Add(lock.TTL).Local().Format(time.RFC3339)
+	if lock.Expired(time.Now()) {
+		fmt.Fprintf(o.w, "The lock expired at %s, the next deployment takes it over.\n", expiresAt)
+		return nil
+	}
+	fmt.Fprintf(o.w, "The lock expires at %s.\n", expiresAt)
+	return nil
+}
+
+// releaseLockOpts holds the fields to release the deployment lock of an application in an environment.
+type releaseLockOpts struct {
+	appDeployOpts
+	SkipConfirmation bool
+}
+
+// Ask prompts the user for any required fields that are not provided.
+func (o *releaseLockOpts) Ask() error {
+	if err := o.askAppName(); err != nil {
+		return err
+	}
+	return o.askEnvName()
+}
+
+// Execute releases the deployment lock of the application in the environment after confirmation.
+func (o *releaseLockOpts) Execute() error {
+	lock, err := o.locker.GetDeploymentLock(o.ProjectName(), o.AppName, o.EnvName)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		log.Infof("Application %s is not locked in environment %s.\n", color.HighlightUserInput(o.AppName), color.HighlightUserInput(o.EnvName))
+		return nil
+	}
+	if err := o.confirmRelease(lock); err != nil {
+		return err
+	}
+	if err := o.locker.ReleaseDeploymentLock(o.ProjectName(), o.AppName, o.EnvName); err != nil {
+		return err
+	}
+	log.Successf("Released the deployment lock of %s in %s.\n", color.HighlightUserInput(o.AppName), color.HighlightUserInput(o.EnvName))
+	return nil
+}
+
+func (o *releaseLockOpts) confirmRelease(lock *archer.DeploymentLock) error {
+	if o.SkipConfirmation {
+		return nil
+	}
+	release, err := o.prompt.Confirm(fmt.Sprintf(fmtReleaseLockPrompt,
+		lock.App, lock.Env, lock.Owner, lock.AcquiredAt.Local().Format(time.RFC3339)), "")
+	if err != nil {
+		return fmt.Errorf("prompt for lock release: %w", err)
+	}
+	if !release {
+		return errLockReleaseCancelled
+	}
+	return nil
+}
+
+// BuildAppLockCmd builds the `app lock` subcommand.
+func BuildAppLockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Deployment lock commands.",
+		Long: `Command for working with the deployment locks of applications.
+An application is locked in an environment while it's being deployed to it.`,
+	}
+
+	cmd.AddCommand(buildAppLockStatusCmd())
+	cmd.AddCommand(buildAppLockReleaseCmd())
+
+	cmd.SetUsageTemplate(template.Usage)
+	return cmd
+}
+
+func buildAppLockStatusCmd() *cobra.Command {
+	opts := &statusLockOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts: NewGlobalOpts(),
+		},
+		w: os.Stdout,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows who is deploying an application to an environment.",
+		Example: `
+  Shows whether the "frontend" application is being deployed to the "prod" environment.
+  /code $ dw_run.sh app lock status --name frontend --env prod`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.init(); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.EnvName, envFlag, envFlagShort, "", envFlagDescription)
+
+	return cmd
+}
+
+func buildAppLockReleaseCmd() *cobra.Command {
+	opts := &releaseLockOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts: NewGlobalOpts(),
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Releases a stale deployment lock of an application in an environment.",
+		Long: `Releases a stale deployment lock of an application in an environment.
+Only release a lock if its owner's deployment was interrupted, for example if the command was killed.`,
+		Example: `
+  Releases the deployment lock of the "frontend" application in the "prod" environment.
+  /code $ dw_run.sh app lock release --name frontend --env prod`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.init(); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.EnvName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().BoolVar(&opts.SkipConfirmation, yesFlag, false, yesFlagDescription)
+
+	return cmd
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+)
+
+func TestStatusLockOpts_Execute(t *testing.T) {
+	acquiredAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	staleAcquiredAt := acquiredAt.Add(-2 * time.Hour)
+	testCases := map[string]struct {
+		mockLocker func(m *climocks.MockdeploymentLocker)
+
+		wantedContent string
+		wantedError   error
+	}{
+		"not locked": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().GetDeploymentLock("phonetool", "frontend", "prod").Return(nil, nil)
+			},
+			wantedContent: "Application frontend is not locked in environment prod.\n",
+		},
+		"locked": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().GetDeploymentLock("phonetool", "frontend", "prod").Return(&archer.DeploymentLock{
+					App: "frontend", Env: "prod", Owner: "alice", AcquiredAt: acquiredAt, TTL: time.Hour,
+				}, nil)
+			},
+			wantedContent: fmt.Sprintf("Application frontend is locked in environment prod by alice since %s.\nThe lock expires at %s.\n",
+				acquiredAt.Local().Format(time.RFC3339), acquiredAt.Add(time.Hour).Local().Format(time.RFC3339)),
+		},
+		"stale lock": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().GetDeploymentLock("phonetool", "frontend", "prod").Return(&archer.DeploymentLock{
+					App: "frontend", Env: "prod", Owner: "alice", AcquiredAt: staleAcquiredAt, TTL: time.Hour,
+				}, nil)
+			},
+			wantedContent: fmt.Sprintf("Application frontend is locked in environment prod by alice since %s.\nThe lock expired at %s, the next deployment takes it over.\n",
+				staleAcquiredAt.Local().Format(time.RFC3339), staleAcquiredAt.Add(time.Hour).Local().Format(time.RFC3339)),
+		},
+		"with store error": {
+			mockLocker: func(m *climocks.MockdeploymentLocker) {
+				m.EXPECT().GetDeploymentLock("phonetool", "frontend", "prod").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockLocker := climocks.NewMockdeploymentLocker(ctrl)
+			tc.mockLocker(mockLocker)
+			b := &bytes.Buffer{}
+			opts := statusLockOpts{
+				appDeployOpts: appDeployOpts{
+					GlobalOpts: &GlobalOpts{
+						projectName: "phonetool",
+					},
+					AppName: "frontend",
+					EnvName: "prod",
+					locker:  mockLocker,
+				},
+				w: b,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, b.String())
+		})
+	}
+}
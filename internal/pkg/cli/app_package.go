@@ -16,9 +16,12 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -41,11 +44,17 @@ type PackageAppOpts struct {
 	ws           archer.Workspace
 	store        projectService
 	describer    projectResourcesGetter
+	envDescriber envOutputsGetter
 	stackWriter  io.Writer
 	paramsWriter io.Writer
 	fs           afero.Fs
 	runner       runner
 
+	// Interpolated manifest of the application, read from the workspace if empty.
+	manifest []byte
+	// Digest of the image deployed instead of its tag, if set.
+	digest string
+
 	*GlobalOpts // Embed global options.
 }
 
@@ -192,33 +201,53 @@ type cfnTemplates struct {
 	configuration string
 }
 
+type appStackSerializer interface {
+	Template() (string, error)
+	SerializedParameters() (string, error)
+}
+
 // getTemplates returns the CloudFormation stack's template and its parameters.
 func (o *PackageAppOpts) getTemplates(env *archer.Environment) (*cfnTemplates, error) {
-	raw, err := o.ws.ReadFile(o.ws.AppManifestFileName(o.AppName))
-	if err != nil {
-		return nil, err
+	if o.manifest == nil {
+		raw, err := o.readManifest(env)
+		if err != nil {
+			return nil, err
+		}
+		o.manifest = raw
 	}
+	raw := o.manifest
 	mft, err := manifest.UnmarshalApp(raw)
 	if err != nil {
 		return nil, err
 	}
+	var envOutputs map[string]string
+	if manifest.ReferencesEnvOutputs(raw) {
+		envOutputs, err = o.envDescriber.Outputs(env)
+		if err != nil {
+			return nil, fmt.Errorf("get outputs of environment %s: %w", env.Name, err)
+		}
+	}
 
 	proj, err := o.store.GetProject(o.ProjectName())
 	if err != nil {
 		return nil, err
 	}
-	resources, err := o.describer.GetProjectResourcesByRegion(proj, env.Region)
-	if err != nil {
-		return nil, err
-	}
-
-	repoURL, ok := resources.RepositoryURLs[o.AppName]
-	if !ok {
-		return nil, &errRepoNotFound{
-			appName:       o.AppName,
-			envRegion:     env.Region,
-			projAccountID: proj.AccountID,
+	// Pre-built images are deployed from their location, so the app doesn't need an ECR repository.
+	var repoURL string
+	if mft.ImageLocation() == "" {
+		resources, err := o.describer.GetProjectResourcesByRegion(proj, env.Region)
+		if err != nil {
+			return nil, err
 		}
+		url, ok := resources.RepositoryURLs[o.AppName]
+		if !ok {
+			return nil, &errRepoNotFound{
+				appName:       o.AppName,
+				envRegion:     env.Region,
+				projAccountID: proj.AccountID,
+			}
+		}
+		repoURL = url
 	}
 
 	switch t := mft.(type) {
@@ -228,6 +257,8 @@ func (o *PackageAppOpts) getTemplates(env *archer.Environment) (*cfnTemplates, e
 			Env:          env,
 			ImageRepoURL: repoURL,
 			ImageTag:     o.Tag,
+			ImageDigest:  o.digest,
+			EnvOutputs:   envOutputs,
 		}
 		var appStack *stack.LBFargateStackConfig
 		// If the project supports DNS Delegation, we'll also
@@ -238,20 +269,87 @@ func (o *PackageAppOpts) getTemplates(env *archer.Environment) (*cfnTemplates, e
 			appStack = stack.NewLBFargateStack(createLBAppInput)
 		}
 
-		tpl, err := appStack.Template()
-		if err != nil {
-			return nil, err
-		}
-		params, err := appStack.SerializedParameters()
-		if err != nil {
-			return nil, err
-		}
-		return &cfnTemplates{stack: tpl, configuration: params}, nil
+		return serializeStack(appStack)
+	case *manifest.BackendAppManifest:
+		appStack := stack.NewBackendStack(&deploy.CreateBackendAppInput{
+			App:          t,
+			Env:          env,
+			ImageRepoURL: repoURL,
+			ImageTag:     o.Tag,
+			ImageDigest:  o.digest,
+			EnvOutputs:   envOutputs,
+		})
+		return serializeStack(appStack)
+	case *manifest.ScheduledJobManifest:
+		jobStack := stack.NewScheduledJobStack(&deploy.CreateScheduledJobInput{
+			App:          t,
+			Env:          env,
+			ImageRepoURL: repoURL,
+			ImageTag:     o.Tag,
+			ImageDigest:  o.digest,
+			EnvOutputs:   envOutputs,
+		})
+		return serializeStack(jobStack)
+	case *manifest.WorkerServiceManifest:
+		workerStack := stack.NewWorkerServiceStack(&deploy.CreateWorkerServiceInput{
+			App:          t,
+			Env:          env,
+			ImageRepoURL: repoURL,
+			ImageTag:     o.Tag,
+			ImageDigest:  o.digest,
+			EnvOutputs:   envOutputs,
+		})
+		return serializeStack(workerStack)
 	default:
 		return nil, fmt.Errorf("create CloudFormation template for manifest of type %T", t)
 	}
 }
 
+// readManifest returns the application's manifest in the workspace interpolated for the environment.
+func (o *PackageAppOpts) readManifest(env *archer.Environment) ([]byte, error) {
+	manifestFileName := o.ws.AppManifestFileName(o.AppName)
+	raw, err := o.ws.ReadFile(manifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	warnOutdatedManifest(manifestFileName, raw)
+	raw, err = manifest.NewInterpolator(o.ProjectName(), env.Name, o.AppName).Interpolate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("interpolate manifest %s: %w", manifestFileName, err)
+	}
+	if err := manifest.ValidateApp(raw); err != nil {
+		return nil, fmt.Errorf("validate manifest %s: %w", manifestFileName, err)
+	}
+	return raw, nil
+}
+
+// warnOutdatedManifest tells the user to update a manifest written by an older version of the CLI.
+// The manifest is migrated in memory when it's read, so the workspace is left untouched.
+func warnOutdatedManifest(manifestFileName string, raw []byte) bool {
+	mft, err := manifest.NewEditor(raw)
+	if err != nil || !mft.Migrated() {
+		// Manifests that can't be parsed are reported by the validation.
+		return false
+	}
+	log.Warningf("The manifest %s stores secret %s in %s without saying so, set %s on the secret.\n",
+		color.HighlightResource(manifestFileName), manifest.DatabasePasswordSecret, manifest.SecretBackendSecretsManager,
+		color.HighlightCode(fmt.Sprintf("backend: %s", manifest.SecretBackendSecretsManager)))
+	return true
+}
+
+// serializeStack renders the CloudFormation template and its parameters for an application stack.
+func serializeStack(s appStackSerializer) (*cfnTemplates, error) {
+	tpl, err := s.Template()
+	if err != nil {
+		return nil, err
+	}
+	params, err := s.SerializedParameters()
+	if err != nil {
+		return nil, err
+	}
+	return &cfnTemplates{stack: tpl, configuration: params}, nil
+}
+
 // setFileWriters creates the output directory, and updates the template and param writers to file writers in the directory.
 func (o *PackageAppOpts) setFileWriters() error {
 	if err := o.fs.MkdirAll(o.OutputDir, 0755); err != nil {
@@ -351,6 +449,7 @@ func BuildAppPackageCmd() *cobra.Command {
 				return fmt.Errorf("error retrieving default session: %w", err)
 			}
 			opts.describer = cloudformation.New(sess)
+			opts.envDescriber = describe.NewEnvDescriber(opts.ProjectName())
 			return nil
 		}),
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
@@ -4,7 +4,9 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -14,6 +16,7 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/aws/amazon-ecs-cli-v2/mocks"
 	"github.com/golang/mock/gomock"
@@ -718,3 +721,65 @@ count: 1`), nil)
 		})
 	}
 }
+
+func TestWarnOutdatedManifest(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+
+		wantedWarning bool
+	}{
+		"database password stored in Secrets Manager implicitly": {
+			inManifest: `name: frontend
+secrets:
+  DB_PASSWORD: phonetool-frontend-database
+database:
+  engine: postgresql
+`,
+			wantedWarning: true,
+		},
+		"database password of an environment stored in Secrets Manager implicitly": {
+			inManifest: `name: frontend
+environments:
+  prod:
+    database:
+      engine: postgresql
+    secrets:
+      DB_PASSWORD: phonetool-frontend-database-prod
+`,
+			wantedWarning: true,
+		},
+		"up-to-date manifest": {
+			inManifest: `name: frontend
+secrets:
+  DB_PASSWORD:
+    from: phonetool-frontend-database
+    backend: secretsmanager
+database:
+  engine: postgresql
+`,
+		},
+		"invalid manifest left to the validation": {
+			inManifest: "name: [frontend\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			diagnostics := &bytes.Buffer{}
+			defer func(w io.Writer) { log.DiagnosticWriter = w }(log.DiagnosticWriter)
+			log.DiagnosticWriter = diagnostics
+
+			// WHEN
+			warned := warnOutdatedManifest("frontend-app.yml", []byte(tc.inManifest))
+
+			// THEN
+			require.Equal(t, tc.wantedWarning, warned)
+			if tc.wantedWarning {
+				require.Contains(t, diagnostics.String(), "backend: secretsmanager")
+			} else {
+				require.Empty(t, diagnostics.String())
+			}
+		})
+	}
+}
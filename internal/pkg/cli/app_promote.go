@@ -0,0 +1,288 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/build/docker"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
+	"github.com/spf13/cobra"
+)
+
+const (
+	appPromoteFromEnvPrompt = "Which environment do you want to promote the application from?"
+	appPromoteToEnvPrompt   = "Which environment do you want to promote the application to?"
+)
+
+// promoteAppOpts holds the fields to promote the image deployed in an environment to another one.
+// The target environment is the EnvName of the embedded deploy options.
+type promoteAppOpts struct {
+	appDeployOpts
+	FromEnv string
+
+	imageGetter deployedImageGetter
+	ecrServices map[string]ecrService // Keyed by region.
+
+	fromEnvironment *archer.Environment
+}
+
+// Validate returns an error if the user inputs are invalid.
+func (o *promoteAppOpts) Validate() error {
+	if err := o.appDeployOpts.Validate(); err != nil {
+		return err
+	}
+	if o.FromEnv != "" {
+		if _, err := o.env(o.FromEnv); err != nil {
+			return err
+		}
+	}
+	if o.FromEnv != "" && o.FromEnv == o.EnvName {
+		return fmt.Errorf("cannot promote application %s from environment %s to itself", o.AppName, o.FromEnv)
+	}
+	return nil
+}
+
+// Ask prompts the user for any required fields that are not provided.
+func (o *promoteAppOpts) Ask() error {
+	if err := o.askAppName(); err != nil {
+		return err
+	}
+	if err := o.askEnv(&o.FromEnv, appPromoteFromEnvPrompt, o.EnvName); err != nil {
+		return err
+	}
+	return o.askEnv(&o.EnvName, appPromoteToEnvPrompt, o.FromEnv)
+}
+
+// Execute copies the image deployed in the source environment to the registry of the target environment if needed,
+// and deploys the application's stack with that image to the target environment.
+func (o *promoteAppOpts) Execute() error {
+	fromEnv, err := o.env(o.FromEnv)
+	if err != nil {
+		return err
+	}
+	o.fromEnvironment = fromEnv
+	toEnv, err := o.env(o.EnvName)
+	if err != nil {
+		return err
+	}
+	o.targetEnvironment = toEnv
+
+	release, err := o.acquireLock()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	source, err := o.promoteImage()
+	if err != nil {
+		return err
+	}
+	o.ImageTag = source.ImageTag
+	o.imageDigest = source.ImageDigest
+	o.gitCommit = source.GitCommit
+
+	if err := o.configureClients(); err != nil {
+		return err
+	}
+	return o.deployStack()
+}
+
+// promoteImage verifies that the image deployed in the source environment is still the one recorded with its deployment
+// there, and returns that deployment. The image is deployed by digest so that the target environment runs the same image.
+// If the environments are in different regions, it pushes the image to the repository of the target region.
+func (o *promoteAppOpts) promoteImage() (*archer.Deployment, error) {
+	image, err := o.imageGetter.Image(o.fromEnvironment.Name)
+	if err != nil {
+		return nil, fmt.Errorf("get image of application %s in environment %s: %w", o.AppName, o.fromEnvironment.Name, err)
+	}
+	repoName := fmt.Sprintf("%s/%s", o.ProjectName(), o.AppName)
+	i := strings.LastIndex(image, ":")
+	if i == -1 || !strings.HasSuffix(image[:i], "/"+repoName) {
+		return nil, fmt.Errorf("image %s deployed in environment %s is not from the ECR repository of application %s, run %s instead",
+			image, o.fromEnvironment.Name, o.AppName, color.HighlightCode("dw_run.sh app deploy"))
+	}
+	uri, tag := image[:i], image[i+1:]
+	source, err := o.sourceDeployment(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	fromECR, err := o.ecrService(o.fromEnvironment.Region)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := fromECR.ImageDigest(repoName, tag)
+	if err != nil {
+		return nil, fmt.Errorf("verify image %s: %w", image, err)
+	}
+	if digest != source.ImageDigest {
+		return nil, fmt.Errorf("image with tag %s was overwritten since deployment %d to environment %s, its digest is %s instead of %s",
+			tag, source.ID, o.fromEnvironment.Name, digest, source.ImageDigest)
+	}
+	log.Infof("Promoting %s with digest %s from %s to %s.\n", color.HighlightResource(image), digest,
+		color.HighlightUserInput(o.fromEnvironment.Name), color.HighlightUserInput(o.targetEnvironment.Name))
+	if o.fromEnvironment.Region == o.targetEnvironment.Region {
+		// Both environments pull the image from the same repository.
+		return source, nil
+	}
+
+	toECR, err := o.ecrService(o.targetEnvironment.Region)
+	if err != nil {
+		return nil, err
+	}
+	targetURI, err := toECR.GetRepository(repoName)
+	if err != nil {
+		return nil, fmt.Errorf("get ECR repository URI: %w", err)
+	}
+	if err := o.login(fromECR, uri); err != nil {
+		return nil, err
+	}
+	if err := o.dockerService.Pull(uri, tag); err != nil {
+		return nil, err
+	}
+	if err := o.dockerService.Tag(uri, tag, targetURI); err != nil {
+		return nil, err
+	}
+	if err := o.login(toECR, targetURI); err != nil {
+		return nil, err
+	}
+	if err := o.dockerService.Push(targetURI, tag); err != nil {
+		return nil, err
+	}
+	pushed, err := toECR.ImageDigest(repoName, tag)
+	if err != nil {
+		return nil, fmt.Errorf("verify image %s:%s: %w", targetURI, tag, err)
+	}
+	if pushed != digest {
+		// The tag was pushed again between the verification and the pull.
+		return nil, fmt.Errorf("image %s:%s was pushed with digest %s instead of %s", targetURI, tag, pushed, digest)
+	}
+	return source, nil
+}
+
+// sourceDeployment returns the latest deployment of the image tag to the source environment.
+func (o *promoteAppOpts) sourceDeployment(tag string) (*archer.Deployment, error) {
+	deployments, err := o.deployStore.ListDeployments(o.ProjectName(), o.AppName, o.fromEnvironment.Name)
+	if err != nil {
+		return nil, fmt.Errorf("list deployments of application %s in environment %s: %w", o.AppName, o.fromEnvironment.Name, err)
+	}
+	for i := len(deployments) - 1; i >= 0; i-- {
+		if deployments[i].ImageTag == tag && deployments[i].ImageDigest != "" {
+			return deployments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no deployment of image with tag %s to environment %s is recorded, run %s instead",
+		tag, o.fromEnvironment.Name, color.HighlightCode("dw_run.sh app deploy"))
+}
+
+func (o *promoteAppOpts) login(svc ecrService, uri string) error {
+	auth, err := svc.GetECRAuth()
+	if err != nil {
+		return fmt.Errorf("get ECR auth data: %w", err)
+	}
+	return o.dockerService.Login(uri, auth.Username, auth.Password)
+}
+
+func (o *promoteAppOpts) ecrService(region string) (ecrService, error) {
+	if _, ok := o.ecrServices[region]; !ok {
+		sess, err := o.sessProvider.DefaultWithRegion(region)
+		if err != nil {
+			return nil, fmt.Errorf("create ECR session with region %s: %w", region, err)
+		}
+		o.ecrServices[region] = ecr.New(sess)
+	}
+	return o.ecrServices[region], nil
+}
+
+func (o *promoteAppOpts) env(name string) (*archer.Environment, error) {
+	env, err := o.projectService.GetEnvironment(o.ProjectName(), name)
+	if err != nil {
+		return nil, fmt.Errorf("get environment %s from metadata store: %w", name, err)
+	}
+	return env, nil
+}
+
+// askEnv prompts for an environment other than the excluded one if the name is empty.
+func (o *promoteAppOpts) askEnv(name *string, msg, exclude string) error {
+	if *name != "" {
+		return nil
+	}
+	envs, err := o.projectService.ListEnvironments(o.ProjectName())
+	if err != nil {
+		return fmt.Errorf("get environments for project %s from metadata store: %w", o.ProjectName(), err)
+	}
+	var names []string
+	for _, env := range envs {
+		if env.Name != exclude {
+			names = append(names, env.Name)
+		}
+	}
+	if len(names) == 0 {
+		return errors.New("a project needs at least two environments to promote an application")
+	}
+	selected, err := o.prompt.SelectOne(msg, "", names)
+	if err != nil {
+		return fmt.Errorf("select env name: %w", err)
+	}
+	*name = selected
+	return nil
+}
+
+// BuildAppPromoteCmd builds the `app promote` subcommand.
+func BuildAppPromoteCmd() *cobra.Command {
+	opts := &promoteAppOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts:    NewGlobalOpts(),
+			spinner:       termprogress.NewSpinner(),
+			dockerService: docker.New(),
+			runner:        command.New(),
+			sessProvider:  session.NewProvider(),
+		},
+		ecrServices: make(map[string]ecrService),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Promotes the image of an application from one environment to another.",
+		Long: `Promotes the image of an application from one environment to another.
+The image deployed in the source environment is deployed as is to the target environment without being rebuilt.`,
+		Example: `
+  Promotes the "frontend" application tested in the "dev" environment to the "prod" environment.
+  /code $ dw_run.sh app promote --name frontend --from dev --to prod`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.init(); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			describer, err := describe.NewWebAppDescriber(opts.ProjectName(), opts.AppName)
+			if err != nil {
+				return fmt.Errorf("create describer for application %s in project %s: %w", opts.AppName, opts.ProjectName(), err)
+			}
+			opts.imageGetter = describer
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVar(&opts.FromEnv, fromEnvFlag, "", fromEnvFlagDescription)
+	cmd.Flags().StringVar(&opts.EnvName, toEnvFlag, "", toEnvFlagDescription)
+	cmd.Flags().BoolVar(&opts.Force, forceFlag, false, forceDeployFlagDescription)
+	cmd.Flags().BoolVar(&opts.SkipPreDeployHook, skipPreDeployHookFlag, false, skipPreDeployHookFlagDescription)
+
+	return cmd
+}
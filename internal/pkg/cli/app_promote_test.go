@@ -0,0 +1,287 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/identity"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+)
+
+func TestPromoteAppOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inFromEnv string
+		inToEnv   string
+
+		mockStore func(m *climocks.MockprojectService)
+
+		wantedError error
+	}{
+		"with unknown source environment": {
+			inFromEnv: "dev",
+			mockStore: func(m *climocks.MockprojectService) {
+				m.EXPECT().GetEnvironment("phonetool", "dev").Return(nil, errors.New("unknown env"))
+			},
+
+			wantedError: errors.New("get environment dev from metadata store: unknown env"),
+		},
+		"with the same source and target environments": {
+			inFromEnv: "dev",
+			inToEnv:   "dev",
+			mockStore: func(m *climocks.MockprojectService) {
+				m.EXPECT().GetEnvironment("phonetool", "dev").Return(&archer.Environment{Name: "dev"}, nil).Times(2)
+			},
+
+			wantedError: errors.New("cannot promote application frontend from environment dev to itself"),
+		},
+		"successful validation": {
+			inFromEnv: "dev",
+			inToEnv:   "prod",
+			mockStore: func(m *climocks.MockprojectService) {
+				m.EXPECT().GetEnvironment("phonetool", "prod").Return(&archer.Environment{Name: "prod"}, nil)
+				m.EXPECT().GetEnvironment("phonetool", "dev").Return(&archer.Environment{Name: "dev"}, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockWs.EXPECT().Apps().Return([]archer.Manifest{
+				&manifest.LBFargateManifest{
+					AppManifest: manifest.AppManifest{
+						Name: "frontend",
+					},
+				},
+			}, nil)
+			mockStore := climocks.NewMockprojectService(ctrl)
+			tc.mockStore(mockStore)
+			opts := promoteAppOpts{
+				appDeployOpts: appDeployOpts{
+					GlobalOpts: &GlobalOpts{
+						projectName: "phonetool",
+					},
+					AppName:          "frontend",
+					EnvName:          tc.inToEnv,
+					workspaceService: mockWs,
+					projectService:   mockStore,
+				},
+				FromEnv: tc.inFromEnv,
+			}
+
+			// WHEN
+			err := opts.Validate()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPromoteAppOpts_promoteImage(t *testing.T) {
+	const (
+		mockRepoName = "phonetool/frontend"
+		mockWestURI  = "1111.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend"
+		mockEastURI  = "1111.dkr.ecr.us-east-1.amazonaws.com/phonetool/frontend"
+		mockTag      = "v1.2"
+	)
+	mockAuth := ecr.Auth{Username: "AWS", Password: "secret"}
+	mockDeployments := []*archer.Deployment{
+		{ID: 1, ImageTag: mockTag, ImageDigest: "sha256:old", GitCommit: "0a1b2c"},
+		{ID: 2, ImageTag: "v1.1", ImageDigest: "sha256:def", GitCommit: "3d4e5f"},
+		{ID: 3, ImageTag: mockTag, ImageDigest: "sha256:abc", GitCommit: "6a7b8c"},
+	}
+	testCases := map[string]struct {
+		inToRegion string
+
+		setupMocks func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService)
+
+		wantedDeployment *archer.Deployment
+		wantedError      error
+	}{
+		"image is not from the app's repository": {
+			inToRegion: "us-west-2",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				getter.EXPECT().Image("dev").Return("nginx:latest", nil)
+			},
+			wantedError: errors.New("image nginx:latest deployed in environment dev is not from the ECR repository of application frontend, run `dw_run.sh app deploy` instead"),
+		},
+		"image without a recorded deployment": {
+			inToRegion: "us-west-2",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				getter.EXPECT().Image("dev").Return(mockWestURI+":v2.0", nil)
+				store.EXPECT().ListDeployments("phonetool", "frontend", "dev").Return(mockDeployments, nil)
+			},
+			wantedError: errors.New("no deployment of image with tag v2.0 to environment dev is recorded, run `dw_run.sh app deploy` instead"),
+		},
+		"image is missing from the repository": {
+			inToRegion: "us-west-2",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				getter.EXPECT().Image("dev").Return(mockWestURI+":"+mockTag, nil)
+				store.EXPECT().ListDeployments("phonetool", "frontend", "dev").Return(mockDeployments, nil)
+				west.EXPECT().ImageDigest(mockRepoName, mockTag).Return("", errors.New("some error"))
+			},
+			wantedError: errors.New("verify image " + mockWestURI + ":" + mockTag + ": some error"),
+		},
+		"tag pushed again since the deployment": {
+			inToRegion: "us-west-2",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				getter.EXPECT().Image("dev").Return(mockWestURI+":"+mockTag, nil)
+				store.EXPECT().ListDeployments("phonetool", "frontend", "dev").Return(mockDeployments, nil)
+				west.EXPECT().ImageDigest(mockRepoName, mockTag).Return("sha256:new", nil)
+			},
+			wantedError: errors.New("image with tag v1.2 was overwritten since deployment 3 to environment dev, its digest is sha256:new instead of sha256:abc"),
+		},
+		"environments in the same region share the image": {
+			inToRegion: "us-west-2",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				getter.EXPECT().Image("dev").Return(mockWestURI+":"+mockTag, nil)
+				store.EXPECT().ListDeployments("phonetool", "frontend", "dev").Return(mockDeployments, nil)
+				west.EXPECT().ImageDigest(mockRepoName, mockTag).Return("sha256:abc", nil)
+			},
+			wantedDeployment: mockDeployments[2],
+		},
+		"image is copied to the repository of the target region": {
+			inToRegion: "us-east-1",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				gomock.InOrder(
+					getter.EXPECT().Image("dev").Return(mockWestURI+":"+mockTag, nil),
+					store.EXPECT().ListDeployments("phonetool", "frontend", "dev").Return(mockDeployments, nil),
+					west.EXPECT().ImageDigest(mockRepoName, mockTag).Return("sha256:abc", nil),
+					east.EXPECT().GetRepository(mockRepoName).Return(mockEastURI, nil),
+					west.EXPECT().GetECRAuth().Return(mockAuth, nil),
+					docker.EXPECT().Login(mockWestURI, mockAuth.Username, mockAuth.Password).Return(nil),
+					docker.EXPECT().Pull(mockWestURI, mockTag).Return(nil),
+					docker.EXPECT().Tag(mockWestURI, mockTag, mockEastURI).Return(nil),
+					east.EXPECT().GetECRAuth().Return(mockAuth, nil),
+					docker.EXPECT().Login(mockEastURI, mockAuth.Username, mockAuth.Password).Return(nil),
+					docker.EXPECT().Push(mockEastURI, mockTag).Return(nil),
+					east.EXPECT().ImageDigest(mockRepoName, mockTag).Return("sha256:abc", nil),
+				)
+			},
+			wantedDeployment: mockDeployments[2],
+		},
+		"tag pushed again before the image is copied": {
+			inToRegion: "us-east-1",
+			setupMocks: func(getter *climocks.MockdeployedImageGetter, store *climocks.MockdeploymentStore, west, east *climocks.MockecrService, docker *climocks.MockdockerService) {
+				getter.EXPECT().Image("dev").Return(mockWestURI+":"+mockTag, nil)
+				store.EXPECT().ListDeployments("phonetool", "frontend", "dev").Return(mockDeployments, nil)
+				west.EXPECT().ImageDigest(mockRepoName, mockTag).Return("sha256:abc", nil)
+				east.EXPECT().GetRepository(mockRepoName).Return(mockEastURI, nil)
+				west.EXPECT().GetECRAuth().Return(mockAuth, nil)
+				east.EXPECT().GetECRAuth().Return(mockAuth, nil)
+				docker.EXPECT().Login(gomock.Any(), mockAuth.Username, mockAuth.Password).Return(nil).Times(2)
+				docker.EXPECT().Pull(mockWestURI, mockTag).Return(nil)
+				docker.EXPECT().Tag(mockWestURI, mockTag, mockEastURI).Return(nil)
+				docker.EXPECT().Push(mockEastURI, mockTag).Return(nil)
+				east.EXPECT().ImageDigest(mockRepoName, mockTag).Return("sha256:new", nil)
+			},
+			wantedError: errors.New("image " + mockEastURI + ":v1.2 was pushed with digest sha256:new instead of sha256:abc"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGetter := climocks.NewMockdeployedImageGetter(ctrl)
+			mockDeployStore := climocks.NewMockdeploymentStore(ctrl)
+			mockWestECR := climocks.NewMockecrService(ctrl)
+			mockEastECR := climocks.NewMockecrService(ctrl)
+			mockDocker := climocks.NewMockdockerService(ctrl)
+			tc.setupMocks(mockGetter, mockDeployStore, mockWestECR, mockEastECR, mockDocker)
+			opts := promoteAppOpts{
+				appDeployOpts: appDeployOpts{
+					GlobalOpts: &GlobalOpts{
+						projectName: "phonetool",
+					},
+					AppName:           "frontend",
+					dockerService:     mockDocker,
+					deployStore:       mockDeployStore,
+					targetEnvironment: &archer.Environment{Name: "prod", Region: tc.inToRegion},
+				},
+				imageGetter: mockGetter,
+				ecrServices: map[string]ecrService{
+					"us-west-2": mockWestECR,
+					"us-east-1": mockEastECR,
+				},
+				fromEnvironment: &archer.Environment{Name: "dev", Region: "us-west-2"},
+			}
+
+			// WHEN
+			deployment, err := opts.promoteImage()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedDeployment, deployment)
+			}
+		})
+	}
+}
+
+func TestPromoteAppOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	heldLock := &archer.DeploymentLock{
+		Project:    "phonetool",
+		App:        "frontend",
+		Env:        "prod",
+		Owner:      "arn:aws:iam::1234:user/alice",
+		AcquiredAt: time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC),
+		TTL:        time.Hour,
+	}
+	mockProjectService := climocks.NewMockprojectService(ctrl)
+	mockProjectService.EXPECT().GetEnvironment("phonetool", "dev").Return(&archer.Environment{Name: "dev"}, nil)
+	mockProjectService.EXPECT().GetEnvironment("phonetool", "prod").Return(&archer.Environment{Name: "prod", Prod: true}, nil)
+	mockIdentity := climocks.NewMockidentityService(ctrl)
+	mockIdentity.EXPECT().Get().Return(identity.Caller{ARN: "arn:aws:iam::1234:user/bob"}, nil)
+	mockLocker := climocks.NewMockdeploymentLocker(ctrl)
+	mockLocker.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(&store.ErrDeploymentLocked{Lock: heldLock})
+	opts := promoteAppOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts: &GlobalOpts{
+				projectName: "phonetool",
+			},
+			AppName:        "frontend",
+			EnvName:        "prod",
+			projectService: mockProjectService,
+			identity:       mockIdentity,
+			locker:         mockLocker,
+		},
+		FromEnv: "dev",
+		// The image isn't promoted while another deployment holds the lock.
+		imageGetter: climocks.NewMockdeployedImageGetter(ctrl),
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.EqualError(t, err, fmt.Sprintf("application frontend is being deployed to environment prod by arn:aws:iam::1234:user/alice since %s, use --force to deploy to environment prod anyway",
+		heldLock.AcquiredAt.Local().Format(time.RFC3339)))
+}
@@ -0,0 +1,196 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
+	"github.com/spf13/cobra"
+)
+
+const (
+	appRollbackDeploymentPrompt = "Which deployment do you want to roll back to?"
+)
+
+// rollbackAppOpts holds the fields to redeploy a previous deployment of an application.
+type rollbackAppOpts struct {
+	appDeployOpts
+	To    int    // ID of the deployment.
+	ToTag string // Image tag of the deployment, the latest deployment of the tag is used.
+}
+
+// Validate returns an error if the user inputs are invalid.
+func (o *rollbackAppOpts) Validate() error {
+	if err := o.appDeployOpts.Validate(); err != nil {
+		return err
+	}
+	if o.To != 0 && o.ToTag != "" {
+		return errors.New("`--to` cannot be used with `--tag`")
+	}
+	return nil
+}
+
+// Ask prompts the user for any required fields that are not provided.
+func (o *rollbackAppOpts) Ask() error {
+	if err := o.askAppName(); err != nil {
+		return err
+	}
+	if err := o.askEnvName(); err != nil {
+		return err
+	}
+	return o.askDeployment()
+}
+
+// Execute redeploys the image and manifest of the selected deployment to the environment.
+func (o *rollbackAppOpts) Execute() error {
+	env, err := o.targetEnv()
+	if err != nil {
+		return err
+	}
+	o.targetEnvironment = env
+
+	release, err := o.acquireLock()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := o.configureClients(); err != nil {
+		return err
+	}
+
+	d, err := o.rollbackTarget()
+	if err != nil {
+		return err
+	}
+	log.Infof("Rolling back %s in %s to deployment %s from %s.\n", color.HighlightUserInput(o.AppName),
+		color.HighlightUserInput(o.targetEnvironment.Name), color.HighlightResource(strconv.Itoa(d.ID)), d.Time.Local().Format(time.RFC3339))
+	o.ImageTag = d.ImageTag
+	o.manifest = []byte(d.Manifest)
+	o.gitCommit = d.GitCommit
+	return o.deployStack()
+}
+
+// rollbackTarget returns the deployment to roll back to.
+// It returns an error if the image of the deployment was overwritten in the app's ECR repository since then.
+func (o *rollbackAppOpts) rollbackTarget() (*archer.Deployment, error) {
+	deployments, err := o.deployStore.ListDeployments(o.ProjectName(), o.AppName, o.targetEnvironment.Name)
+	if err != nil {
+		return nil, err
+	}
+	d := findDeployment(deployments, o.To, o.ToTag)
+	if d == nil {
+		target := strconv.Itoa(o.To)
+		if o.ToTag != "" {
+			target = fmt.Sprintf("of image tag %s", o.ToTag)
+		}
+		return nil, fmt.Errorf("no deployment %s of application %s to environment %s, run %s to list them",
+			target, o.AppName, o.targetEnvironment.Name, color.HighlightCode("dw_run.sh app history"))
+	}
+	if d.ImageDigest == "" {
+		// The image is deployed from the location in the manifest.
+		return d, nil
+	}
+	digest, err := o.ecrService.ImageDigest(fmt.Sprintf("%s/%s", o.ProjectName(), o.AppName), d.ImageTag)
+	if err != nil {
+		return nil, fmt.Errorf("get digest of image with tag %s: %w", d.ImageTag, err)
+	}
+	if digest != d.ImageDigest {
+		return nil, fmt.Errorf("image with tag %s was overwritten since deployment %d, its digest is %s instead of %s", d.ImageTag, d.ID, digest, d.ImageDigest)
+	}
+	return d, nil
+}
+
+// findDeployment returns the latest deployment of the image tag if it's set, otherwise the deployment with the ID.
+// It returns nil if there is no such deployment.
+func findDeployment(deployments []*archer.Deployment, id int, tag string) *archer.Deployment {
+	for i := len(deployments) - 1; i >= 0; i-- {
+		d := deployments[i]
+		if (tag != "" && d.ImageTag == tag) || (tag == "" && d.ID == id) {
+			return d
+		}
+	}
+	return nil
+}
+
+func (o *rollbackAppOpts) askDeployment() error {
+	if o.To != 0 || o.ToTag != "" {
+		return nil
+	}
+	deployments, err := o.deployStore.ListDeployments(o.ProjectName(), o.AppName, o.EnvName)
+	if err != nil {
+		return err
+	}
+	if len(deployments) < 2 {
+		return fmt.Errorf("no previous deployment of application %s to environment %s to roll back to", o.AppName, o.EnvName)
+	}
+	// The latest deployment is the one running, so it's not offered.
+	ids := make(map[string]int)
+	var options []string
+	for i := len(deployments) - 2; i >= 0; i-- {
+		d := deployments[i]
+		option := fmt.Sprintf("%d: %s deployed on %s", d.ID, d.ImageTag, d.Time.Local().Format(time.RFC3339))
+		ids[option] = d.ID
+		options = append(options, option)
+	}
+	selected, err := o.prompt.SelectOne(appRollbackDeploymentPrompt, "", options)
+	if err != nil {
+		return fmt.Errorf("select deployment: %w", err)
+	}
+	o.To = ids[selected]
+	return nil
+}
+
+// BuildAppRollbackCmd builds the `app rollback` subcommand.
+func BuildAppRollbackCmd() *cobra.Command {
+	opts := &rollbackAppOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts:   NewGlobalOpts(),
+			spinner:      termprogress.NewSpinner(),
+			runner:       command.New(),
+			sessProvider: session.NewProvider(),
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Rolls an application back to a previous deployment.",
+		Long: `Rolls an application back to a previous deployment.
+The image and the manifest of the deployment are redeployed as they were, regardless of the workspace.`,
+		Example: `
+  Rolls the "frontend" application in the "prod" environment back to its third deployment.
+  /code $ dw_run.sh app rollback --name frontend --env prod --to 3
+
+  Rolls the "frontend" application in the "prod" environment back to the latest deployment of the "v1.2" image.
+  /code $ dw_run.sh app rollback --name frontend --env prod --tag v1.2`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.init(); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.EnvName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().IntVar(&opts.To, rollbackToFlag, 0, rollbackToFlagDescription)
+	cmd.Flags().StringVar(&opts.ToTag, imageTagFlag, "", rollbackTagFlagDescription)
+	cmd.Flags().BoolVar(&opts.Force, forceFlag, false, forceDeployFlagDescription)
+
+	return cmd
+}
@@ -0,0 +1,197 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/identity"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+)
+
+func TestRollbackAppOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inTo    int
+		inToTag string
+
+		wantedError error
+	}{
+		"by id": {
+			inTo: 2,
+		},
+		"by image tag": {
+			inToTag: "v1.0",
+		},
+		"by both": {
+			inTo:    2,
+			inToTag: "v1.0",
+
+			wantedError: errors.New("`--to` cannot be used with `--tag`"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			opts := rollbackAppOpts{
+				appDeployOpts: appDeployOpts{
+					GlobalOpts: &GlobalOpts{
+						projectName: "phonetool",
+					},
+				},
+				To:    tc.inTo,
+				ToTag: tc.inToTag,
+			}
+
+			// WHEN
+			err := opts.Validate()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRollbackAppOpts_rollbackTarget(t *testing.T) {
+	deployments := []*archer.Deployment{
+		{ID: 1, ImageTag: "v1.0", ImageDigest: "sha256:aaa"},
+		{ID: 2, ImageTag: "v1.1", ImageDigest: "sha256:bbb"},
+		{ID: 3, ImageTag: "v1.0", ImageDigest: "sha256:aaa"},
+		{ID: 4, ImageTag: "latest"},
+	}
+	testCases := map[string]struct {
+		inTo    int
+		inToTag string
+
+		mockECR func(m *climocks.MockecrService)
+
+		wantedID    int
+		wantedError error
+	}{
+		"by id": {
+			inTo: 2,
+			mockECR: func(m *climocks.MockecrService) {
+				m.EXPECT().ImageDigest("phonetool/frontend", "v1.1").Return("sha256:bbb", nil)
+			},
+			wantedID: 2,
+		},
+		"latest deployment of the tag": {
+			inToTag: "v1.0",
+			mockECR: func(m *climocks.MockecrService) {
+				m.EXPECT().ImageDigest("phonetool/frontend", "v1.0").Return("sha256:aaa", nil)
+			},
+			wantedID: 3,
+		},
+		"image deployed from its location": {
+			inToTag:  "latest",
+			mockECR:  func(m *climocks.MockecrService) {},
+			wantedID: 4,
+		},
+		"unknown deployment": {
+			inTo:        5,
+			mockECR:     func(m *climocks.MockecrService) {},
+			wantedError: errors.New("no deployment 5 of application frontend to environment prod, run `dw_run.sh app history` to list them"),
+		},
+		"tag that looks like an id": {
+			inToTag:     "2",
+			mockECR:     func(m *climocks.MockecrService) {},
+			wantedError: errors.New("no deployment of image tag 2 of application frontend to environment prod, run `dw_run.sh app history` to list them"),
+		},
+		"overwritten image": {
+			inToTag: "v1.1",
+			mockECR: func(m *climocks.MockecrService) {
+				m.EXPECT().ImageDigest("phonetool/frontend", "v1.1").Return("sha256:ccc", nil)
+			},
+			wantedError: errors.New("image with tag v1.1 was overwritten since deployment 2, its digest is sha256:ccc instead of sha256:bbb"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockStore := climocks.NewMockdeploymentStore(ctrl)
+			mockStore.EXPECT().ListDeployments("phonetool", "frontend", "prod").Return(deployments, nil)
+			mockECR := climocks.NewMockecrService(ctrl)
+			tc.mockECR(mockECR)
+			opts := rollbackAppOpts{
+				appDeployOpts: appDeployOpts{
+					GlobalOpts: &GlobalOpts{
+						projectName: "phonetool",
+					},
+					AppName:           "frontend",
+					ecrService:        mockECR,
+					deployStore:       mockStore,
+					targetEnvironment: &archer.Environment{Name: "prod"},
+				},
+				To:    tc.inTo,
+				ToTag: tc.inToTag,
+			}
+
+			// WHEN
+			d, err := opts.rollbackTarget()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedID, d.ID)
+			}
+		})
+	}
+}
+
+func TestRollbackAppOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	heldLock := &archer.DeploymentLock{
+		Project:    "phonetool",
+		App:        "frontend",
+		Env:        "test",
+		Owner:      "arn:aws:iam::1234:user/alice",
+		AcquiredAt: time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC),
+		TTL:        time.Hour,
+	}
+	mockProjectService := climocks.NewMockprojectService(ctrl)
+	mockProjectService.EXPECT().GetEnvironment("phonetool", "test").Return(&archer.Environment{Name: "test"}, nil)
+	mockIdentity := climocks.NewMockidentityService(ctrl)
+	mockIdentity.EXPECT().Get().Return(identity.Caller{ARN: "arn:aws:iam::1234:user/bob"}, nil)
+	mockLocker := climocks.NewMockdeploymentLocker(ctrl)
+	mockLocker.EXPECT().AcquireDeploymentLock(gomock.Any()).Return(&store.ErrDeploymentLocked{Lock: heldLock})
+	opts := rollbackAppOpts{
+		appDeployOpts: appDeployOpts{
+			GlobalOpts: &GlobalOpts{
+				projectName: "phonetool",
+			},
+			AppName:        "frontend",
+			EnvName:        "test",
+			projectService: mockProjectService,
+			identity:       mockIdentity,
+			locker:         mockLocker,
+		},
+		To: 2,
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.EqualError(t, err, fmt.Sprintf("application frontend is being deployed to environment test by arn:aws:iam::1234:user/alice since %s, use --force to deploy to environment test anyway",
+		heldLock.AcquiredAt.Local().Format(time.RFC3339)))
+}
@@ -102,11 +102,20 @@ func (o *ShowAppOpts) retrieveData() (*describe.WebApp, error) {
 	for _, env := range environments {
 		webAppURI, err := o.describer.URI(env.Name)
 		if err == nil {
-			routes = append(routes, describe.WebAppRoute{
-				Environment: env.Name,
-				URL:         webAppURI.DNSName,
-				Path:        webAppURI.Path,
-			})
+			if len(webAppURI.Routes) == 0 {
+				routes = append(routes, describe.WebAppRoute{
+					Environment: env.Name,
+					URL:         webAppURI.DNSName,
+					Path:        webAppURI.Path,
+				})
+			}
+			for _, route := range webAppURI.Routes {
+				routes = append(routes, describe.WebAppRoute{
+					Environment: env.Name,
+					URL:         route.URL,
+					Path:        route.Conditions,
+				})
+			}
 			webAppECSParams, err := o.describer.ECSParams(env.Name)
 			if err != nil {
 				return nil, fmt.Errorf("retrieving application deployment configuration: %w", err)
@@ -0,0 +1,111 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+// validateAppOpts holds the configuration needed to validate the manifests of applications in the workspace.
+type validateAppOpts struct {
+	// Fields with matching flags.
+	AppName string
+
+	ws archer.ManifestIO
+	w  io.Writer
+}
+
+// Execute validates the manifest of the application, or of every application in the workspace if no
+// name is provided, and writes each problem found with the file, line and column where it occurs.
+func (opts *validateAppOpts) Execute() error {
+	files, err := opts.manifestFiles()
+	if err != nil {
+		return err
+	}
+
+	var numProblems, numInvalidFiles int
+	for _, file := range files {
+		raw, err := opts.ws.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read manifest file %s: %w", file, err)
+		}
+		path := filepath.Join(workspace.ProjectDirectoryName, file)
+		err = manifest.ValidateApp(raw)
+		if err == nil {
+			continue
+		}
+		numInvalidFiles++
+		var invalidErr *manifest.ErrInvalidAppManifest
+		if !errors.As(err, &invalidErr) {
+			// The manifest couldn't be read at all, so there is no position to report.
+			numProblems++
+			fmt.Fprintf(opts.w, "%s: %v\n", path, err)
+			continue
+		}
+		for _, problem := range invalidErr.Errors {
+			numProblems++
+			fmt.Fprintf(opts.w, "%s:%d:%d: %s\n", path, problem.Line, problem.Column, problem.Msg)
+		}
+	}
+	if numProblems > 0 {
+		return fmt.Errorf("found %d problem(s) in %d manifest file(s)", numProblems, numInvalidFiles)
+	}
+	log.Successf("Validated %d manifest file(s).\n", len(files))
+	return nil
+}
+
+func (opts *validateAppOpts) manifestFiles() ([]string, error) {
+	if opts.AppName != "" {
+		return []string{opts.ws.AppManifestFileName(opts.AppName)}, nil
+	}
+	files, err := opts.ws.ListManifestFiles()
+	if err != nil {
+		return nil, fmt.Errorf("list local manifest files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, errNoLocalManifestsFound
+	}
+	return files, nil
+}
+
+// BuildAppValidateCmd builds the command for validating the manifests of applications.
+func BuildAppValidateCmd() *cobra.Command {
+	opts := validateAppOpts{
+		w: log.OutputWriter,
+	}
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Checks application manifests for problems before deploying.",
+		Long: `Checks application manifests for unknown fields, values of the wrong type and settings that can't be deployed.
+Every problem is reported with the file, line and column where it occurs.`,
+		Example: `
+  Validate the manifests of all the applications in the workspace.
+  /code $ dw_run.sh app validate
+
+  Validate the manifest of the "frontend" application.
+  /code $ dw_run.sh app validate -n frontend`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			opts.ws = ws
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.AppName, nameFlag, nameFlagShort, "", appFlagDescription)
+	return cmd
+}
@@ -0,0 +1,106 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAppOpts_Execute(t *testing.T) {
+	validManifest := []byte(`name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+cpu: 256
+memory: 512
+`)
+	invalidManifest := []byte(`name: backend
+type: Load Balanced Web App
+image:
+  build: backend/Dockerfile
+  port: 80
+cpu: 256
+memory: 512
+healthcheck:
+  paths: /health
+`)
+	mockErr := errors.New("some error")
+
+	testCases := map[string]struct {
+		inAppName  string
+		setupMocks func(m *mocks.MockManifestIO)
+
+		wantedErr    error
+		wantedOutput string
+	}{
+		"validates a single application": {
+			inAppName: "frontend",
+			setupMocks: func(m *mocks.MockManifestIO) {
+				m.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+				m.EXPECT().ReadFile("frontend-app.yml").Return(validManifest, nil)
+			},
+		},
+		"reports every problem of every application": {
+			setupMocks: func(m *mocks.MockManifestIO) {
+				m.EXPECT().ListManifestFiles().Return([]string{"backend-app.yml", "frontend-app.yml", "worker-app.yml"}, nil)
+				m.EXPECT().ReadFile("backend-app.yml").Return(invalidManifest, nil)
+				m.EXPECT().ReadFile("frontend-app.yml").Return(validManifest, nil)
+				m.EXPECT().ReadFile("worker-app.yml").Return([]byte("name: worker\ntype: Queue"), nil)
+			},
+			wantedErr: errors.New("found 2 problem(s) in 2 manifest file(s)"),
+			wantedOutput: `ecs-project/backend-app.yml:9:3: unknown field "paths"
+ecs-project/worker-app.yml: invalid manifest type: Queue
+`,
+		},
+		"no manifests in the workspace": {
+			setupMocks: func(m *mocks.MockManifestIO) {
+				m.EXPECT().ListManifestFiles().Return(nil, nil)
+			},
+			wantedErr: errNoLocalManifestsFound,
+		},
+		"fails to read a manifest": {
+			inAppName: "frontend",
+			setupMocks: func(m *mocks.MockManifestIO) {
+				m.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+				m.EXPECT().ReadFile("frontend-app.yml").Return(nil, mockErr)
+			},
+			wantedErr: fmt.Errorf("read manifest file frontend-app.yml: %w", mockErr),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockManifestIO(ctrl)
+			tc.setupMocks(mockWs)
+			b := &bytes.Buffer{}
+			opts := &validateAppOpts{
+				AppName: tc.inAppName,
+				ws:      mockWs,
+				w:       b,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedOutput, b.String())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+)
+
+var errJSONWithoutDryRun = errors.New("`--json` can only be used with `--dry-run`")
+
+// writeResourceChanges writes the changes that a deployment would make to the stack, in JSON or as a table.
+// The changes that replace or remove resources holding data are flagged as dangerous.
+func writeResourceChanges(w io.Writer, stackName string, changes []deploy.ResourceChange, asJSON bool) error {
+	if asJSON {
+		type serializedChange struct {
+			deploy.ResourceChange
+			Dangerous bool `json:"dangerous"`
+		}
+		serialized := make([]serializedChange, 0, len(changes))
+		for _, change := range changes {
+			serialized = append(serialized, serializedChange{ResourceChange: change, Dangerous: change.Dangerous()})
+		}
+		b, err := json.Marshal(struct {
+			Stack   string             `json:"stack"`
+			Changes []serializedChange `json:"changes"`
+		}{Stack: stackName, Changes: serialized})
+		if err != nil {
+			return fmt.Errorf("marshal changes: %w", err)
+		}
+		fmt.Fprintf(w, "%s\n", b)
+		return nil
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "No changes to stack %s.\n", stackName)
+		return nil
+	}
+	fmt.Fprintf(w, "Changes to stack %s:\n\n", stackName)
+	writer := tabwriter.NewWriter(w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n", "Resource", "Type", "Action", "Replacement", "Properties")
+	var dangerous []deploy.ResourceChange
+	for _, change := range changes {
+		name := change.LogicalID
+		if change.Dangerous() {
+			name += " (!)"
+			dangerous = append(dangerous, change)
+		}
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n", name, change.Type, change.Action, humanReplacement(change), strings.Join(change.Properties, ", "))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	for _, change := range dangerous {
+		verb := "replaced"
+		if !change.Replaced() {
+			verb = "removed"
+		}
+		fmt.Fprint(w, color.Red.Sprintf("\n(!) %s (%s) will be %s, the data it holds will be lost.", change.LogicalID, change.Type, verb))
+	}
+	if len(dangerous) > 0 {
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func humanReplacement(change deploy.ResourceChange) string {
+	switch {
+	case change.Replacement == "Conditional":
+		return "Conditional"
+	case change.Replaced():
+		return "Yes"
+	default:
+		return "No"
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteResourceChanges(t *testing.T) {
+	changes := []deploy.ResourceChange{
+		{
+			LogicalID:   "Service",
+			Type:        "AWS::ECS::Service",
+			Action:      "Modify",
+			Replacement: "False",
+			Properties:  []string{"TaskDefinition"},
+		},
+		{
+			LogicalID:   "Bucket",
+			Type:        "AWS::S3::Bucket",
+			Action:      "Modify",
+			Replacement: "Conditional",
+			Properties:  []string{"BucketName"},
+		},
+	}
+	testCases := map[string]struct {
+		inChanges []deploy.ResourceChange
+		inJSON    bool
+
+		wantedContent string
+	}{
+		"no changes": {
+			wantedContent: "No changes to stack phonetool-test-frontend.\n",
+		},
+		"flags dangerous changes": {
+			inChanges: changes,
+			wantedContent: `Changes to stack phonetool-test-frontend:
+
+  Resource          Type                Action              Replacement         Properties
+  Service           AWS::ECS::Service   Modify              No                  TaskDefinition
+  Bucket (!)        AWS::S3::Bucket     Modify              Conditional         BucketName
+
+(!) Bucket (AWS::S3::Bucket) will be replaced, the data it holds will be lost.
+`,
+		},
+		"json output": {
+			inChanges: changes,
+			inJSON:    true,
+			wantedContent: `{"stack":"phonetool-test-frontend","changes":[` +
+				`{"logicalID":"Service","type":"AWS::ECS::Service","action":"Modify","replacement":"False","properties":["TaskDefinition"],"dangerous":false},` +
+				`{"logicalID":"Bucket","type":"AWS::S3::Bucket","action":"Modify","replacement":"Conditional","properties":["BucketName"],"dangerous":true}]}` + "\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			b := &bytes.Buffer{}
+
+			// WHEN
+			err := writeResourceChanges(b, "phonetool-test-frontend", tc.inChanges, tc.inJSON)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, b.String())
+		})
+	}
+}
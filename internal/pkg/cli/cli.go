@@ -7,9 +7,12 @@ package cli
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/build/docker"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
@@ -132,12 +135,29 @@ type projectService interface {
 type ecrService interface {
 	GetRepository(name string) (string, error)
 	GetECRAuth() (ecr.Auth, error)
+	ImageDigest(repoName, tag string) (string, error)
+}
+
+type ecsService interface {
+	WaitForSteadyState(cluster, service string, timeout time.Duration) error
+	RunTask(in ecs.RunTaskInput) (*ecs.Task, error)
+}
+
+type stackResourceGetter interface {
+	StackResourceID(stackName, logicalID string) (string, error)
+}
+
+type serviceRestarter interface {
+	RestartService(cluster, service string) error
+	WaitForSteadyState(cluster, service string, timeout time.Duration) error
 }
 
 type dockerService interface {
-	Build(uri, tag, path string) error
+	Build(in *docker.BuildArguments) error
 	Login(uri, username, password string) error
 	Push(uri, tag string) error
+	Pull(uri, tag string) error
+	Tag(uri, tag, targetURI string) error
 }
 
 type runner interface {
@@ -168,6 +188,23 @@ type webAppDescriber interface {
 	StackResources(envName string) ([]*describe.CfnResource, error)
 }
 
+type deployedImageGetter interface {
+	Image(envName string) (string, error)
+}
+
+type deploymentStore interface {
+	archer.DeploymentLister
+	archer.DeploymentCreator
+}
+
+type deploymentLocker interface {
+	archer.DeploymentLocker
+}
+
+type envOutputsGetter interface {
+	Outputs(env *archer.Environment) (map[string]string, error)
+}
+
 type storeReader interface {
 	archer.ProjectLister
 	archer.ProjectGetter
@@ -14,9 +14,16 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
+// Value of the variables the stack sets to the endpoint of the database when it is deployed.
+const autoGeneratedValue = "*auto-generated*"
+
+// databasePasswordSecretName returns the name of the Secrets Manager secret holding the password of an application's database.
+func databasePasswordSecretName(project, app string) string {
+	return fmt.Sprintf("%s-%s-database", project, app)
+}
+
 // DatabaseCreateOpts contains the fields to collect to create a database.
 type DatabaseCreateOpts struct {
 	appName string
@@ -84,21 +91,8 @@ func (o *DatabaseCreateOpts) Execute() error {
 	if err != nil {
 		return err
 	}
-	lbmft := mft.(*manifest.LBFargateManifest)
-	if lbmft.Variables == nil {
-		lbmft.Variables = make(map[string]string)
-	}
-	if lbmft.Environments == nil {
-		lbmft.Environments = make(map[string]manifest.LBFargateConfig)
-	}
-	if lbmft.Secrets == nil {
-		lbmft.Secrets = make(map[string]string)
-	}
-	if lbmft.Database == nil {
-		lbmft.Database = &manifest.DatabaseConfig{}
-	}
 
-	secretName := fmt.Sprintf("%s-%s-database", project, o.appName)
+	secretName := databasePasswordSecretName(project, o.appName)
 	_, err = o.secretManager.CreateSecret(secretName, o.db.Password)
 
 	if err != nil {
@@ -111,17 +105,36 @@ func (o *DatabaseCreateOpts) Execute() error {
 
 	log.Successf("Created a secret with the database password.\n")
 
-	lbmft.Variables["DB_NAME"] = o.db.DatabaseName
-	lbmft.Variables["DB_USERNAME"] = o.db.Username
-	lbmft.Variables["DB_HOST"] = "*auto-generated*"
-	lbmft.Variables["DB_PORT"] = "*auto-generated*"
-	lbmft.Secrets["DB_PASSWORD"] = secretName
+	variables := []struct {
+		name  string
+		value string
+	}{
+		{"DB_NAME", o.db.DatabaseName},
+		{"DB_USERNAME", o.db.Username},
+		{"DB_HOST", autoGeneratedValue},
+		{"DB_PORT", autoGeneratedValue},
+	}
+	for _, v := range variables {
+		if err := mft.Set(v.value, manifest.VariablesKey, v.name); err != nil {
+			return fmt.Errorf("add environment variable %s: %w", v.name, err)
+		}
+	}
+	password := manifest.Secret{From: secretName, Backend: manifest.SecretBackendSecretsManager}
+	if err := mft.SetSecret(password, manifest.SecretsKey, manifest.DatabasePasswordSecret); err != nil {
+		return fmt.Errorf("add secret %s: %w", manifest.DatabasePasswordSecret, err)
+	}
 
-	lbmft.Database.Engine = o.db.Engine
-	lbmft.Database.MinCapacity = int(o.db.MinCapacity)
-	lbmft.Database.MaxCapacity = int(o.db.MaxCapacity)
+	if err := mft.Set(o.db.Engine, manifest.DatabaseKey, "engine"); err != nil {
+		return fmt.Errorf("add database engine: %w", err)
+	}
+	if err := mft.SetInt(int(o.db.MinCapacity), manifest.DatabaseKey, "minCapacity"); err != nil {
+		return fmt.Errorf("add database capacity: %w", err)
+	}
+	if err := mft.SetInt(int(o.db.MaxCapacity), manifest.DatabaseKey, "maxCapacity"); err != nil {
+		return fmt.Errorf("add database capacity: %w", err)
+	}
 
-	if err = o.writeManifest(lbmft); err != nil {
+	if err = o.writeManifest(mft); err != nil {
 		return err
 	}
 
@@ -136,16 +149,19 @@ func (o *DatabaseCreateOpts) RecommendedActions() []string {
 	}
 }
 
-func (o *DatabaseCreateOpts) readManifest() (archer.Manifest, error) {
+func (o *DatabaseCreateOpts) readManifest() (*manifest.Editor, error) {
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return nil, err
 	}
-	return manifest.UnmarshalApp(raw)
+	return manifest.NewEditor(raw)
 }
 
-func (o *DatabaseCreateOpts) writeManifest(manifest *manifest.LBFargateManifest) error {
-	manifestBytes, err := yaml.Marshal(manifest)
+func (o *DatabaseCreateOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
+	}
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
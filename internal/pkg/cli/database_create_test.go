@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseCreateOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	written := mockManifestEdit(mockWs, editTestManifest)
+	mockSecretManager := mocks.NewMockSecretsManager(ctrl)
+	mockSecretManager.EXPECT().CreateSecret("dw-run-frontend-database", "s3cr3t").Return("arn", nil)
+
+	opts := DatabaseCreateOpts{
+		appName: "frontend",
+		db: &archer.Database{
+			Engine:   "postgresql",
+			Username: "admin",
+			Password: "s3cr3t",
+		},
+		secretManager: mockSecretManager,
+		ws:            mockWs,
+		GlobalOpts:    &GlobalOpts{projectName: "dw-run"},
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+  DB_NAME: frontenddb
+  DB_USERNAME: admin
+  DB_HOST: '*auto-generated*'
+  DB_PORT: '*auto-generated*'
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+  DB_PASSWORD:
+    from: dw-run-frontend-database
+    backend: secretsmanager
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+database:
+  engine: postgresql
+  minCapacity: 2
+  maxCapacity: 4
+`, *written)
+}
@@ -6,12 +6,12 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // DatabaseDeleteOpts contains the fields to collect to delete a database.
@@ -20,7 +20,8 @@ type DatabaseDeleteOpts struct {
 
 	manifestPath string
 
-	storeReader storeReader
+	secretBackends archer.SecretBackends
+	storeReader    storeReader
 
 	ws archer.Workspace
 
@@ -53,32 +54,41 @@ func (o *DatabaseDeleteOpts) Ask() error {
 	return o.askAppName()
 }
 
-// Execute creates the cluster.
+// Execute deletes the secret holding the database password and removes the database from the manifest.
 func (o *DatabaseDeleteOpts) Execute() error {
-	//project := o.GlobalOpts.ProjectName()
 	o.manifestPath = o.ws.AppManifestFileName(o.appName)
 
 	mft, err := o.readManifest()
 	if err != nil {
 		return err
 	}
-	lbmft := mft.(*manifest.LBFargateManifest)
 
-	// TODO delete secret from secretsmanager
-	//if err := o.secretManager.DeleteSecret(pwKey); err != nil {
-	//	return err
-	//}
+	// Delete the password the manifest references right away, so that creating the database again creates a new secret.
+	password, ok := mft.GetSecret(manifest.SecretsKey, manifest.DatabasePasswordSecret)
+	if !ok {
+		password = manifest.Secret{
+			From:    databasePasswordSecretName(o.ProjectName(), o.appName),
+			Backend: manifest.SecretBackendSecretsManager,
+		}
+	}
+	secretManager, err := o.secretBackends.SecretBackend(password.Backend)
+	if err != nil {
+		return err
+	}
+	if err := deleteSecret(secretManager, password.From, true); err != nil {
+		return err
+	}
 
-	//log.Successf("Deleted the secret for the database password.\n")
+	log.Successf("Deleted the secret with the database password.\n")
 
-	delete(lbmft.Variables, "DB_NAME")
-	delete(lbmft.Variables, "DB_USERNAME")
-	delete(lbmft.Variables, "DB_HOST")
-	delete(lbmft.Variables, "DB_PORT")
-	delete(lbmft.Secrets, "DB_PASSWORD")
-	lbmft.Database = &manifest.DatabaseConfig{}
+	mft.Delete(manifest.VariablesKey, "DB_NAME")
+	mft.Delete(manifest.VariablesKey, "DB_USERNAME")
+	mft.Delete(manifest.VariablesKey, "DB_HOST")
+	mft.Delete(manifest.VariablesKey, "DB_PORT")
+	mft.Delete(manifest.SecretsKey, manifest.DatabasePasswordSecret)
+	mft.Delete(manifest.DatabaseKey)
 
-	if err = o.writeManifest(lbmft); err != nil {
+	if err = o.writeManifest(mft); err != nil {
 		return err
 	}
 
@@ -93,16 +103,19 @@ func (o *DatabaseDeleteOpts) RecommendedActions() []string {
 	}
 }
 
-func (o *DatabaseDeleteOpts) readManifest() (archer.Manifest, error) {
+func (o *DatabaseDeleteOpts) readManifest() (*manifest.Editor, error) {
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return nil, err
 	}
-	return manifest.UnmarshalApp(raw)
+	return manifest.NewEditor(raw)
 }
 
-func (o *DatabaseDeleteOpts) writeManifest(manifest *manifest.LBFargateManifest) error {
-	manifestBytes, err := yaml.Marshal(manifest)
+func (o *DatabaseDeleteOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
+	}
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -205,8 +218,13 @@ func BuildDatabaseDeleteCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("new workspace: %w", err)
 			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
 			opts.ws = ws
 			opts.storeReader = store
+			opts.secretBackends = secretBackends
 
 			return nil
 		}),
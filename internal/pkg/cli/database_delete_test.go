@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseDeleteOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	written := mockManifestEdit(mockWs, `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  DB_NAME: frontenddb
+  DB_USERNAME: admin
+  DB_HOST: '*auto-generated*'
+  DB_PORT: '*auto-generated*'
+  API_URL: https://api.example.com
+
+secrets:
+  DB_PASSWORD:
+    from: dw-run-frontend-database
+    backend: secretsmanager
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# The Aurora Serverless cluster of the application.
+database:
+  engine: postgresql
+  minCapacity: 2
+  maxCapacity: 4
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+`)
+
+	mockSecretManager := forceDeletingSecretsManager{mocks.NewMockSecretsManager(ctrl), mocks.NewMockSecretForceDeleter(ctrl)}
+	mockSecretManager.MockSecretForceDeleter.EXPECT().ForceDeleteSecret("dw-run-frontend-database").Return(nil)
+	mockBackends := mocks.NewMockSecretBackends(ctrl)
+	mockBackends.EXPECT().SecretBackend("secretsmanager").Return(mockSecretManager, nil)
+
+	opts := DatabaseDeleteOpts{
+		appName:        "frontend",
+		secretBackends: mockBackends,
+		ws:             mockWs,
+		GlobalOpts:     &GlobalOpts{projectName: "dw-run"},
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, editTestManifest, *written)
+}
@@ -6,6 +6,8 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
@@ -13,6 +15,7 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
@@ -31,6 +34,7 @@ const (
 
 const (
 	fmtDeployEnvStart          = "Proposing infrastructure changes for the %s environment."
+	fmtDiffEnvFailed           = "Failed to propose changes for the %s environment."
 	fmtDeployEnvFailed         = "Failed to accept changes for the %s environment."
 	fmtDNSDelegationStart      = "Sharing DNS permissions for this project to account %s."
 	fmtDNSDelegationFailed     = "Failed to grant DNS permissions to account %s."
@@ -45,9 +49,11 @@ const (
 // InitEnvOpts contains the fields to collect for adding an environment.
 type InitEnvOpts struct {
 	// Flags set by the user.
-	EnvName      string // Name of the environment.
-	EnvProfile   string // AWS profile used to create an environment.
-	IsProduction bool   // Marks the environment as "production" to create it with additional guardrails.
+	EnvName          string // Name of the environment.
+	EnvProfile       string // AWS profile used to create an environment.
+	IsProduction     bool   // Marks the environment as "production" to create it with additional guardrails.
+	DryRun           bool   // Shows the changes to the environment's stack instead of deploying it.
+	ShouldOutputJSON bool   // Shows the changes of a dry run in JSON.
 
 	// Interfaces to interact with dependencies.
 	projectGetter archer.ProjectGetter
@@ -57,6 +63,7 @@ type InitEnvOpts struct {
 	identity      identityService
 	envIdentity   identityService
 	prog          progress
+	w             io.Writer
 
 	*GlobalOpts
 }
@@ -95,6 +102,9 @@ func (opts *InitEnvOpts) Validate() error {
 	if opts.ProjectName() == "" {
 		return errors.New("no project found, run `project init` first please")
 	}
+	if opts.ShouldOutputJSON && !opts.DryRun {
+		return errJSONWithoutDryRun
+	}
 	return nil
 }
 
@@ -119,6 +129,9 @@ func (opts *InitEnvOpts) Execute() error {
 		ToolsAccountPrincipalARN: caller.RootUserARN,
 		ProjectDNSName:           project.Domain,
 	}
+	if opts.DryRun {
+		return opts.diffEnv(deployEnvInput)
+	}
 
 	if project.RequiresDNSDelegation() {
 		if err := opts.delegateDNSFromProject(project); err != nil {
@@ -171,6 +184,18 @@ func (opts *InitEnvOpts) Execute() error {
 	return nil
 }
 
+// diffEnv writes the changes that deploying the environment would make to its stack.
+func (opts *InitEnvOpts) diffEnv(in *deploy.CreateEnvironmentInput) error {
+	opts.prog.Start(fmt.Sprintf(fmtDeployEnvStart, color.HighlightUserInput(opts.EnvName)))
+	changes, err := opts.envDeployer.DiffEnvironment(in)
+	if err != nil {
+		opts.prog.Stop(log.Serrorf(fmtDiffEnvFailed, color.HighlightUserInput(opts.EnvName)))
+		return fmt.Errorf("diff environment %s: %w", opts.EnvName, err)
+	}
+	opts.prog.Stop("")
+	return writeResourceChanges(opts.w, stack.NameForEnv(opts.ProjectName(), opts.EnvName), changes, opts.ShouldOutputJSON)
+}
+
 func (opts *InitEnvOpts) delegateDNSFromProject(project *archer.Project) error {
 	envAccount, err := opts.envIdentity.Get()
 	if err != nil {
@@ -241,6 +266,7 @@ func BuildEnvInitCmd() *cobra.Command {
 	opts := InitEnvOpts{
 		IsProduction: false,
 		prog:         termprogress.NewSpinner(),
+		w:            os.Stdout,
 		GlobalOpts:   NewGlobalOpts(),
 	}
 
@@ -252,7 +278,10 @@ func BuildEnvInitCmd() *cobra.Command {
   /code $ dw_run.sh env init --name test --profile default
 
   Creates a prod-iad environment using your "prod-admin" AWS profile.
-  /code $ dw_run.sh env init --name prod-iad --profile prod-admin --prod`,
+  /code $ dw_run.sh env init --name prod-iad --profile prod-admin --prod
+
+  Shows the infrastructure changes to the test environment without deploying them.
+  /code $ dw_run.sh env init --name test --profile default --dry-run`,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			if err := opts.Ask(); err != nil {
 				return err
@@ -289,5 +318,7 @@ func BuildEnvInitCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.EnvName, nameFlag, nameFlagShort, "", envFlagDescription)
 	cmd.Flags().StringVar(&opts.EnvProfile, profileFlag, "services-admin", profileFlagDescription)
 	cmd.Flags().BoolVar(&opts.IsProduction, prodEnvFlag, opts.IsProduction, prodEnvFlagDescription)
+	cmd.Flags().BoolVar(&opts.DryRun, dryRunFlag, false, dryRunFlagDescription)
+	cmd.Flags().BoolVar(&opts.ShouldOutputJSON, jsonFlag, false, dryRunJSONFlagDescription)
 	return cmd
 }
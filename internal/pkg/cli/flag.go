@@ -29,6 +29,16 @@ const (
 	domainNameFlag        = "domain"
 	pipelineFileFlag      = "file"
 	appLocalFlag          = "local"
+	fromEnvFlag           = "from"
+	toEnvFlag             = "to"
+	rollbackToFlag        = "to"
+	dryRunFlag            = "dry-run"
+	rollbackOnFailureFlag = "rollback-on-failure"
+	allAppsFlag           = "all"
+	maxParallelFlag       = "max-parallel"
+	forceFlag             = "force"
+	secretBackendFlag     = "backend"
+	skipPreDeployHookFlag = "skip-pre-deploy-hook"
 )
 
 // Short flag names.
@@ -72,4 +82,18 @@ const (
 	resourcesFlagDescription         = "Optional. Show the resources of your application."
 	pipelineFileFlagDescription      = "Name of YAML file used to update the pipeline."
 	appLocalFlagDescription          = "Only show applications in the current directory."
+	fromEnvFlagDescription           = "Name of the environment to promote the application from."
+	toEnvFlagDescription             = "Name of the environment to promote the application to."
+	rollbackToFlagDescription        = "Number of the deployment to roll back to."
+	rollbackTagFlagDescription       = "Image tag of the deployment to roll back to, the latest deployment of the tag is used."
+	dryRunFlagDescription            = "Optional. Show the changes to the stack without deploying them."
+	dryRunJSONFlagDescription        = "Optional. Output the changes of a dry run in JSON format."
+	rollbackOnFailureFlagDescription = "Optional. Re-deploy the previous template if the new tasks don't reach a steady state."
+	deployEnvsFlagDescription        = "Name of the environment, or comma-separated names of the environments to deploy to."
+	allAppsFlagDescription           = "Optional. Deploy every application in the workspace."
+	maxParallelFlagDescription       = "Optional. Maximum number of deployments running at the same time."
+	forceDeployFlagDescription       = "Optional. Deploy even if another deployment holds the lock of the environment."
+	forceSecretDeleteFlagDescription = "Optional. Delete the secret without a recovery window, so that it can't be restored."
+	secretBackendFlagDescription     = "Optional. Where to store the secret; ssm or secretsmanager."
+	skipPreDeployHookFlagDescription = "Optional. Deploy without running the pre-deploy hook of the manifest."
 )
@@ -20,3 +20,13 @@ func getVersionTag(runner runner) (string, error) {
 	// NOTE: `git describe` output bytes includes a `\n` character, so we trim it out.
 	return strings.TrimSpace(b.String()), nil
 }
+
+// getCommit returns the commit checked out in the current git repository.
+func getCommit(runner runner) (string, error) {
+	var b bytes.Buffer
+
+	if err := runner.Run("git", []string{"rev-parse", "HEAD"}, command.Stdout(&b)); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of manifests that have a schema.
+const (
+	appManifestKind      = "app"
+	pipelineManifestKind = "pipeline"
+)
+
+type manifestSchemaOpts struct {
+	kind  string
+	write bool
+
+	ws archer.WorkspaceFileReadWriter
+	w  io.Writer
+}
+
+// Validate returns an error if the manifest kind is not supported.
+func (o *manifestSchemaOpts) Validate() error {
+	if o.kind != appManifestKind && o.kind != pipelineManifestKind {
+		return fmt.Errorf("manifest kind %s must be either %s or %s", o.kind, appManifestKind, pipelineManifestKind)
+	}
+	return nil
+}
+
+// Execute prints the JSON Schema of the manifest, or saves it next to the manifests in the workspace.
+func (o *manifestSchemaOpts) Execute() error {
+	schema, fileName, err := manifestSchema(o.kind)
+	if err != nil {
+		return err
+	}
+	if !o.write {
+		_, err := o.w.Write(schema)
+		return err
+	}
+	path, err := o.ws.WriteFile(schema, fileName)
+	if err != nil {
+		return fmt.Errorf("write %s manifest schema: %w", o.kind, err)
+	}
+	log.Successf("Wrote the %s manifest schema at %s\n", o.kind, color.HighlightResource(path))
+	return nil
+}
+
+// manifestSchema returns the JSON Schema of a kind of manifest along with the name of the file it's saved to.
+func manifestSchema(kind string) ([]byte, string, error) {
+	var schema []byte
+	var fileName string
+	var err error
+	switch kind {
+	case appManifestKind:
+		schema, err = manifest.AppSchema()
+		fileName = manifest.AppSchemaFileName
+	case pipelineManifestKind:
+		schema, err = manifest.PipelineSchema()
+		fileName = manifest.PipelineSchemaFileName
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("generate %s manifest schema: %w", kind, err)
+	}
+	return schema, fileName, nil
+}
+
+// BuildManifestSchemaCmd builds the command for printing the JSON Schema of manifests.
+func BuildManifestSchemaCmd() *cobra.Command {
+	opts := manifestSchemaOpts{
+		kind: appManifestKind,
+		w:    os.Stdout,
+	}
+	cmd := &cobra.Command{
+		Use:   "schema [app|pipeline]",
+		Short: "Prints the JSON Schema of application or pipeline manifests.",
+		Long: `Prints the JSON Schema of application or pipeline manifests.
+Manifests reference the schema from their first line so that YAML language servers provide completion and validation.`,
+		Example: `
+  Print the schema of application manifests.
+  /code $ dw_run.sh manifest schema app
+  Save the schema of the pipeline manifest in the workspace.
+  /code $ dw_run.sh manifest schema pipeline --write`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.kind = args[0]
+			}
+			if !opts.write {
+				return nil
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			opts.ws = ws
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().BoolVar(&opts.write, "write", false, "Save the schema next to the manifests in the workspace.")
+	return cmd
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestSchemaOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inKind string
+
+		wantedErr error
+	}{
+		"app": {
+			inKind: "app",
+		},
+		"pipeline": {
+			inKind: "pipeline",
+		},
+		"unknown kind": {
+			inKind:    "environment",
+			wantedErr: errors.New("manifest kind environment must be either app or pipeline"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			opts := &manifestSchemaOpts{kind: tc.inKind}
+
+			err := opts.Validate()
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestManifestSchemaOpts_Execute(t *testing.T) {
+	appSchema, err := manifest.AppSchema()
+	require.NoError(t, err)
+	pipelineSchema, err := manifest.PipelineSchema()
+	require.NoError(t, err)
+	mockErr := errors.New("some error")
+
+	testCases := map[string]struct {
+		inKind  string
+		inWrite bool
+		mockWs  func(m *mocks.MockWorkspaceFileReadWriter)
+
+		wantedErr    error
+		wantedOutput []byte
+	}{
+		"prints the app schema": {
+			inKind:       "app",
+			mockWs:       func(m *mocks.MockWorkspaceFileReadWriter) {},
+			wantedOutput: appSchema,
+		},
+		"writes the pipeline schema to the workspace": {
+			inKind:  "pipeline",
+			inWrite: true,
+			mockWs: func(m *mocks.MockWorkspaceFileReadWriter) {
+				m.EXPECT().WriteFile(pipelineSchema, "pipeline.schema.json").Return("/ecs-project/pipeline.schema.json", nil)
+			},
+		},
+		"fails to write the schema": {
+			inKind:  "app",
+			inWrite: true,
+			mockWs: func(m *mocks.MockWorkspaceFileReadWriter) {
+				m.EXPECT().WriteFile(appSchema, "app.schema.json").Return("", mockErr)
+			},
+			wantedErr: fmt.Errorf("write app manifest schema: %w", mockErr),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspaceFileReadWriter(ctrl)
+			tc.mockWs(mockWs)
+			b := &bytes.Buffer{}
+			opts := &manifestSchemaOpts{
+				kind:  tc.inKind,
+				write: tc.inWrite,
+				ws:    mockWs,
+				w:     b,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, string(tc.wantedOutput), b.String())
+		})
+	}
+}
@@ -7,11 +7,14 @@ package mocks
 import (
 	archer "github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	ecr "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	ecs "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
+	docker "github.com/aws/amazon-ecs-cli-v2/internal/pkg/build/docker"
 	describe "github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
 	command "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
 	session "github.com/aws/aws-sdk-go/aws/session"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockactionCommand is a mock of actionCommand interface
@@ -329,6 +332,162 @@ func (mr *MockecrServiceMockRecorder) GetECRAuth() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetECRAuth", reflect.TypeOf((*MockecrService)(nil).GetECRAuth))
 }
 
+// ImageDigest mocks base method
+func (m *MockecrService) ImageDigest(repoName, tag string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageDigest", repoName, tag)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImageDigest indicates an expected call of ImageDigest
+func (mr *MockecrServiceMockRecorder) ImageDigest(repoName, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageDigest", reflect.TypeOf((*MockecrService)(nil).ImageDigest), repoName, tag)
+}
+
+// MockecsService is a mock of ecsService interface
+type MockecsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockecsServiceMockRecorder
+}
+
+// MockecsServiceMockRecorder is the mock recorder for MockecsService
+type MockecsServiceMockRecorder struct {
+	mock *MockecsService
+}
+
+// NewMockecsService creates a new mock instance
+func NewMockecsService(ctrl *gomock.Controller) *MockecsService {
+	mock := &MockecsService{ctrl: ctrl}
+	mock.recorder = &MockecsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockecsService) EXPECT() *MockecsServiceMockRecorder {
+	return m.recorder
+}
+
+// WaitForSteadyState mocks base method
+func (m *MockecsService) WaitForSteadyState(cluster, service string, timeout time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForSteadyState", cluster, service, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForSteadyState indicates an expected call of WaitForSteadyState
+func (mr *MockecsServiceMockRecorder) WaitForSteadyState(cluster, service, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForSteadyState", reflect.TypeOf((*MockecsService)(nil).WaitForSteadyState), cluster, service, timeout)
+}
+
+// RunTask mocks base method
+func (m *MockecsService) RunTask(in ecs.RunTaskInput) (*ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTask", in)
+	ret0, _ := ret[0].(*ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunTask indicates an expected call of RunTask
+func (mr *MockecsServiceMockRecorder) RunTask(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTask", reflect.TypeOf((*MockecsService)(nil).RunTask), in)
+}
+
+// MockstackResourceGetter is a mock of stackResourceGetter interface
+type MockstackResourceGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockstackResourceGetterMockRecorder
+}
+
+// MockstackResourceGetterMockRecorder is the mock recorder for MockstackResourceGetter
+type MockstackResourceGetterMockRecorder struct {
+	mock *MockstackResourceGetter
+}
+
+// NewMockstackResourceGetter creates a new mock instance
+func NewMockstackResourceGetter(ctrl *gomock.Controller) *MockstackResourceGetter {
+	mock := &MockstackResourceGetter{ctrl: ctrl}
+	mock.recorder = &MockstackResourceGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockstackResourceGetter) EXPECT() *MockstackResourceGetterMockRecorder {
+	return m.recorder
+}
+
+// StackResourceID mocks base method
+func (m *MockstackResourceGetter) StackResourceID(stackName, logicalID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StackResourceID", stackName, logicalID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StackResourceID indicates an expected call of StackResourceID
+func (mr *MockstackResourceGetterMockRecorder) StackResourceID(stackName, logicalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StackResourceID", reflect.TypeOf((*MockstackResourceGetter)(nil).StackResourceID), stackName, logicalID)
+}
+
+// MockserviceRestarter is a mock of serviceRestarter interface
+type MockserviceRestarter struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceRestarterMockRecorder
+}
+
+// MockserviceRestarterMockRecorder is the mock recorder for MockserviceRestarter
+type MockserviceRestarterMockRecorder struct {
+	mock *MockserviceRestarter
+}
+
+// NewMockserviceRestarter creates a new mock instance
+func NewMockserviceRestarter(ctrl *gomock.Controller) *MockserviceRestarter {
+	mock := &MockserviceRestarter{ctrl: ctrl}
+	mock.recorder = &MockserviceRestarterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockserviceRestarter) EXPECT() *MockserviceRestarterMockRecorder {
+	return m.recorder
+}
+
+// RestartService mocks base method
+func (m *MockserviceRestarter) RestartService(cluster, service string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestartService", cluster, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestartService indicates an expected call of RestartService
+func (mr *MockserviceRestarterMockRecorder) RestartService(cluster, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartService", reflect.TypeOf((*MockserviceRestarter)(nil).RestartService), cluster, service)
+}
+
+// WaitForSteadyState mocks base method
+func (m *MockserviceRestarter) WaitForSteadyState(cluster, service string, timeout time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForSteadyState", cluster, service, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForSteadyState indicates an expected call of WaitForSteadyState
+func (mr *MockserviceRestarterMockRecorder) WaitForSteadyState(cluster, service, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForSteadyState", reflect.TypeOf((*MockserviceRestarter)(nil).WaitForSteadyState), cluster, service, timeout)
+}
+
 // MockdockerService is a mock of dockerService interface
 type MockdockerService struct {
 	ctrl     *gomock.Controller
@@ -353,17 +512,17 @@ func (m *MockdockerService) EXPECT() *MockdockerServiceMockRecorder {
 }
 
 // Build mocks base method
-func (m *MockdockerService) Build(uri, tag, path string) error {
+func (m *MockdockerService) Build(in *docker.BuildArguments) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Build", uri, tag, path)
+	ret := m.ctrl.Call(m, "Build", in)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Build indicates an expected call of Build
-func (mr *MockdockerServiceMockRecorder) Build(uri, tag, path interface{}) *gomock.Call {
+func (mr *MockdockerServiceMockRecorder) Build(in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockdockerService)(nil).Build), uri, tag, path)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockdockerService)(nil).Build), in)
 }
 
 // Login mocks base method
@@ -394,6 +553,34 @@ func (mr *MockdockerServiceMockRecorder) Push(uri, tag interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockdockerService)(nil).Push), uri, tag)
 }
 
+// Pull mocks base method
+func (m *MockdockerService) Pull(uri, tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", uri, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pull indicates an expected call of Pull
+func (mr *MockdockerServiceMockRecorder) Pull(uri, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockdockerService)(nil).Pull), uri, tag)
+}
+
+// Tag mocks base method
+func (m *MockdockerService) Tag(uri, tag, targetURI string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", uri, tag, targetURI)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Tag indicates an expected call of Tag
+func (mr *MockdockerServiceMockRecorder) Tag(uri, tag, targetURI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockdockerService)(nil).Tag), uri, tag, targetURI)
+}
+
 // Mockrunner is a mock of runner interface
 type Mockrunner struct {
 	ctrl     *gomock.Controller
@@ -686,6 +873,200 @@ func (mr *MockwebAppDescriberMockRecorder) StackResources(envName interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StackResources", reflect.TypeOf((*MockwebAppDescriber)(nil).StackResources), envName)
 }
 
+// MockdeployedImageGetter is a mock of deployedImageGetter interface
+type MockdeployedImageGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeployedImageGetterMockRecorder
+}
+
+// MockdeployedImageGetterMockRecorder is the mock recorder for MockdeployedImageGetter
+type MockdeployedImageGetterMockRecorder struct {
+	mock *MockdeployedImageGetter
+}
+
+// NewMockdeployedImageGetter creates a new mock instance
+func NewMockdeployedImageGetter(ctrl *gomock.Controller) *MockdeployedImageGetter {
+	mock := &MockdeployedImageGetter{ctrl: ctrl}
+	mock.recorder = &MockdeployedImageGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockdeployedImageGetter) EXPECT() *MockdeployedImageGetterMockRecorder {
+	return m.recorder
+}
+
+// Image mocks base method
+func (m *MockdeployedImageGetter) Image(envName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Image", envName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Image indicates an expected call of Image
+func (mr *MockdeployedImageGetterMockRecorder) Image(envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Image", reflect.TypeOf((*MockdeployedImageGetter)(nil).Image), envName)
+}
+
+// MockdeploymentStore is a mock of deploymentStore interface
+type MockdeploymentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeploymentStoreMockRecorder
+}
+
+// MockdeploymentStoreMockRecorder is the mock recorder for MockdeploymentStore
+type MockdeploymentStoreMockRecorder struct {
+	mock *MockdeploymentStore
+}
+
+// NewMockdeploymentStore creates a new mock instance
+func NewMockdeploymentStore(ctrl *gomock.Controller) *MockdeploymentStore {
+	mock := &MockdeploymentStore{ctrl: ctrl}
+	mock.recorder = &MockdeploymentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockdeploymentStore) EXPECT() *MockdeploymentStoreMockRecorder {
+	return m.recorder
+}
+
+// ListDeployments mocks base method
+func (m *MockdeploymentStore) ListDeployments(projectName, appName, envName string) ([]*archer.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeployments", projectName, appName, envName)
+	ret0, _ := ret[0].([]*archer.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeployments indicates an expected call of ListDeployments
+func (mr *MockdeploymentStoreMockRecorder) ListDeployments(projectName, appName, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeployments", reflect.TypeOf((*MockdeploymentStore)(nil).ListDeployments), projectName, appName, envName)
+}
+
+// CreateDeployment mocks base method
+func (m *MockdeploymentStore) CreateDeployment(d *archer.Deployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeployment", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeployment indicates an expected call of CreateDeployment
+func (mr *MockdeploymentStoreMockRecorder) CreateDeployment(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockdeploymentStore)(nil).CreateDeployment), d)
+}
+
+// MockdeploymentLocker is a mock of deploymentLocker interface
+type MockdeploymentLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeploymentLockerMockRecorder
+}
+
+// MockdeploymentLockerMockRecorder is the mock recorder for MockdeploymentLocker
+type MockdeploymentLockerMockRecorder struct {
+	mock *MockdeploymentLocker
+}
+
+// NewMockdeploymentLocker creates a new mock instance
+func NewMockdeploymentLocker(ctrl *gomock.Controller) *MockdeploymentLocker {
+	mock := &MockdeploymentLocker{ctrl: ctrl}
+	mock.recorder = &MockdeploymentLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockdeploymentLocker) EXPECT() *MockdeploymentLockerMockRecorder {
+	return m.recorder
+}
+
+// AcquireDeploymentLock mocks base method
+func (m *MockdeploymentLocker) AcquireDeploymentLock(lock *archer.DeploymentLock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireDeploymentLock", lock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcquireDeploymentLock indicates an expected call of AcquireDeploymentLock
+func (mr *MockdeploymentLockerMockRecorder) AcquireDeploymentLock(lock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireDeploymentLock", reflect.TypeOf((*MockdeploymentLocker)(nil).AcquireDeploymentLock), lock)
+}
+
+// GetDeploymentLock mocks base method
+func (m *MockdeploymentLocker) GetDeploymentLock(projectName, appName, envName string) (*archer.DeploymentLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentLock", projectName, appName, envName)
+	ret0, _ := ret[0].(*archer.DeploymentLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentLock indicates an expected call of GetDeploymentLock
+func (mr *MockdeploymentLockerMockRecorder) GetDeploymentLock(projectName, appName, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentLock", reflect.TypeOf((*MockdeploymentLocker)(nil).GetDeploymentLock), projectName, appName, envName)
+}
+
+// ReleaseDeploymentLock mocks base method
+func (m *MockdeploymentLocker) ReleaseDeploymentLock(projectName, appName, envName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDeploymentLock", projectName, appName, envName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDeploymentLock indicates an expected call of ReleaseDeploymentLock
+func (mr *MockdeploymentLockerMockRecorder) ReleaseDeploymentLock(projectName, appName, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDeploymentLock", reflect.TypeOf((*MockdeploymentLocker)(nil).ReleaseDeploymentLock), projectName, appName, envName)
+}
+
+// MockenvOutputsGetter is a mock of envOutputsGetter interface
+type MockenvOutputsGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockenvOutputsGetterMockRecorder
+}
+
+// MockenvOutputsGetterMockRecorder is the mock recorder for MockenvOutputsGetter
+type MockenvOutputsGetterMockRecorder struct {
+	mock *MockenvOutputsGetter
+}
+
+// NewMockenvOutputsGetter creates a new mock instance
+func NewMockenvOutputsGetter(ctrl *gomock.Controller) *MockenvOutputsGetter {
+	mock := &MockenvOutputsGetter{ctrl: ctrl}
+	mock.recorder = &MockenvOutputsGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockenvOutputsGetter) EXPECT() *MockenvOutputsGetterMockRecorder {
+	return m.recorder
+}
+
+// Outputs mocks base method
+func (m *MockenvOutputsGetter) Outputs(env *archer.Environment) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Outputs", env)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Outputs indicates an expected call of Outputs
+func (mr *MockenvOutputsGetterMockRecorder) Outputs(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Outputs", reflect.TypeOf((*MockenvOutputsGetter)(nil).Outputs), env)
+}
+
 // MockstoreReader is a mock of storeReader interface
 type MockstoreReader struct {
 	ctrl     *gomock.Controller
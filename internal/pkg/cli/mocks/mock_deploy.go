@@ -77,6 +77,21 @@ func (mr *MockenvironmentDeployerMockRecorder) DeleteEnvironment(projName, envNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*MockenvironmentDeployer)(nil).DeleteEnvironment), projName, envName)
 }
 
+// DiffEnvironment mocks base method
+func (m *MockenvironmentDeployer) DiffEnvironment(env *deploy.CreateEnvironmentInput) ([]deploy.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffEnvironment", env)
+	ret0, _ := ret[0].([]deploy.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffEnvironment indicates an expected call of DiffEnvironment
+func (mr *MockenvironmentDeployerMockRecorder) DiffEnvironment(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffEnvironment", reflect.TypeOf((*MockenvironmentDeployer)(nil).DiffEnvironment), env)
+}
+
 // MockpipelineDeployer is a mock of pipelineDeployer interface
 type MockpipelineDeployer struct {
 	ctrl     *gomock.Controller
@@ -143,6 +158,21 @@ func (mr *MockpipelineDeployerMockRecorder) PipelineExists(env interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineExists", reflect.TypeOf((*MockpipelineDeployer)(nil).PipelineExists), env)
 }
 
+// DiffPipeline mocks base method
+func (m *MockpipelineDeployer) DiffPipeline(env *deploy.CreatePipelineInput) ([]deploy.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffPipeline", env)
+	ret0, _ := ret[0].([]deploy.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffPipeline indicates an expected call of DiffPipeline
+func (mr *MockpipelineDeployerMockRecorder) DiffPipeline(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffPipeline", reflect.TypeOf((*MockpipelineDeployer)(nil).DiffPipeline), env)
+}
+
 // AddPipelineResourcesToProject mocks base method
 func (m *MockpipelineDeployer) AddPipelineResourcesToProject(project *archer.Project, region string) error {
 	m.ctrl.T.Helper()
@@ -385,6 +415,21 @@ func (mr *MockdeployerMockRecorder) DeleteEnvironment(projName, envName interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*Mockdeployer)(nil).DeleteEnvironment), projName, envName)
 }
 
+// DiffEnvironment mocks base method
+func (m *Mockdeployer) DiffEnvironment(env *deploy.CreateEnvironmentInput) ([]deploy.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffEnvironment", env)
+	ret0, _ := ret[0].([]deploy.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffEnvironment indicates an expected call of DiffEnvironment
+func (mr *MockdeployerMockRecorder) DiffEnvironment(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffEnvironment", reflect.TypeOf((*Mockdeployer)(nil).DiffEnvironment), env)
+}
+
 // DeployProject mocks base method
 func (m *Mockdeployer) DeployProject(in *deploy.CreateProjectInput) error {
 	m.ctrl.T.Helper()
@@ -484,6 +529,21 @@ func (mr *MockdeployerMockRecorder) PipelineExists(env interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineExists", reflect.TypeOf((*Mockdeployer)(nil).PipelineExists), env)
 }
 
+// DiffPipeline mocks base method
+func (m *Mockdeployer) DiffPipeline(env *deploy.CreatePipelineInput) ([]deploy.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffPipeline", env)
+	ret0, _ := ret[0].([]deploy.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffPipeline indicates an expected call of DiffPipeline
+func (mr *MockdeployerMockRecorder) DiffPipeline(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffPipeline", reflect.TypeOf((*Mockdeployer)(nil).DiffPipeline), env)
+}
+
 // AddPipelineResourcesToProject mocks base method
 func (m *Mockdeployer) AddPipelineResourcesToProject(project *archer.Project, region string) error {
 	m.ctrl.T.Helper()
@@ -212,12 +212,12 @@ func (opts *InitPipelineOpts) createPipelineManifest() (string, error) {
 		return "", fmt.Errorf("could not create pipeline: %w", err)
 	}
 
-	manifest, err := manifest.CreatePipeline(pipelineName, provider, opts.Environments)
+	mft, err := manifest.CreatePipeline(pipelineName, provider, opts.Environments)
 	if err != nil {
 		return "", fmt.Errorf("generate a manifest: %w", err)
 	}
 
-	manifestBytes, err := manifest.Marshal()
+	manifestBytes, err := mft.Marshal()
 	if err != nil {
 		return "", fmt.Errorf("marshal manifest: %w", err)
 	}
@@ -225,6 +225,13 @@ func (opts *InitPipelineOpts) createPipelineManifest() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("write file %s to workspace: %w", workspace.PipelineFileName, err)
 	}
+	schema, err := manifest.PipelineSchema()
+	if err != nil {
+		return "", fmt.Errorf("generate manifest schema: %w", err)
+	}
+	if _, err := opts.workspace.WriteFile(schema, manifest.PipelineSchemaFileName); err != nil {
+		return "", fmt.Errorf("write file %s to workspace: %w", manifest.PipelineSchemaFileName, err)
+	}
 
 	return manifestPath, nil
 }
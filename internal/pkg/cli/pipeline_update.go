@@ -6,11 +6,14 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
@@ -36,6 +39,8 @@ const (
 	fmtUpdatePipelineStart    = "Proposing infrastructure changes for the pipeline: %s"
 	fmtUpdatePipelineComplete = "Successfully updated pipeline: %s"
 
+	fmtDiffPipelineFailed = "Failed to propose changes for pipeline: %s."
+
 	fmtUpdateEnvPrompt = "Are you sure you want to update an existing pipeline: %s?"
 )
 
@@ -46,6 +51,8 @@ type UpdatePipelineOpts struct {
 	PipelineFile     string
 	PipelineName     string
 	SkipConfirmation bool
+	DryRun           bool
+	ShouldOutputJSON bool
 
 	pipelineDeployer pipelineDeployer
 	project          *archer.Project
@@ -53,6 +60,7 @@ type UpdatePipelineOpts struct {
 	region           string
 	envStore         archer.EnvironmentStore
 	ws               archer.Workspace
+	w                io.Writer
 
 	*GlobalOpts
 }
@@ -62,6 +70,7 @@ func NewUpdatePipelineOpts() *UpdatePipelineOpts {
 	return &UpdatePipelineOpts{
 		GlobalOpts: NewGlobalOpts(),
 		prog:       termprogress.NewSpinner(),
+		w:          os.Stdout,
 	}
 }
 
@@ -70,6 +79,9 @@ func (opts *UpdatePipelineOpts) Validate() error {
 	if opts.PipelineFile == "" {
 		return errNoPipelineFile
 	}
+	if opts.ShouldOutputJSON && !opts.DryRun {
+		return errJSONWithoutDryRun
+	}
 
 	return nil
 }
@@ -172,16 +184,31 @@ func (opts *UpdatePipelineOpts) deployPipeline(in *deploy.CreatePipelineInput) e
 	return nil
 }
 
+// diffPipeline writes the changes that deploying the pipeline would make to its stack.
+func (opts *UpdatePipelineOpts) diffPipeline(in *deploy.CreatePipelineInput) error {
+	opts.prog.Start(fmt.Sprintf(fmtUpdatePipelineStart, color.HighlightUserInput(opts.PipelineName)))
+	changes, err := opts.pipelineDeployer.DiffPipeline(in)
+	if err != nil {
+		opts.prog.Stop(log.Serrorf(fmtDiffPipelineFailed, color.HighlightUserInput(opts.PipelineName)))
+		return fmt.Errorf("diff pipeline: %w", err)
+	}
+	opts.prog.Stop("")
+	return writeResourceChanges(opts.w, stack.NewPipelineStackConfig(in).StackName(), changes, opts.ShouldOutputJSON)
+}
+
 // Execute create a new pipeline or update the current pipeline if it already exists.
+// In a dry run, it only shows the changes that the deployment would make to the pipeline's stack.
 func (opts *UpdatePipelineOpts) Execute() error {
-	// bootstrap pipeline resources
-	opts.prog.Start(fmt.Sprintf(fmtAddPipelineResourcesStart, color.HighlightUserInput(opts.ProjectName())))
-	err := opts.pipelineDeployer.AddPipelineResourcesToProject(opts.project, opts.region)
-	if err != nil {
-		opts.prog.Stop(log.Serrorf(fmtAddPipelineResourcesFailed, color.HighlightUserInput(opts.ProjectName())))
-		return fmt.Errorf("add pipeline resources to project %s in %s: %w", opts.ProjectName(), opts.region, err)
+	// bootstrap pipeline resources, a dry run uses the existing ones.
+	if !opts.DryRun {
+		opts.prog.Start(fmt.Sprintf(fmtAddPipelineResourcesStart, color.HighlightUserInput(opts.ProjectName())))
+		err := opts.pipelineDeployer.AddPipelineResourcesToProject(opts.project, opts.region)
+		if err != nil {
+			opts.prog.Stop(log.Serrorf(fmtAddPipelineResourcesFailed, color.HighlightUserInput(opts.ProjectName())))
+			return fmt.Errorf("add pipeline resources to project %s in %s: %w", opts.ProjectName(), opts.region, err)
+		}
+		opts.prog.Stop(log.Ssuccessf(fmtAddPipelineResourcesComplete, color.HighlightUserInput(opts.ProjectName())))
 	}
-	opts.prog.Stop(log.Ssuccessf(fmtAddPipelineResourcesComplete, color.HighlightUserInput(opts.ProjectName())))
 
 	// read pipeline manifest
 	data, err := opts.ws.ReadFile(workspace.PipelineFileName)
@@ -217,6 +244,9 @@ func (opts *UpdatePipelineOpts) Execute() error {
 		Stages:          stages,
 		ArtifactBuckets: artifactBuckets,
 	}
+	if opts.DryRun {
+		return opts.diffPipeline(deployPipelineInput)
+	}
 
 	if err := opts.deployPipeline(deployPipelineInput); err != nil {
 		return err
@@ -234,7 +264,10 @@ func BuildPipelineUpdateCmd() *cobra.Command {
 		Long:  `Deploys a pipeline for the applications in your workspace, using the environments associated with the applications.`,
 		Example: `
   Deploy an updated pipeline for the applications in your workspace:
-  /code $ dw_run.sh pipeline update`,
+  /code $ dw_run.sh pipeline update
+
+  Show the changes to the pipeline without deploying them:
+  /code $ dw_run.sh pipeline update --dry-run`,
 
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			store, err := store.New()
@@ -273,6 +306,8 @@ func BuildPipelineUpdateCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&opts.PipelineFile, pipelineFileFlag, pipelineFileFlagShort, workspace.PipelineFileName, pipelineFileFlagDescription)
 	cmd.Flags().BoolVar(&opts.SkipConfirmation, yesFlag, false, yesFlagDescription)
+	cmd.Flags().BoolVar(&opts.DryRun, dryRunFlag, false, dryRunFlagDescription)
+	cmd.Flags().BoolVar(&opts.ShouldOutputJSON, jsonFlag, false, dryRunJSONFlagDescription)
 
 	return cmd
 }
@@ -3,7 +3,12 @@
 
 package cli
 
-import termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
+import (
+	"fmt"
+	"sync"
+
+	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
+)
 
 // progress is the interface to inform the user that a long operation is taking place.
 type progress interface {
@@ -28,3 +33,94 @@ const (
 	textECSCluster      termprogress.Text = "- ECS Cluster to hold your services "
 	textALB             termprogress.Text = "- Application load balancer to distribute traffic "
 )
+
+// appProgressOrder is the order in which the progress of an application's resources appears on the terminal.
+var appProgressOrder = []termprogress.Text{textLogGroup, textDatabase, textTargetGroup, textListenerRule, textECSService}
+
+// Row descriptions displayed while deploying an application.
+const (
+	textLogGroup     termprogress.Text = "- Log group to store the logs of your application"
+	textDatabase     termprogress.Text = "- Database cluster for your application"
+	textTargetGroup  termprogress.Text = "- Target group to register your tasks with the load balancer"
+	textListenerRule termprogress.Text = "- Listener rules to route requests to your application"
+	textECSService   termprogress.Text = "- ECS service to run and maintain your tasks"
+)
+
+// multiProgress displays the progress of concurrent operations as rows under a single spinner.
+type multiProgress struct {
+	spinner progress
+
+	mu   sync.Mutex
+	rows []*progressRow
+}
+
+// progressRow displays the progress of one of the operations of a multiProgress.
+// It implements the progress interface so that an operation reports its progress the same way it would on its own.
+type progressRow struct {
+	parent *multiProgress
+	name   string // Columns identifying the operation, separated with the '\t' character.
+	status string
+}
+
+func newMultiProgress(spinner progress) *multiProgress {
+	return &multiProgress{
+		spinner: spinner,
+	}
+}
+
+// Row adds a row for an operation, displayed as pending until the operation starts.
+func (m *multiProgress) Row(name string) *progressRow {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	row := &progressRow{
+		parent: m,
+		name:   name,
+		status: "Pending",
+	}
+	m.rows = append(m.rows, row)
+	return row
+}
+
+// Start starts the spinner with a label and displays the rows under it.
+func (m *multiProgress) Start(label string) {
+	m.spinner.Start(label)
+	m.render()
+}
+
+// Stop stops the spinner and replaces it with a label, the rows stay on the screen.
+func (m *multiProgress) Stop(label string) {
+	m.spinner.Stop(label)
+}
+
+func (m *multiProgress) render() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var events []termprogress.TabRow
+	for _, row := range m.rows {
+		events = append(events, termprogress.TabRow(fmt.Sprintf("  %s\t%s", row.name, row.status)))
+	}
+	m.spinner.Events(events)
+}
+
+// Start replaces the status of the row with a label.
+func (r *progressRow) Start(label string) {
+	r.setStatus(label)
+}
+
+// Stop replaces the status of the row with a label, the last status is kept if the label is empty.
+func (r *progressRow) Stop(label string) {
+	if label == "" {
+		return
+	}
+	r.setStatus(label)
+}
+
+// Events is a no-op, there is only one line for each operation.
+func (r *progressRow) Events([]termprogress.TabRow) {}
+
+func (r *progressRow) setStatus(status string) {
+	r.parent.mu.Lock()
+	r.status = status
+	r.parent.mu.Unlock()
+	r.parent.render()
+}
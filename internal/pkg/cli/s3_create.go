@@ -11,7 +11,6 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // S3AddOpts contains the fields to collect to create the s3 environment variables.
@@ -62,27 +61,18 @@ func (o *S3AddOpts) Execute() error {
 	if err != nil {
 		return err
 	}
-	lbmft := mft.(*manifest.LBFargateManifest)
-	if lbmft.Variables == nil {
-		lbmft.Variables = make(map[string]string)
-	}
-	if lbmft.Environments == nil {
-		lbmft.Environments = make(map[string]manifest.LBFargateConfig)
-	}
 
 	for _, envName := range []string{"dev", "prod"} {
-		if _, ok := lbmft.Environments[envName]; !ok {
-			lbmft.Environments[envName] = manifest.LBFargateConfig{}
+		bucket := s3Bucket(project, envName)
+		if err := mft.Set(bucket, manifest.EnvPath(envName, manifest.VariablesKey, "S3_BUCKET")...); err != nil {
+			return fmt.Errorf("add environment variable S3_BUCKET: %w", err)
 		}
-		if lbmft.Environments[envName].Variables == nil {
-			envConf := lbmft.Environments[envName]
-			envConf.Variables = make(map[string]string)
-			lbmft.Environments[envName] = envConf
+		prefix := s3Prefix(o.appName)
+		if err := mft.Set(prefix, manifest.EnvPath(envName, manifest.VariablesKey, "S3_PREFIX")...); err != nil {
+			return fmt.Errorf("add environment variable S3_PREFIX: %w", err)
 		}
-		lbmft.Environments[envName].Variables["S3_BUCKET"] = fmt.Sprintf("%s-%s-storage", project, envName)
-		lbmft.Environments[envName].Variables["S3_PREFIX"] = fmt.Sprintf("/apps/%s", o.appName)
 	}
-	if err = o.writeManifest(lbmft); err != nil {
+	if err = o.writeManifest(mft); err != nil {
 		return err
 	}
 
@@ -90,16 +80,29 @@ func (o *S3AddOpts) Execute() error {
 	return nil
 }
 
-func (o *S3AddOpts) readManifest() (archer.Manifest, error) {
+// s3Bucket returns the name of the bucket storing the objects of the project's apps in the environment.
+func s3Bucket(project, envName string) string {
+	return fmt.Sprintf("%s-%s-storage", project, envName)
+}
+
+// s3Prefix returns the prefix of the app's objects in the bucket.
+func s3Prefix(appName string) string {
+	return fmt.Sprintf("/apps/%s", appName)
+}
+
+func (o *S3AddOpts) readManifest() (*manifest.Editor, error) {
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return nil, err
 	}
-	return manifest.UnmarshalApp(raw)
+	return manifest.NewEditor(raw)
 }
 
-func (o *S3AddOpts) writeManifest(manifest *manifest.LBFargateManifest) error {
-	manifestBytes, err := yaml.Marshal(manifest)
+func (o *S3AddOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
+	}
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3AddOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	written := mockManifestEdit(mockWs, editTestManifest)
+
+	opts := S3AddOpts{
+		appName:    "frontend",
+		ws:         mockWs,
+		GlobalOpts: &GlobalOpts{projectName: "dw-run"},
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+      S3_BUCKET: dw-run-prod-storage
+      S3_PREFIX: /apps/frontend
+  dev:
+    variables:
+      S3_BUCKET: dw-run-dev-storage
+      S3_PREFIX: /apps/frontend
+`, *written)
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // S3DeleteOpts contains the fields to collect to delete the s3 environment variables.
@@ -61,14 +60,13 @@ func (o *S3DeleteOpts) Execute() error {
 	if err != nil {
 		return err
 	}
-	lbmft := mft.(*manifest.LBFargateManifest)
 
 	for _, envName := range []string{"dev", "prod"} {
-		delete(lbmft.Environments[envName].Variables, "S3_BUCKET")
-		delete(lbmft.Environments[envName].Variables, "S3_PREFIX")
+		mft.Delete(manifest.EnvPath(envName, manifest.VariablesKey, "S3_BUCKET")...)
+		mft.Delete(manifest.EnvPath(envName, manifest.VariablesKey, "S3_PREFIX")...)
 	}
 
-	if err = o.writeManifest(lbmft); err != nil {
+	if err = o.writeManifest(mft); err != nil {
 		return err
 	}
 
@@ -76,16 +74,19 @@ func (o *S3DeleteOpts) Execute() error {
 	return nil
 }
 
-func (o *S3DeleteOpts) readManifest() (archer.Manifest, error) {
+func (o *S3DeleteOpts) readManifest() (*manifest.Editor, error) {
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return nil, err
 	}
-	return manifest.UnmarshalApp(raw)
+	return manifest.NewEditor(raw)
 }
 
-func (o *S3DeleteOpts) writeManifest(manifest *manifest.LBFargateManifest) error {
-	manifestBytes, err := yaml.Marshal(manifest)
+func (o *S3DeleteOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
+	}
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3DeleteOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	written := mockManifestEdit(mockWs, `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      S3_BUCKET: dw-run-prod-storage # Created by the environment.
+      LOG_LEVEL: warn
+      S3_PREFIX: /apps/frontend
+`)
+
+	opts := S3DeleteOpts{
+		appName:    "frontend",
+		ws:         mockWs,
+		GlobalOpts: &GlobalOpts{projectName: "dw-run"},
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, editTestManifest, *written)
+}
@@ -19,6 +19,10 @@ func BuildSecretCmd() *cobra.Command {
 
 	cmd.AddCommand(BuildSecretAddCmd())
 	cmd.AddCommand(BuildSecretDeleteCmd())
+	cmd.AddCommand(BuildSecretImportCmd())
+	cmd.AddCommand(BuildSecretListCmd())
+	cmd.AddCommand(BuildSecretRotateCmd())
+	cmd.AddCommand(BuildSecretShowCmd())
 
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
@@ -7,12 +7,12 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // SecretAddOpts contains the fields to collect to create a secret.
@@ -21,11 +21,12 @@ type SecretAddOpts struct {
 	envName     string
 	secretName  string
 	secretValue string
+	backend     string
 
 	manifestPath string
 
-	secretManager archer.SecretsManager
-	storeReader   storeReader
+	secretBackends archer.SecretBackends
+	storeReader    storeReader
 
 	ws archer.Workspace
 
@@ -51,6 +52,9 @@ func (o *SecretAddOpts) Validate() error {
 			return err
 		}
 	}
+	if _, err := o.secretBackends.SecretBackend(o.backend); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -77,16 +81,8 @@ func (o *SecretAddOpts) Execute() error {
 		return err
 	}
 
-	name := strings.ToLower(o.secretName)
-	name = strings.ReplaceAll(name, "_", "-")
-
-	key := fmt.Sprintf("/ecs-cli-v2/%s/applications/%s/secrets/%s", o.GlobalOpts.ProjectName(),
-		o.appName, name)
-	if o.envName != "" {
-		key = fmt.Sprintf("%s-%s", key, o.envName)
-	}
-
-	if _, err := o.secretManager.CreateSecret(key, o.secretValue); err != nil {
+	secret, err := o.createSecret(o.secretName, o.secretValue)
+	if err != nil {
 		return err
 	}
 
@@ -94,29 +90,11 @@ func (o *SecretAddOpts) Execute() error {
 		color.HighlightResource(o.appName), color.HighlightResource(o.GlobalOpts.ProjectName()))
 
 	// save the secret to the manifest
-	lbmft := mft.(*manifest.LBFargateManifest)
-
-	if o.envName == "" {
-		if lbmft.Secrets == nil {
-			lbmft.Secrets = make(map[string]string)
-		}
-		lbmft.Secrets[o.secretName] = key
-	} else {
-		if lbmft.Environments == nil {
-			lbmft.Environments = make(map[string]manifest.LBFargateConfig)
-		}
-		if _, ok := lbmft.Environments[o.envName]; !ok {
-			lbmft.Environments[o.envName] = manifest.LBFargateConfig{}
-		}
-		if lbmft.Environments[o.envName].Secrets == nil {
-			envConf := lbmft.Environments[o.envName]
-			envConf.Secrets = make(map[string]string)
-			lbmft.Environments[o.envName] = envConf
-		}
-		lbmft.Environments[o.envName].Secrets[o.secretName] = key
+	if err := mft.SetSecret(secret, manifest.EnvPath(o.envName, manifest.SecretsKey, o.secretName)...); err != nil {
+		return fmt.Errorf("add secret %s: %w", o.secretName, err)
 	}
 
-	if err = o.writeManifest(lbmft); err != nil {
+	if err = o.writeManifest(mft); err != nil {
 		return err
 	}
 
@@ -124,16 +102,53 @@ func (o *SecretAddOpts) Execute() error {
 	return nil
 }
 
-func (o *SecretAddOpts) readManifest() (archer.Manifest, error) {
+// createSecret stores the value of the secret in the backend, and returns the secret to reference in the manifest.
+func (o *SecretAddOpts) createSecret(secretName, secretValue string) (manifest.Secret, error) {
+	secretManager, err := o.secretBackends.SecretBackend(o.backend)
+	if err != nil {
+		return manifest.Secret{}, err
+	}
+	key := secretParameterName(o.ProjectName(), o.appName, o.envName, secretName)
+	if _, err := secretManager.CreateSecret(key, secretValue); err != nil {
+		return manifest.Secret{}, err
+	}
+	secret := manifest.Secret{From: key}
+	if o.backend != manifest.SecretBackendSSM {
+		secret.Backend = o.backend
+	}
+	return secret, nil
+}
+
+// secretParametersPath returns the path of the SSM parameters, or the prefix of the Secrets Manager secrets, holding the values of the app's secrets.
+func secretParametersPath(projectName, appName string) string {
+	return fmt.Sprintf("/ecs-cli-v2/%s/applications/%s/secrets", projectName, appName)
+}
+
+// secretParameterName returns the name of the SSM parameter, or of the Secrets Manager secret, holding the value of the app's secret.
+func secretParameterName(projectName, appName, envName, secretName string) string {
+	name := strings.ToLower(secretName)
+	name = strings.ReplaceAll(name, "_", "-")
+
+	key := fmt.Sprintf("%s/%s", secretParametersPath(projectName, appName), name)
+	if envName != "" {
+		key = fmt.Sprintf("%s-%s", key, envName)
+	}
+	return key
+}
+
+func (o *SecretAddOpts) readManifest() (*manifest.Editor, error) {
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return nil, err
 	}
-	return manifest.UnmarshalApp(raw)
+	return manifest.NewEditor(raw)
 }
 
-func (o *SecretAddOpts) writeManifest(mft *manifest.LBFargateManifest) error {
-	manifestBytes, err := yaml.Marshal(mft)
+func (o *SecretAddOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
+	}
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -272,9 +287,13 @@ func BuildSecretAddCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("new workspace: %w", err)
 			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
 			opts.ws = ws
 			opts.storeReader = ssmStore
-			opts.secretManager = ssmStore
+			opts.secretBackends = secretBackends
 
 			return nil
 		}),
@@ -293,6 +312,7 @@ func BuildSecretAddCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
 	cmd.Flags().StringVarP(&opts.secretName, "secret-name", "n", "", "Name of the secret, e.g. MY_SECRET.")
 	cmd.Flags().StringVarP(&opts.secretValue, "secret-value", "v", "", "Value to encrypt.")
+	cmd.Flags().StringVar(&opts.backend, secretBackendFlag, manifest.SecretBackendSSM, secretBackendFlagDescription)
 	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
 	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
 
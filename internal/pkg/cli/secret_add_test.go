@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretAddOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inEnvName string
+		inBackend string
+
+		wantedKey     string
+		wantedContent string
+	}{
+		"adds an SSM parameter to the default configuration": {
+			inBackend: manifest.SecretBackendSSM,
+
+			wantedKey: "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key",
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+  API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+`,
+		},
+		"adds a Secrets Manager secret to an environment": {
+			inEnvName: "prod",
+			inBackend: manifest.SecretBackendSecretsManager,
+
+			wantedKey: "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod",
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+    secrets:
+      API_KEY:
+        from: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod
+        backend: secretsmanager
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			written := mockManifestEdit(mockWs, editTestManifest)
+			mockSecretManager := mocks.NewMockSecretsManager(ctrl)
+			mockSecretManager.EXPECT().CreateSecret(tc.wantedKey, "s3cr3t").Return("1", nil)
+			mockBackends := mocks.NewMockSecretBackends(ctrl)
+			mockBackends.EXPECT().SecretBackend(tc.inBackend).Return(mockSecretManager, nil)
+
+			opts := SecretAddOpts{
+				appName:        "frontend",
+				envName:        tc.inEnvName,
+				secretName:     "API_KEY",
+				secretValue:    "s3cr3t",
+				backend:        tc.inBackend,
+				secretBackends: mockBackends,
+				ws:             mockWs,
+				GlobalOpts:     &GlobalOpts{projectName: "dw-run"},
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, *written)
+		})
+	}
+}
@@ -2,18 +2,16 @@ package cli
 
 import (
 	"fmt"
-	"reflect"
-	"strings"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // SecretDeleteOpts contains the fields to collect to delete a secret.
@@ -21,12 +19,13 @@ type SecretDeleteOpts struct {
 	appName    string
 	envName    string
 	secretName string
+	force      bool // Delete the secret without a recovery window.
 
 	manifestPath string
-	manifest     *manifest.LBFargateManifest
+	manifest     *manifest.Editor
 
-	secretManager archer.SecretsManager
-	storeReader   storeReader
+	secretBackends archer.SecretBackends
+	storeReader    storeReader
 
 	ws archer.Workspace
 
@@ -76,27 +75,23 @@ func (o *SecretDeleteOpts) Execute() error {
 		}
 	}
 
-	name := strings.ToLower(o.secretName)
-	name = strings.ReplaceAll(name, "_", "-")
-
-	key := fmt.Sprintf("/ecs-cli-v2/%s/applications/%s/secrets/%s", o.GlobalOpts.ProjectName(),
-		o.appName, name)
-	if o.envName != "" {
-		key = fmt.Sprintf("%s-%s", key, o.envName)
+	// Delete the secret the manifest references, from the backend storing it.
+	secret, ok := o.manifest.GetSecret(manifest.EnvPath(o.envName, manifest.SecretsKey, o.secretName)...)
+	if !ok {
+		secret = manifest.Secret{From: secretParameterName(o.ProjectName(), o.appName, o.envName, o.secretName)}
 	}
-
-	if err := o.secretManager.DeleteSecret(key); err != nil {
+	secretManager, err := o.secretBackends.SecretBackend(secret.Backend)
+	if err != nil {
+		return err
+	}
+	if err := deleteSecret(secretManager, secret.From, o.force); err != nil {
 		return err
 	}
 
 	log.Successf("Deleted %s in %s under project %s.\n", color.HighlightUserInput(o.secretName),
 		color.HighlightResource(o.appName), color.HighlightResource(o.GlobalOpts.ProjectName()))
 
-	if o.envName == "" {
-		delete(o.manifest.Secrets, o.secretName)
-	} else {
-		delete(o.manifest.Environments[o.envName].Secrets, o.secretName)
-	}
+	o.manifest.Delete(manifest.EnvPath(o.envName, manifest.SecretsKey, o.secretName)...)
 
 	if err := o.writeManifest(o.manifest); err != nil {
 		return err
@@ -106,31 +101,35 @@ func (o *SecretDeleteOpts) Execute() error {
 	return nil
 }
 
+// deleteSecret deletes the secret from the store. With force, the stores that keep deleted secrets for a recovery
+// window delete it right away, the other stores delete it as usual.
+func deleteSecret(secretManager archer.SecretsManager, secretName string, force bool) error {
+	if deleter, ok := secretManager.(archer.SecretForceDeleter); ok && force {
+		return deleter.ForceDeleteSecret(secretName)
+	}
+	return secretManager.DeleteSecret(secretName)
+}
+
 func (o *SecretDeleteOpts) readManifest() error {
 	o.manifestPath = o.ws.AppManifestFileName(o.appName)
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return err
 	}
-	mft, err := manifest.UnmarshalApp(raw)
+	mft, err := manifest.NewEditor(raw)
 	if err != nil {
 		return err
 	}
 
-	o.manifest = mft.(*manifest.LBFargateManifest)
+	o.manifest = mft
 	return nil
 }
 
-func (o *SecretDeleteOpts) writeManifest(mft *manifest.LBFargateManifest) error {
-	if len(o.manifest.Environments[o.envName].Secrets) == 0 {
-		envConf := o.manifest.Environments[o.envName]
-		envConf.Secrets = nil
-		o.manifest.Environments[o.envName] = envConf
-	}
-	if reflect.DeepEqual(o.manifest.Environments[o.envName], manifest.LBFargateConfig{}) {
-		delete(o.manifest.Environments, o.envName)
+func (o *SecretDeleteOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
 	}
-	manifestBytes, err := yaml.Marshal(mft)
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -195,19 +194,7 @@ func (o *SecretDeleteOpts) retrieveSecrets() ([]string, error) {
 		return nil, err
 	}
 
-	var keys []string
-	var secrets map[string]string
-
-	if o.envName == "" {
-		secrets = o.manifest.Secrets
-	} else {
-		secrets = o.manifest.Environments[o.envName].Secrets
-	}
-
-	for k := range secrets {
-		keys = append(keys, k)
-	}
-	return keys, nil
+	return o.manifest.Keys(manifest.EnvPath(o.envName, manifest.SecretsKey)...), nil
 }
 
 func (o *SecretDeleteOpts) askSecretName() error {
@@ -271,7 +258,11 @@ func BuildSecretDeleteCmd() *cobra.Command {
 		Aliases: []string{"remove"},
 		Short:   "Delete a secret.",
 		Example: `
-  /code $ dw_run.sh secret delete -n secret-name
+  Deletes the GITHUB_TOKEN secret of the "frontend" application.
+  /code $ dw_run.sh secret delete --app frontend -n GITHUB_TOKEN
+
+  Deletes a secret stored in Secrets Manager without a recovery window.
+  /code $ dw_run.sh secret delete --app frontend -n API_KEY --force
 `,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			ssmStore, err := store.New()
@@ -282,9 +273,13 @@ func BuildSecretDeleteCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("new workspace: %w", err)
 			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
 			opts.ws = ws
 			opts.storeReader = ssmStore
-			opts.secretManager = ssmStore
+			opts.secretBackends = secretBackends
 
 			return nil
 		}),
@@ -302,6 +297,7 @@ func BuildSecretDeleteCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
 	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
 	cmd.Flags().StringVarP(&opts.secretName, "secret-name", "n", "", "Name of the secret.")
+	cmd.Flags().BoolVar(&opts.force, forceFlag, false, forceSecretDeleteFlagDescription)
 	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
 	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
 
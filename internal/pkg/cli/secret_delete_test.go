@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// forceDeletingSecretsManager is a backend that keeps deleted secrets for a recovery window unless they are force deleted.
+type forceDeletingSecretsManager struct {
+	*mocks.MockSecretsManager
+	*mocks.MockSecretForceDeleter
+}
+
+func TestSecretDeleteOpts_Execute(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	written := mockManifestEdit(mockWs, editTestManifest)
+	mockSecretManager := mocks.NewMockSecretsManager(ctrl)
+	mockSecretManager.EXPECT().DeleteSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/github-token").Return(nil)
+	mockBackends := mocks.NewMockSecretBackends(ctrl)
+	mockBackends.EXPECT().SecretBackend("").Return(mockSecretManager, nil)
+
+	opts := SecretDeleteOpts{
+		appName:        "frontend",
+		secretName:     "GITHUB_TOKEN",
+		secretBackends: mockBackends,
+		ws:             mockWs,
+		GlobalOpts:     &GlobalOpts{projectName: "dw-run"},
+	}
+
+	// WHEN
+	err := opts.Execute()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+`, *written)
+}
+
+func TestDeleteSecret(t *testing.T) {
+	testCases := map[string]struct {
+		inForce      bool
+		inForceStore bool
+
+		mockStore func(m *mocks.MockSecretsManager, f *mocks.MockSecretForceDeleter)
+	}{
+		"deletes with the recovery window of the store": {
+			inForceStore: true,
+			mockStore: func(m *mocks.MockSecretsManager, f *mocks.MockSecretForceDeleter) {
+				m.EXPECT().DeleteSecret("api-key").Return(nil)
+			},
+		},
+		"force deletes from a store with a recovery window": {
+			inForce:      true,
+			inForceStore: true,
+			mockStore: func(m *mocks.MockSecretsManager, f *mocks.MockSecretForceDeleter) {
+				f.EXPECT().ForceDeleteSecret("api-key").Return(nil)
+			},
+		},
+		"force deletes from a store without a recovery window": {
+			inForce: true,
+			mockStore: func(m *mocks.MockSecretsManager, f *mocks.MockSecretForceDeleter) {
+				m.EXPECT().DeleteSecret("api-key").Return(nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSecretManager := mocks.NewMockSecretsManager(ctrl)
+			mockForceDeleter := mocks.NewMockSecretForceDeleter(ctrl)
+			tc.mockStore(mockSecretManager, mockForceDeleter)
+			var store archer.SecretsManager = mockSecretManager
+			if tc.inForceStore {
+				store = forceDeletingSecretsManager{mockSecretManager, mockForceDeleter}
+			}
+
+			// WHEN
+			err := deleteSecret(store, "api-key", tc.inForce)
+
+			// THEN
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// SecretImportOpts contains the fields to collect to create the secrets of a dotenv file.
+type SecretImportOpts struct {
+	SecretAddOpts
+
+	file string
+	fs   afero.Fs
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *SecretImportOpts) Validate() error {
+	if o.file == "" {
+		return errMissingDotenvFile
+	}
+	return o.SecretAddOpts.Validate()
+}
+
+// Ask asks for fields that are required but not passed in.
+func (o *SecretImportOpts) Ask() error {
+	if err := o.askProject(); err != nil {
+		return err
+	}
+	return o.askAppName()
+}
+
+// Execute encrypts all the secrets of the dotenv file, then adds them to the manifest in a single edit.
+// If a secret can't be created, the secrets created before it are still saved to the manifest
+// and the error names them.
+func (o *SecretImportOpts) Execute() error {
+	secrets, err := readDotenvFile(o.fs, o.file)
+	if err != nil {
+		return err
+	}
+
+	o.manifestPath = o.ws.AppManifestFileName(o.appName)
+	mft, err := o.readManifest()
+	if err != nil {
+		return err
+	}
+
+	var created []string
+	var createErr error
+	for _, secret := range secrets {
+		stored, err := o.createSecret(secret.Name, secret.Value)
+		if err != nil {
+			createErr = fmt.Errorf("create secret %s: %w", secret.Name, err)
+			break
+		}
+		if err := mft.SetSecret(stored, manifest.EnvPath(o.envName, manifest.SecretsKey, secret.Name)...); err != nil {
+			return fmt.Errorf("add secret %s: %w", secret.Name, err)
+		}
+		created = append(created, secret.Name)
+	}
+	if len(created) == 0 {
+		return createErr
+	}
+	log.Successf("Created/updated the secrets %s in %s under project %s.\n", strings.Join(created, ", "),
+		color.HighlightResource(o.appName), color.HighlightResource(o.ProjectName()))
+
+	if err = o.writeManifest(mft); err != nil {
+		return fmt.Errorf("save the created secrets %s to the manifest: %w", strings.Join(created, ", "), err)
+	}
+	log.Successf("Saved %d secrets from %s to the manifest.\n", len(created), color.HighlightResource(o.file))
+	if createErr != nil {
+		return fmt.Errorf("%w, saved the secrets created before it to the manifest: %s", createErr, strings.Join(created, ", "))
+	}
+	return nil
+}
+
+// BuildSecretImportCmd adds the secrets of a dotenv file.
+func BuildSecretImportCmd() *cobra.Command {
+	opts := SecretImportOpts{
+		SecretAddOpts: SecretAddOpts{
+			GlobalOpts: NewGlobalOpts(),
+		},
+		fs: &afero.Afero{Fs: afero.NewOsFs()},
+	}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Adds the secrets of a dotenv file.",
+		Long: `Encrypts the values of a dotenv file as secrets and adds them to the manifest.
+Secrets that already exist are updated.`,
+		Example: `
+  Adds the secrets of the secrets.env file to the "frontend" application.
+  /code $ dw_run.sh secret import --app frontend --file secrets.env
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			ssmStore, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
+			opts.ws = ws
+			opts.storeReader = ssmStore
+			opts.secretBackends = secretBackends
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Path to the dotenv file.")
+	cmd.Flags().StringVar(&opts.backend, secretBackendFlag, manifest.SecretBackendSSM, secretBackendFlagDescription)
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
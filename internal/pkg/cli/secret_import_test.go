@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretImportOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		setupMocks func(m *mocks.MockSecretsManager)
+
+		wantedErr     error
+		wantedContent string
+	}{
+		"saves all the secrets to the manifest": {
+			setupMocks: func(m *mocks.MockSecretsManager) {
+				gomock.InOrder(
+					m.EXPECT().CreateSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod", "k3y").Return("arn", nil),
+					m.EXPECT().CreateSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token-prod", "t0ken").Return("arn", nil),
+				)
+			},
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+    secrets:
+      API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod
+      STRIPE_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token-prod
+`,
+		},
+		"saves the secrets created before a failure and names them": {
+			setupMocks: func(m *mocks.MockSecretsManager) {
+				gomock.InOrder(
+					m.EXPECT().CreateSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod", "k3y").Return("arn", nil),
+					m.EXPECT().CreateSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token-prod", "t0ken").Return("", errors.New("some error")),
+				)
+			},
+
+			wantedErr: errors.New("create secret STRIPE_TOKEN: some error, saved the secrets created before it to the manifest: API_KEY"),
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+    secrets:
+      API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod
+`,
+		},
+		"leaves the manifest untouched if the first secret fails": {
+			setupMocks: func(m *mocks.MockSecretsManager) {
+				m.EXPECT().CreateSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key-prod", "k3y").Return("", errors.New("some error"))
+			},
+
+			wantedErr: errors.New("create secret API_KEY: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockSecretManager := mocks.NewMockSecretsManager(ctrl)
+			mockSecretBackends := mocks.NewMockSecretBackends(ctrl)
+			mockSecretBackends.EXPECT().SecretBackend(manifest.SecretBackendSSM).Return(mockSecretManager, nil).AnyTimes()
+			tc.setupMocks(mockSecretManager)
+			fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+			require.NoError(t, fs.WriteFile("secrets.env", []byte("API_KEY=k3y\nSTRIPE_TOKEN=t0ken\n"), 0600))
+			written := new(string)
+			if tc.wantedContent != "" {
+				written = mockManifestEdit(mockWs, editTestManifest)
+			} else {
+				mockWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+				mockWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(editTestManifest), nil)
+			}
+
+			opts := SecretImportOpts{
+				SecretAddOpts: SecretAddOpts{
+					appName:        "frontend",
+					envName:        "prod",
+					backend:        manifest.SecretBackendSSM,
+					secretBackends: mockSecretBackends,
+					ws:             mockWs,
+					GlobalOpts:     &GlobalOpts{projectName: "dw-run"},
+				},
+				file: "secrets.env",
+				fs:   fs,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedContent, *written)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// SecretListOpts contains the fields to collect to list the secrets of an application.
+type SecretListOpts struct {
+	VariableListOpts
+
+	secretBackends archer.SecretBackends
+}
+
+// secretListing is a secret of the application in the store, the manifest, or both.
+type secretListing struct {
+	Name         string     `json:"name"` // Name in the manifest, or the base name of the parameter if it's not referenced.
+	Parameter    string     `json:"parameter"`
+	Backend      string     `json:"backend"`
+	Envs         []string   `json:"environments"` // Environments whose containers see the secret.
+	Version      int64      `json:"version,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Exists       bool       `json:"exists"`
+	Referenced   bool       `json:"referenced"`
+}
+
+// secretKey identifies a secret across backends.
+type secretKey struct {
+	backend string
+	name    string
+}
+
+// Execute lists the secrets of the application in the store along with whether the manifest references them,
+// and the secrets the manifest references that don't exist in the store.
+func (o *SecretListOpts) Execute() error {
+	mf, err := o.appManifest()
+	if err != nil {
+		return err
+	}
+	envs, err := o.storeReader.ListEnvironments(o.ProjectName())
+	if err != nil {
+		return fmt.Errorf("list environments of project %s: %w", o.ProjectName(), err)
+	}
+	secretsPath := secretParametersPath(o.ProjectName(), o.appName)
+	listings := make(map[secretKey]*secretListing)
+	for _, backend := range manifest.SecretBackends {
+		secretManager, err := o.secretBackends.SecretBackend(backend)
+		if err != nil {
+			return err
+		}
+		secrets, err := secretManager.ListSecrets(secretsPath)
+		if err != nil {
+			return err
+		}
+		for _, secret := range secrets {
+			lastModified := secret.LastModified
+			listings[secretKey{backend, secret.Name}] = &secretListing{
+				Name:         path.Base(secret.Name),
+				Parameter:    secret.Name,
+				Backend:      backend,
+				Envs:         []string{},
+				Version:      secret.Version,
+				LastModified: &lastModified,
+				Exists:       true,
+			}
+		}
+	}
+	for _, env := range envs {
+		variables, err := effectiveVariables(mf, o.ProjectName(), env.Name)
+		if err != nil {
+			return err
+		}
+		for _, v := range variables {
+			// Secrets outside of the application's path, such as database passwords, aren't listed.
+			if !v.Masked || !strings.HasPrefix(v.ValueFrom, secretsPath+"/") {
+				continue
+			}
+			key := secretKey{v.Backend, v.ValueFrom}
+			listing, ok := listings[key]
+			if !ok {
+				listing = &secretListing{Parameter: v.ValueFrom, Backend: v.Backend}
+				listings[key] = listing
+			}
+			listing.Name = v.Name
+			listing.Envs = append(listing.Envs, env.Name)
+			listing.Referenced = true
+		}
+	}
+
+	var sorted []*secretListing
+	var missing []string
+	for _, listing := range listings {
+		sorted = append(sorted, listing)
+		if !listing.Exists {
+			missing = append(missing, listing.Name)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		if sorted[i].Parameter != sorted[j].Parameter {
+			return sorted[i].Parameter < sorted[j].Parameter
+		}
+		return sorted[i].Backend < sorted[j].Backend
+	})
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		log.Warningf("The manifest references secrets that don't exist: %s.\n", strings.Join(missing, ", "))
+	}
+
+	if o.shouldOutputJSON {
+		return writeJSON(o.w, struct {
+			Secrets []*secretListing `json:"secrets"`
+		}{Secrets: sorted})
+	}
+	writer := tabwriter.NewWriter(o.w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", "Name", "Backend", "Environment", "Version", "Last Modified", "In Manifest")
+	for _, listing := range sorted {
+		envs, version, lastModified, referenced := "-", "-", "-", "no"
+		if len(listing.Envs) > 0 {
+			envs = strings.Join(listing.Envs, ",")
+		}
+		if listing.Exists {
+			version = strconv.FormatInt(listing.Version, 10)
+			lastModified = listing.LastModified.Local().Format(time.RFC3339)
+		}
+		if listing.Referenced {
+			referenced = "yes"
+			if !listing.Exists {
+				referenced = "yes (missing)"
+			}
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", listing.Name, listing.Backend, envs, version, lastModified, referenced)
+	}
+	return writer.Flush()
+}
+
+// BuildSecretListCmd lists the secrets of an application.
+func BuildSecretListCmd() *cobra.Command {
+	opts := SecretListOpts{
+		VariableListOpts: VariableListOpts{
+			GlobalOpts: NewGlobalOpts(),
+			w:          os.Stdout,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists the secrets of an application.",
+		Long: `Lists the secrets of an application in every backend, when they last changed, and whether the manifest references them.
+Secrets referenced by the manifest that don't exist are listed as missing.`,
+		Example: `
+  /code $ dw_run.sh secret list --app frontend
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			ssmStore, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
+			opts.ws = ws
+			opts.storeReader = ssmStore
+			opts.secretBackends = secretBackends
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().BoolVar(&opts.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
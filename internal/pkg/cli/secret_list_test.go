@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+const secretTestManifest = `name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+secrets:
+  API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key
+  STRIPE_TOKEN:
+    from: /ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token
+    backend: secretsmanager
+  DB_PASSWORD:
+    from: dw-run-frontend-database
+    backend: secretsmanager
+database:
+  engine: postgresql
+environments:
+  prod:
+    secrets:
+      SENTRY_DSN: /ecs-cli-v2/dw-run/applications/frontend/secrets/sentry-dsn-prod
+`
+
+func TestSecretListOpts_Execute(t *testing.T) {
+	lastModified := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		inJSON bool
+
+		wantedRows    [][]string
+		wantedContent string
+	}{
+		"merges the secrets of the store and the manifest across backends": {
+			wantedRows: [][]string{
+				{"Name", "Backend", "Environment", "Version", "Last", "Modified", "In", "Manifest"},
+				{"API_KEY", "ssm", "test,prod", "3", lastModified.Local().Format(time.RFC3339), "yes"},
+				{"SENTRY_DSN", "ssm", "prod", "-", "-", "yes", "(missing)"},
+				{"STRIPE_TOKEN", "secretsmanager", "test,prod", "0", lastModified.Local().Format(time.RFC3339), "yes"},
+				{"old-token", "ssm", "-", "1", lastModified.Local().Format(time.RFC3339), "no"},
+			},
+		},
+		"in JSON": {
+			inJSON: true,
+
+			wantedContent: `{"secrets":[` +
+				`{"name":"API_KEY","parameter":"/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key","backend":"ssm","environments":["test","prod"],"version":3,"lastModified":"2020-03-01T10:00:00Z","exists":true,"referenced":true},` +
+				`{"name":"SENTRY_DSN","parameter":"/ecs-cli-v2/dw-run/applications/frontend/secrets/sentry-dsn-prod","backend":"ssm","environments":["prod"],"exists":false,"referenced":true},` +
+				`{"name":"STRIPE_TOKEN","parameter":"/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token","backend":"secretsmanager","environments":["test","prod"],"lastModified":"2020-03-01T10:00:00Z","exists":true,"referenced":true},` +
+				`{"name":"old-token","parameter":"/ecs-cli-v2/dw-run/applications/frontend/secrets/old-token","backend":"ssm","environments":[],"version":1,"lastModified":"2020-03-01T10:00:00Z","exists":true,"referenced":false}` +
+				"]}\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+			mockWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(secretTestManifest), nil)
+			mockStore := climocks.NewMockstoreReader(ctrl)
+			mockStore.EXPECT().ListEnvironments("dw-run").Return([]*archer.Environment{
+				{Name: "test"},
+				{Name: "prod"},
+			}, nil)
+			mockSSM := mocks.NewMockSecretsManager(ctrl)
+			mockSSM.EXPECT().ListSecrets("/ecs-cli-v2/dw-run/applications/frontend/secrets").Return([]*archer.Secret{
+				{Name: "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key", Version: 3, LastModified: lastModified},
+				{Name: "/ecs-cli-v2/dw-run/applications/frontend/secrets/old-token", Version: 1, LastModified: lastModified},
+			}, nil)
+			mockSecretsManager := mocks.NewMockSecretsManager(ctrl)
+			mockSecretsManager.EXPECT().ListSecrets("/ecs-cli-v2/dw-run/applications/frontend/secrets").Return([]*archer.Secret{
+				{Name: "/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token", LastModified: lastModified},
+			}, nil)
+			mockSecretBackends := mocks.NewMockSecretBackends(ctrl)
+			mockSecretBackends.EXPECT().SecretBackend(manifest.SecretBackendSSM).Return(mockSSM, nil)
+			mockSecretBackends.EXPECT().SecretBackend(manifest.SecretBackendSecretsManager).Return(mockSecretsManager, nil)
+			b := &bytes.Buffer{}
+			diagnostics := &bytes.Buffer{}
+			defer func(w io.Writer) { log.DiagnosticWriter = w }(log.DiagnosticWriter)
+			log.DiagnosticWriter = diagnostics
+
+			opts := SecretListOpts{
+				VariableListOpts: VariableListOpts{
+					appName:          "frontend",
+					shouldOutputJSON: tc.inJSON,
+					storeReader:      mockStore,
+					ws:               mockWs,
+					w:                b,
+					GlobalOpts:       &GlobalOpts{projectName: "dw-run"},
+				},
+				secretBackends: mockSecretBackends,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			require.NoError(t, err)
+			require.Contains(t, diagnostics.String(), "The manifest references secrets that don't exist: SENTRY_DSN.")
+			if tc.inJSON {
+				require.Equal(t, tc.wantedContent, b.String())
+				return
+			}
+			var rows [][]string
+			for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+				rows = append(rows, strings.Fields(line))
+			}
+			require.Equal(t, tc.wantedRows, rows)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package cli
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultSecretLength = 32
+	minSecretLength     = 16
+
+	// Letters and digits only, so that the value can be used as is in URLs and shell commands.
+	secretAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+var errRotateDatabasePassword = errors.New("the database password can only be rotated with --lambda, " +
+	"so that the master password of the database changes along with the secret; " +
+	"set database.rotationDays in the manifest and deploy the application to create the rotation function")
+
+// SecretRotateOpts contains the fields to collect to rotate a secret of an application.
+type SecretRotateOpts struct {
+	SecretShowOpts
+
+	length     int
+	withLambda bool
+
+	// Clients of the environment whose applications are restarted.
+	stackResources stackResourceGetter
+	restarter      serviceRestarter
+	spinner        progress
+
+	// initEnvClients is overridden in tests.
+	initEnvClients func(*SecretRotateOpts, *archer.Environment) error
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *SecretRotateOpts) Validate() error {
+	if o.length < minSecretLength {
+		return fmt.Errorf("length %d must be at least %d", o.length, minSecretLength)
+	}
+	return o.SecretShowOpts.Validate()
+}
+
+// Execute stores a new value of the secret, then restarts the applications that reference the secret
+// so that their tasks read the new value.
+func (o *SecretRotateOpts) Execute() error {
+	mf, err := o.appManifest()
+	if err != nil {
+		return err
+	}
+	secret, err := o.manifestSecret(mf)
+	if err != nil {
+		return err
+	}
+	secretManager, err := o.secretBackends.SecretBackend(secret.Backend)
+	if err != nil {
+		return err
+	}
+
+	if o.withLambda {
+		rotator, ok := secretManager.(archer.SecretRotator)
+		if !ok {
+			return fmt.Errorf("secrets stored in %s can't be rotated with a function, remove --lambda", secret.Backend)
+		}
+		o.spinner.Start(fmt.Sprintf("Rotating secret %s with its rotation function.", color.HighlightUserInput(o.secretName)))
+		if err := rotator.RotateSecret(secret.From); err != nil {
+			o.spinner.Stop("Error!")
+			return err
+		}
+		o.spinner.Stop("")
+	} else {
+		if isDatabasePassword(mf, o.secretName) {
+			return errRotateDatabasePassword
+		}
+		value, err := generateSecretValue(o.length)
+		if err != nil {
+			return err
+		}
+		if err := secretManager.UpdateSecret(secret.From, value); err != nil {
+			return err
+		}
+	}
+	log.Successf("Rotated secret %s of application %s.\n", color.HighlightUserInput(o.secretName), color.HighlightResource(o.appName))
+
+	return o.restartApps(secret)
+}
+
+// manifestSecret returns the secret the application's manifest references in the environment.
+func (o *SecretRotateOpts) manifestSecret(mf archer.Manifest) (manifest.Secret, error) {
+	variables, err := effectiveVariables(mf, o.ProjectName(), o.envName)
+	if err != nil {
+		return manifest.Secret{}, err
+	}
+	for _, v := range variables {
+		if v.Masked && v.Name == o.secretName {
+			return manifest.Secret{From: v.ValueFrom, Backend: v.Backend}, nil
+		}
+	}
+	return manifest.Secret{}, fmt.Errorf("secret %s not found in the manifest of application %s", o.secretName, o.appName)
+}
+
+// restartApps restarts the services of the applications in the workspace that reference the secret,
+// in every environment where they reference it.
+func (o *SecretRotateOpts) restartApps(secret manifest.Secret) error {
+	envs, err := o.rotatedEnvironments()
+	if err != nil {
+		return err
+	}
+	apps, err := o.ws.Apps()
+	if err != nil {
+		return fmt.Errorf("get applications in the workspace: %w", err)
+	}
+	for _, env := range envs {
+		var restarted []archer.Manifest
+		for _, app := range apps {
+			if _, ok := app.(deploymentConfigurer); !ok {
+				// Jobs read the new value the next time they run.
+				continue
+			}
+			references, err := referencesSecret(app, o.ProjectName(), env.Name, secret)
+			if err != nil {
+				return err
+			}
+			if references {
+				restarted = append(restarted, app)
+			}
+		}
+		if len(restarted) == 0 {
+			continue
+		}
+		if err := o.restartAppsInEnv(env, restarted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotatedEnvironments returns the environments where applications can see the new value of the secret.
+func (o *SecretRotateOpts) rotatedEnvironments() ([]*archer.Environment, error) {
+	if o.envName != "" {
+		env, err := o.storeReader.GetEnvironment(o.ProjectName(), o.envName)
+		if err != nil {
+			return nil, err
+		}
+		return []*archer.Environment{env}, nil
+	}
+	envs, err := o.storeReader.ListEnvironments(o.ProjectName())
+	if err != nil {
+		return nil, fmt.Errorf("list environments of project %s: %w", o.ProjectName(), err)
+	}
+	return envs, nil
+}
+
+// restartAppsInEnv forces a new deployment of the apps' services, one after the other, and waits for each of them
+// to reach a steady state so that a broken secret doesn't take down all of the applications.
+func (o *SecretRotateOpts) restartAppsInEnv(env *archer.Environment, apps []archer.Manifest) error {
+	if err := o.initEnvClients(o, env); err != nil {
+		return err
+	}
+	cluster, err := o.stackResources.StackResourceID(stack.NameForEnv(o.ProjectName(), env.Name), envClusterLogicalID)
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		service, err := o.stackResources.StackResourceID(stack.NameForApp(o.ProjectName(), env.Name, app.AppName()), appServiceLogicalID)
+		if err != nil {
+			var notFound *cloudformation.ErrStackNotFound
+			if errors.As(err, &notFound) {
+				log.Infof("Application %s isn't deployed in %s, nothing to restart.\n",
+					color.HighlightResource(app.AppName()), color.HighlightResource(env.Name))
+				continue
+			}
+			return err
+		}
+		timeout := defaultSteadyStateTimeout
+		if seconds := app.(deploymentConfigurer).DeploymentConfig(env.Name).Timeout; seconds != 0 {
+			timeout = time.Duration(seconds) * time.Second
+		}
+
+		o.spinner.Start(fmt.Sprintf("Restarting the tasks of %s in %s.",
+			color.HighlightUserInput(app.AppName()), color.HighlightUserInput(env.Name)))
+		if err := o.restarter.RestartService(cluster, service); err != nil {
+			o.spinner.Stop("Error!")
+			return err
+		}
+		if err := o.restarter.WaitForSteadyState(cluster, service, timeout); err != nil {
+			o.spinner.Stop("Error!")
+			return fmt.Errorf("wait for the tasks of %s to reach a steady state: %w", app.AppName(), err)
+		}
+		o.spinner.Stop("")
+		log.Successf("Restarted %s in %s.\n", color.HighlightResource(app.AppName()), color.HighlightResource(env.Name))
+	}
+	return nil
+}
+
+// referencesSecret returns whether the containers of the app read the secret in the environment.
+func referencesSecret(app archer.Manifest, projectName, envName string, secret manifest.Secret) (bool, error) {
+	variables, err := effectiveVariables(app, projectName, envName)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range variables {
+		if v.Masked && v.ValueFrom == secret.From && v.Backend == secret.Backend {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isDatabasePassword returns whether the secret holds the master password of the application's database.
+func isDatabasePassword(mf archer.Manifest, secretName string) bool {
+	lb, ok := mf.(*manifest.LBFargateManifest)
+	return ok && lb.Database != nil && secretName == manifest.DatabasePasswordSecret
+}
+
+// generateSecretValue returns a random value of letters and digits.
+func generateSecretValue(length int) (string, error) {
+	value := make([]byte, length)
+	max := big.NewInt(int64(len(secretAlphabet)))
+	for i := range value {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("generate secret value: %w", err)
+		}
+		value[i] = secretAlphabet[n.Int64()]
+	}
+	return string(value), nil
+}
+
+// BuildSecretRotateCmd rotates a secret of an application.
+func BuildSecretRotateCmd() *cobra.Command {
+	opts := SecretRotateOpts{
+		SecretShowOpts: SecretShowOpts{
+			VariableListOpts: VariableListOpts{
+				GlobalOpts: NewGlobalOpts(),
+				w:          os.Stdout,
+			},
+		},
+		spinner: termprogress.NewSpinner(),
+		initEnvClients: func(o *SecretRotateOpts, env *archer.Environment) error {
+			sess, err := session.NewProvider().FromRole(env.ManagerRoleARN, env.Region)
+			if err != nil {
+				return fmt.Errorf("assuming environment manager role: %w", err)
+			}
+			o.stackResources = cloudformation.New(sess)
+			o.restarter = ecs.New(sess)
+			return nil
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotates a secret of an application.",
+		Long: `Stores a new random value of a secret, then restarts the applications that reference the secret
+so that their tasks read the new value.
+With --lambda, the secret is rotated by the rotation function of its Secrets Manager secret instead,
+such as the one of the database password when database.rotationDays is set in the manifest.`,
+		Example: `
+  Rotates the API_KEY secret of the "frontend" application in the "prod" environment.
+  /code $ dw_run.sh secret rotate --app frontend --env prod --secret-name API_KEY
+
+  Rotates the master password of the database of the "frontend" application.
+  /code $ dw_run.sh secret rotate --app frontend --secret-name DB_PASSWORD --lambda
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			ssmStore, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
+			opts.ws = ws
+			opts.storeReader = ssmStore
+			opts.secretBackends = secretBackends
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&opts.secretName, "secret-name", "n", "", "Name of the secret, e.g. MY_SECRET.")
+	cmd.Flags().IntVar(&opts.length, "length", defaultSecretLength, "Optional. Number of characters of the new value.")
+	cmd.Flags().BoolVar(&opts.withLambda, "lambda", false, "Optional. Rotate the secret with the rotation function of its Secrets Manager secret.")
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingSecretsManager is a backend whose secrets can be rotated by a function.
+type rotatingSecretsManager struct {
+	*mocks.MockSecretsManager
+	*mocks.MockSecretRotator
+}
+
+func TestSecretRotateOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inSecretName string
+		inLambda     bool
+
+		setupMocks func(ctrl *gomock.Controller, mockBackends *mocks.MockSecretBackends)
+
+		wantedErr error
+	}{
+		"stores a new value": {
+			inSecretName: "API_KEY",
+			setupMocks: func(ctrl *gomock.Controller, mockBackends *mocks.MockSecretBackends) {
+				m := mocks.NewMockSecretsManager(ctrl)
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSSM).Return(m, nil)
+				m.EXPECT().UpdateSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key", gomock.Any()).Return(nil)
+			},
+		},
+		"rotates the secret with its function": {
+			inSecretName: "DB_PASSWORD",
+			inLambda:     true,
+			setupMocks: func(ctrl *gomock.Controller, mockBackends *mocks.MockSecretBackends) {
+				m := rotatingSecretsManager{mocks.NewMockSecretsManager(ctrl), mocks.NewMockSecretRotator(ctrl)}
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSecretsManager).Return(m, nil)
+				m.MockSecretRotator.EXPECT().RotateSecret("dw-run-frontend-database").Return(nil)
+				m.MockSecretsManager.EXPECT().UpdateSecret(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		"refuses to rotate with a function in a backend without any": {
+			inSecretName: "API_KEY",
+			inLambda:     true,
+			setupMocks: func(ctrl *gomock.Controller, mockBackends *mocks.MockSecretBackends) {
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSSM).Return(mocks.NewMockSecretsManager(ctrl), nil)
+			},
+
+			wantedErr: errors.New("secrets stored in ssm can't be rotated with a function, remove --lambda"),
+		},
+		"refuses to change the database password without its function": {
+			inSecretName: "DB_PASSWORD",
+			setupMocks: func(ctrl *gomock.Controller, mockBackends *mocks.MockSecretBackends) {
+				m := mocks.NewMockSecretsManager(ctrl)
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSecretsManager).Return(m, nil)
+				m.EXPECT().UpdateSecret(gomock.Any(), gomock.Any()).Times(0)
+			},
+
+			wantedErr: errRotateDatabasePassword,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+			mockWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(secretTestManifest), nil)
+			mockBackends := mocks.NewMockSecretBackends(ctrl)
+			tc.setupMocks(ctrl, mockBackends)
+			mockStore := climocks.NewMockstoreReader(ctrl)
+			mockSpinner := climocks.NewMockprogress(ctrl)
+			if tc.wantedErr == nil {
+				// Nothing references the secret in the workspace, so no application is restarted.
+				mockStore.EXPECT().GetEnvironment("dw-run", "test").Return(&archer.Environment{Name: "test"}, nil)
+				mockWs.EXPECT().Apps().Return(nil, nil)
+				mockSpinner.EXPECT().Start(gomock.Any()).AnyTimes()
+				mockSpinner.EXPECT().Stop(gomock.Any()).AnyTimes()
+			}
+
+			opts := SecretRotateOpts{
+				SecretShowOpts: SecretShowOpts{
+					VariableListOpts: VariableListOpts{
+						appName:     "frontend",
+						envName:     "test",
+						storeReader: mockStore,
+						ws:          mockWs,
+						GlobalOpts:  &GlobalOpts{projectName: "dw-run"},
+					},
+					secretName:     tc.inSecretName,
+					secretBackends: mockBackends,
+				},
+				length:     defaultSecretLength,
+				withLambda: tc.inLambda,
+				spinner:    mockSpinner,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSecretRotateOpts_restartApps(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	apps := make([]archer.Manifest, 0, 4)
+	for _, content := range []string{
+		// References the secret in every environment.
+		`name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+secrets:
+  API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key
+`,
+		// Only references the secret in prod.
+		`name: api
+type: Backend App
+image:
+  build: api/Dockerfile
+  port: 8080
+environments:
+  prod:
+    secrets:
+      API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key
+`,
+		// References a secret with the same name in another parameter.
+		`name: worker
+type: Worker Service
+image:
+  build: worker/Dockerfile
+secrets:
+  API_KEY: /ecs-cli-v2/dw-run/applications/worker/secrets/api-key
+`,
+		// Jobs read the new value the next time they run.
+		`name: reports
+type: Scheduled Job
+image:
+  build: reports/Dockerfile
+schedule: rate(1 day)
+secrets:
+  API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key
+`,
+	} {
+		app, err := manifest.UnmarshalApp([]byte(content))
+		require.NoError(t, err)
+		apps = append(apps, app)
+	}
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	mockWs.EXPECT().Apps().Return(apps, nil)
+	mockStore := climocks.NewMockstoreReader(ctrl)
+	mockStore.EXPECT().ListEnvironments("dw-run").Return([]*archer.Environment{
+		{Name: "test"},
+		{Name: "staging"},
+		{Name: "prod"},
+	}, nil)
+	mockStackResources := climocks.NewMockstackResourceGetter(ctrl)
+	mockRestarter := climocks.NewMockserviceRestarter(ctrl)
+	mockSpinner := climocks.NewMockprogress(ctrl)
+	mockSpinner.EXPECT().Start(gomock.Any()).AnyTimes()
+	mockSpinner.EXPECT().Stop(gomock.Any()).AnyTimes()
+	for env, appNames := range map[string][]string{
+		"test":    {"frontend"},
+		"staging": {"frontend"},
+		"prod":    {"frontend", "api"},
+	} {
+		cluster := env + "-cluster"
+		mockStackResources.EXPECT().StackResourceID(stack.NameForEnv("dw-run", env), envClusterLogicalID).Return(cluster, nil)
+		for _, appName := range appNames {
+			service := env + "-" + appName
+			mockStackResources.EXPECT().StackResourceID(stack.NameForApp("dw-run", env, appName), appServiceLogicalID).Return(service, nil)
+			mockRestarter.EXPECT().RestartService(cluster, service).Return(nil)
+			mockRestarter.EXPECT().WaitForSteadyState(cluster, service, defaultSteadyStateTimeout).Return(nil)
+		}
+	}
+	var initialized []string
+
+	opts := SecretRotateOpts{
+		SecretShowOpts: SecretShowOpts{
+			VariableListOpts: VariableListOpts{
+				appName:     "frontend",
+				storeReader: mockStore,
+				ws:          mockWs,
+				GlobalOpts:  &GlobalOpts{projectName: "dw-run"},
+			},
+			secretName: "API_KEY",
+		},
+		spinner: mockSpinner,
+		initEnvClients: func(o *SecretRotateOpts, env *archer.Environment) error {
+			initialized = append(initialized, env.Name)
+			o.stackResources = mockStackResources
+			o.restarter = mockRestarter
+			return nil
+		},
+	}
+
+	// WHEN
+	err := opts.restartApps(manifest.Secret{From: "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key", Backend: manifest.SecretBackendSSM})
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, []string{"test", "staging", "prod"}, initialized)
+}
+
+func TestSecretRotateOpts_restartAppsSkipsEnvironmentsWithoutReferences(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	app, err := manifest.UnmarshalApp([]byte(`name: api
+type: Backend App
+image:
+  build: api/Dockerfile
+  port: 8080
+environments:
+  prod:
+    secrets:
+      API_KEY: /ecs-cli-v2/dw-run/applications/frontend/secrets/api-key
+`))
+	require.NoError(t, err)
+	mockWs := mocks.NewMockWorkspace(ctrl)
+	mockWs.EXPECT().Apps().Return([]archer.Manifest{app}, nil)
+	mockStore := climocks.NewMockstoreReader(ctrl)
+	mockStore.EXPECT().ListEnvironments("dw-run").Return([]*archer.Environment{
+		{Name: "test"},
+		{Name: "prod"},
+	}, nil)
+	var initialized []string
+
+	opts := SecretRotateOpts{
+		SecretShowOpts: SecretShowOpts{
+			VariableListOpts: VariableListOpts{
+				appName:     "api",
+				storeReader: mockStore,
+				ws:          mockWs,
+				GlobalOpts:  &GlobalOpts{projectName: "dw-run"},
+			},
+			secretName: "API_KEY",
+		},
+		initEnvClients: func(o *SecretRotateOpts, env *archer.Environment) error {
+			initialized = append(initialized, env.Name)
+			return errors.New("some error")
+		},
+	}
+
+	// WHEN
+	err = opts.restartApps(manifest.Secret{From: "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key", Backend: manifest.SecretBackendSSM})
+
+	// THEN
+	require.EqualError(t, err, "some error")
+	require.Equal(t, []string{"prod"}, initialized, "only the environments referencing the secret get clients")
+}
+
+func TestGenerateSecretValue(t *testing.T) {
+	for _, length := range []int{minSecretLength, defaultSecretLength, 100} {
+		// WHEN
+		value, err := generateSecretValue(length)
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, value, length)
+		require.Empty(t, strings.Trim(value, secretAlphabet), "only letters and digits are used")
+	}
+}
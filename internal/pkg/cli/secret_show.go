@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretbackend"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const fmtRevealSecretPrompt = "Are you sure you want to print the value of secret %s of application %s?"
+
+var errRevealCancelled = errors.New("reveal cancelled - the value is not printed")
+
+// SecretShowOpts contains the fields to collect to show a secret of an application.
+type SecretShowOpts struct {
+	VariableListOpts
+
+	secretName string
+	reveal     bool
+
+	secretBackends archer.SecretBackends
+}
+
+// Ask asks for fields that are required but not passed in.
+func (o *SecretShowOpts) Ask() error {
+	if err := o.VariableListOpts.Ask(); err != nil {
+		return err
+	}
+	return o.askSecretName()
+}
+
+// Execute writes the metadata of the secret the manifest references, and its value if revealed.
+func (o *SecretShowOpts) Execute() error {
+	mf, err := o.appManifest()
+	if err != nil {
+		return err
+	}
+	variables, err := effectiveVariables(mf, o.ProjectName(), o.envName)
+	if err != nil {
+		return err
+	}
+	var parameter, backend string
+	for _, v := range variables {
+		if v.Masked && v.Name == o.secretName {
+			parameter, backend = v.ValueFrom, v.Backend
+		}
+	}
+	if parameter == "" {
+		return fmt.Errorf("secret %s not found in the manifest of application %s", o.secretName, o.appName)
+	}
+
+	secretManager, err := o.secretBackends.SecretBackend(backend)
+	if err != nil {
+		return err
+	}
+	secret, err := secretManager.GetSecret(parameter)
+	if err != nil {
+		return err
+	}
+	var value string
+	if o.reveal {
+		if err := o.confirmReveal(); err != nil {
+			return err
+		}
+		if value, err = secretManager.GetSecretValue(parameter); err != nil {
+			return err
+		}
+	}
+
+	writer := tabwriter.NewWriter(o.w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "Name\t%s\n", o.secretName)
+	fmt.Fprintf(writer, "Backend\t%s\n", backend)
+	fmt.Fprintf(writer, "Parameter\t%s\n", secret.Name)
+	fmt.Fprintf(writer, "Version\t%d\n", secret.Version)
+	fmt.Fprintf(writer, "Last Modified\t%s\n", secret.LastModified.Local().Format(time.RFC3339))
+	if o.reveal {
+		fmt.Fprintf(writer, "Value\t%s\n", value)
+	}
+	return writer.Flush()
+}
+
+func (o *SecretShowOpts) confirmReveal() error {
+	reveal, err := o.prompt.Confirm(fmt.Sprintf(fmtRevealSecretPrompt, o.secretName, o.appName), "")
+	if err != nil {
+		return fmt.Errorf("prompt for reveal: %w", err)
+	}
+	if !reveal {
+		return errRevealCancelled
+	}
+	return nil
+}
+
+func (o *SecretShowOpts) askSecretName() error {
+	if o.secretName != "" {
+		return nil
+	}
+
+	name, err := o.prompt.Get(
+		"Secret name (e.g. MY_SECRET):",
+		"The name of the secret in the manifest.",
+		validateEnvVarName)
+
+	if err != nil {
+		return fmt.Errorf("failed to get secret name: %w", err)
+	}
+
+	o.secretName = name
+	return nil
+}
+
+// BuildSecretShowCmd shows a secret of an application.
+func BuildSecretShowCmd() *cobra.Command {
+	opts := SecretShowOpts{
+		VariableListOpts: VariableListOpts{
+			GlobalOpts: NewGlobalOpts(),
+			w:          os.Stdout,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Shows a secret of an application.",
+		Long: `Shows the version of a secret and when it last changed.
+With --reveal, the decrypted value is printed after confirmation.`,
+		Example: `
+  Shows the DB_TOKEN secret of the "frontend" application in the "prod" environment.
+  /code $ dw_run.sh secret show --app frontend --env prod --secret-name DB_TOKEN --reveal
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			ssmStore, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			secretBackends, err := secretbackend.New()
+			if err != nil {
+				return err
+			}
+			opts.ws = ws
+			opts.storeReader = ssmStore
+			opts.secretBackends = secretBackends
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&opts.secretName, "secret-name", "n", "", "Name of the secret, e.g. MY_SECRET.")
+	cmd.Flags().BoolVar(&opts.reveal, "reveal", false, "Print the decrypted value of the secret.")
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
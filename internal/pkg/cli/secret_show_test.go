@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretShowOpts_Execute(t *testing.T) {
+	lastModified := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		inEnvName    string
+		inSecretName string
+		inReveal     bool
+
+		setupMocks func(mockBackends *mocks.MockSecretBackends, mockSecretManager *mocks.MockSecretsManager, mockPrompt *climocks.Mockprompter)
+
+		wantedErr  error
+		wantedRows [][]string
+	}{
+		"shows the metadata without the value": {
+			inEnvName:    "test",
+			inSecretName: "API_KEY",
+			setupMocks: func(mockBackends *mocks.MockSecretBackends, mockSecretManager *mocks.MockSecretsManager, mockPrompt *climocks.Mockprompter) {
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSSM).Return(mockSecretManager, nil)
+				mockSecretManager.EXPECT().GetSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key").Return(&archer.Secret{
+					Name: "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key", Version: 3, LastModified: lastModified,
+				}, nil)
+			},
+
+			wantedRows: [][]string{
+				{"Name", "API_KEY"},
+				{"Backend", "ssm"},
+				{"Parameter", "/ecs-cli-v2/dw-run/applications/frontend/secrets/api-key"},
+				{"Version", "3"},
+				{"Last", "Modified", lastModified.Local().Format(time.RFC3339)},
+			},
+		},
+		"shows the value once the reveal is confirmed": {
+			inEnvName:    "test",
+			inSecretName: "STRIPE_TOKEN",
+			inReveal:     true,
+			setupMocks: func(mockBackends *mocks.MockSecretBackends, mockSecretManager *mocks.MockSecretsManager, mockPrompt *climocks.Mockprompter) {
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSecretsManager).Return(mockSecretManager, nil)
+				mockSecretManager.EXPECT().GetSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token").Return(&archer.Secret{
+					Name: "/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token", LastModified: lastModified,
+				}, nil)
+				mockPrompt.EXPECT().Confirm(fmt.Sprintf(fmtRevealSecretPrompt, "STRIPE_TOKEN", "frontend"), "").Return(true, nil)
+				mockSecretManager.EXPECT().GetSecretValue("/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token").Return("sk_live_123", nil)
+			},
+
+			wantedRows: [][]string{
+				{"Name", "STRIPE_TOKEN"},
+				{"Backend", "secretsmanager"},
+				{"Parameter", "/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token"},
+				{"Version", "0"},
+				{"Last", "Modified", lastModified.Local().Format(time.RFC3339)},
+				{"Value", "sk_live_123"},
+			},
+		},
+		"doesn't read the value if the reveal is declined": {
+			inEnvName:    "test",
+			inSecretName: "STRIPE_TOKEN",
+			inReveal:     true,
+			setupMocks: func(mockBackends *mocks.MockSecretBackends, mockSecretManager *mocks.MockSecretsManager, mockPrompt *climocks.Mockprompter) {
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSecretsManager).Return(mockSecretManager, nil)
+				mockSecretManager.EXPECT().GetSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token").Return(&archer.Secret{
+					Name: "/ecs-cli-v2/dw-run/applications/frontend/secrets/stripe-token", LastModified: lastModified,
+				}, nil)
+				mockPrompt.EXPECT().Confirm(fmt.Sprintf(fmtRevealSecretPrompt, "STRIPE_TOKEN", "frontend"), "").Return(false, nil)
+				mockSecretManager.EXPECT().GetSecretValue(gomock.Any()).Times(0)
+			},
+
+			wantedErr: errRevealCancelled,
+		},
+		"reads the secret of the environment": {
+			inEnvName:    "prod",
+			inSecretName: "SENTRY_DSN",
+			setupMocks: func(mockBackends *mocks.MockSecretBackends, mockSecretManager *mocks.MockSecretsManager, mockPrompt *climocks.Mockprompter) {
+				mockBackends.EXPECT().SecretBackend(manifest.SecretBackendSSM).Return(mockSecretManager, nil)
+				mockSecretManager.EXPECT().GetSecret("/ecs-cli-v2/dw-run/applications/frontend/secrets/sentry-dsn-prod").Return(nil, errors.New("some error"))
+			},
+
+			wantedErr: errors.New("some error"),
+		},
+		"secret not in the manifest of the environment": {
+			inEnvName:    "test",
+			inSecretName: "SENTRY_DSN",
+			setupMocks: func(mockBackends *mocks.MockSecretBackends, mockSecretManager *mocks.MockSecretsManager, mockPrompt *climocks.Mockprompter) {
+			},
+
+			wantedErr: errors.New("secret SENTRY_DSN not found in the manifest of application frontend"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+			mockWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(secretTestManifest), nil)
+			mockSecretBackends := mocks.NewMockSecretBackends(ctrl)
+			mockSecretManager := mocks.NewMockSecretsManager(ctrl)
+			mockPrompt := climocks.NewMockprompter(ctrl)
+			tc.setupMocks(mockSecretBackends, mockSecretManager, mockPrompt)
+			b := &bytes.Buffer{}
+
+			opts := SecretShowOpts{
+				VariableListOpts: VariableListOpts{
+					appName:    "frontend",
+					envName:    tc.inEnvName,
+					ws:         mockWs,
+					w:          b,
+					GlobalOpts: &GlobalOpts{projectName: "dw-run", prompt: mockPrompt},
+				},
+				secretName:     tc.inSecretName,
+				reveal:         tc.inReveal,
+				secretBackends: mockSecretBackends,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				require.Empty(t, b.String())
+				return
+			}
+			require.NoError(t, err)
+			var rows [][]string
+			for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+				rows = append(rows, strings.Fields(line))
+			}
+			require.Equal(t, tc.wantedRows, rows)
+		})
+	}
+}
@@ -19,6 +19,9 @@ func BuildVariableCmd() *cobra.Command {
 
 	cmd.AddCommand(BuildVariableAddCmd())
 	cmd.AddCommand(BuildVariableDeleteCmd())
+	cmd.AddCommand(BuildVariableListCmd())
+	cmd.AddCommand(BuildVariableImportCmd())
+	cmd.AddCommand(BuildVariableExportCmd())
 
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
@@ -11,7 +11,6 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // VariableAddOpts contains the fields to collect to create an environment variable.
@@ -77,29 +76,11 @@ func (o *VariableAddOpts) Execute() error {
 	}
 
 	// add the env var to the manifest
-	lbmft := mft.(*manifest.LBFargateManifest)
-
-	if o.envName == "" {
-		if lbmft.Variables == nil {
-			lbmft.Variables = make(map[string]string)
-		}
-		lbmft.Variables[o.name] = o.value
-	} else {
-		if lbmft.Environments == nil {
-			lbmft.Environments = make(map[string]manifest.LBFargateConfig)
-		}
-		if _, ok := lbmft.Environments[o.envName]; !ok {
-			lbmft.Environments[o.envName] = manifest.LBFargateConfig{}
-		}
-		if lbmft.Environments[o.envName].Variables == nil {
-			envConf := lbmft.Environments[o.envName]
-			envConf.Variables = make(map[string]string)
-			lbmft.Environments[o.envName] = envConf
-		}
-		lbmft.Environments[o.envName].Variables[o.name] = o.value
+	if err := mft.Set(o.value, manifest.EnvPath(o.envName, manifest.VariablesKey, o.name)...); err != nil {
+		return fmt.Errorf("add environment variable %s: %w", o.name, err)
 	}
 
-	if err = o.writeManifest(lbmft); err != nil {
+	if err = o.writeManifest(mft); err != nil {
 		return err
 	}
 
@@ -108,16 +89,19 @@ func (o *VariableAddOpts) Execute() error {
 	return nil
 }
 
-func (o *VariableAddOpts) readManifest() (archer.Manifest, error) {
+func (o *VariableAddOpts) readManifest() (*manifest.Editor, error) {
 	raw, err := o.ws.ReadFile(o.manifestPath)
 	if err != nil {
 		return nil, err
 	}
-	return manifest.UnmarshalApp(raw)
+	return manifest.NewEditor(raw)
 }
 
-func (o *VariableAddOpts) writeManifest(mft *manifest.LBFargateManifest) error {
-	manifestBytes, err := yaml.Marshal(mft)
+func (o *VariableAddOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
+	}
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// editTestManifest is a manifest with comments, blank lines and keys in a non-alphabetical order,
+// that the commands editing manifests must leave untouched except for the keys they edit.
+const editTestManifest = `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+`
+
+// mockManifestEdit expects the manifest of the "frontend" application to be read and written back once,
+// and returns the content that was written.
+func mockManifestEdit(m *mocks.MockWorkspace, content string) *string {
+	written := new(string)
+	m.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml").AnyTimes()
+	m.EXPECT().ReadFile("frontend-app.yml").Return([]byte(content), nil).AnyTimes()
+	m.EXPECT().WriteFile(gomock.Any(), "frontend-app.yml").DoAndReturn(func(blob []byte, filename string) (string, error) {
+		*written = string(blob)
+		return filename, nil
+	})
+	return written
+}
+
+func TestVariableAddOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inEnvName string
+		inName    string
+		inValue   string
+
+		wantedContent string
+	}{
+		"adds a variable to the default configuration": {
+			inName:  "FEATURE_FLAGS",
+			inValue: "beta",
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+  FEATURE_FLAGS: beta
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+`,
+		},
+		"overrides a variable in an environment": {
+			inEnvName: "prod",
+			inName:    "API_URL",
+			inValue:   "https://api.prod.example.com",
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+      API_URL: https://api.prod.example.com
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			written := mockManifestEdit(mockWs, editTestManifest)
+
+			opts := VariableAddOpts{
+				appName:    "frontend",
+				envName:    tc.inEnvName,
+				name:       tc.inName,
+				value:      tc.inValue,
+				ws:         mockWs,
+				GlobalOpts: &GlobalOpts{projectName: "dw-run"},
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, *written)
+		})
+	}
+}
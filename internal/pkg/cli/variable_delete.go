@@ -2,7 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"reflect"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
@@ -12,7 +11,6 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // VariableDeleteOpts contains the fields to collect to delete an environment variable.
@@ -22,7 +20,7 @@ type VariableDeleteOpts struct {
 	name    string
 
 	manifestPath string
-	manifest     *manifest.LBFargateManifest
+	manifest     *manifest.Editor
 
 	storeReader storeReader
 
@@ -75,11 +73,7 @@ func (o *VariableDeleteOpts) Execute() error {
 		}
 	}
 
-	if o.envName == "" {
-		delete(o.manifest.Variables, o.name)
-	} else {
-		delete(o.manifest.Environments[o.envName].Variables, o.name)
-	}
+	o.manifest.Delete(manifest.EnvPath(o.envName, manifest.VariablesKey, o.name)...)
 
 	if err := o.writeManifest(o.manifest); err != nil {
 		return err
@@ -96,25 +90,20 @@ func (o *VariableDeleteOpts) readManifest() error {
 	if err != nil {
 		return err
 	}
-	mft, err := manifest.UnmarshalApp(raw)
+	mft, err := manifest.NewEditor(raw)
 	if err != nil {
 		return err
 	}
 
-	o.manifest = mft.(*manifest.LBFargateManifest)
+	o.manifest = mft
 	return nil
 }
 
-func (o *VariableDeleteOpts) writeManifest(mft *manifest.LBFargateManifest) error {
-	if len(o.manifest.Environments[o.envName].Variables) == 0 {
-		envConf := o.manifest.Environments[o.envName]
-		envConf.Variables = nil
-		o.manifest.Environments[o.envName] = envConf
-	}
-	if reflect.DeepEqual(o.manifest.Environments[o.envName], manifest.LBFargateConfig{}) {
-		delete(o.manifest.Environments, o.envName)
+func (o *VariableDeleteOpts) writeManifest(mft *manifest.Editor) error {
+	manifestBytes, err := mft.Marshal()
+	if err != nil {
+		return err
 	}
-	manifestBytes, err := yaml.Marshal(mft)
 	_, err = o.ws.WriteFile(manifestBytes, o.manifestPath)
 	return err
 }
@@ -180,19 +169,7 @@ func (o *VariableDeleteOpts) retrieveEnvVars() ([]string, error) {
 		return nil, err
 	}
 
-	var keys []string
-	var variables map[string]string
-
-	if o.envName == "" {
-		variables = o.manifest.Variables
-	} else {
-		variables = o.manifest.Environments[o.envName].Variables
-	}
-
-	for k := range variables {
-		keys = append(keys, k)
-	}
-	return keys, nil
+	return o.manifest.Keys(manifest.EnvPath(o.envName, manifest.VariablesKey)...), nil
 }
 
 func (o *VariableDeleteOpts) askEnvVarName() error {
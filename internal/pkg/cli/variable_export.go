@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/dotenv"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// VariableExportOpts contains the fields to collect to export the environment variables of an application.
+type VariableExportOpts struct {
+	VariableListOpts
+
+	file string
+	fs   afero.Fs
+}
+
+// Ask asks for fields that are required but not passed in.
+func (o *VariableExportOpts) Ask() error {
+	if err := o.VariableListOpts.Ask(); err != nil {
+		return err
+	}
+	return o.askEnvName()
+}
+
+// Execute writes the variables the application's containers see in the environment as dotenv or JSON.
+// Secrets are left out since their values are only available to the containers, and so are
+// the database endpoint variables since the stack only sets their values when it is deployed.
+func (o *VariableExportOpts) Execute() error {
+	mf, err := o.appManifest()
+	if err != nil {
+		return err
+	}
+	all, err := effectiveVariables(mf, o.ProjectName(), o.envName)
+	if err != nil {
+		return err
+	}
+	var vars []dotenv.Variable
+	var secrets, generated []string
+	for _, v := range all {
+		if v.Masked {
+			secrets = append(secrets, v.Name)
+			continue
+		}
+		if v.Value == autoGeneratedValue {
+			generated = append(generated, v.Name)
+			continue
+		}
+		vars = append(vars, dotenv.Variable{Name: v.Name, Value: v.Value})
+	}
+	if len(secrets) > 0 {
+		log.Warningf("Skipped the secrets %s.\n", strings.Join(secrets, ", "))
+	}
+	if len(generated) > 0 {
+		log.Warningf("Skipped the variables %s set when the app is deployed.\n", strings.Join(generated, ", "))
+	}
+
+	if o.file == "" {
+		return o.export(o.w, vars)
+	}
+	f, err := o.fs.OpenFile(o.file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("create file %s: %w", o.file, err)
+	}
+	if err := o.export(f, vars); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close file %s: %w", o.file, err)
+	}
+	return nil
+}
+
+func (o *VariableExportOpts) export(w io.Writer, vars []dotenv.Variable) error {
+	if o.shouldOutputJSON {
+		values := make(map[string]string, len(vars))
+		for _, v := range vars {
+			values[v.Name] = v.Value
+		}
+		return writeJSON(w, values)
+	}
+	return dotenv.Write(w, vars)
+}
+
+func (o *VariableExportOpts) askEnvName() error {
+	if o.envName != "" {
+		return nil
+	}
+	envs, err := o.storeReader.ListEnvironments(o.ProjectName())
+	if err != nil {
+		return fmt.Errorf("list environments of project %s: %w", o.ProjectName(), err)
+	}
+	if len(envs) == 0 {
+		return fmt.Errorf("no environments found in project %s", o.ProjectName())
+	}
+	var envNames []string
+	for _, env := range envs {
+		envNames = append(envNames, env.Name)
+	}
+	envName, err := o.prompt.SelectOne(
+		"Which environment:",
+		"The environment whose variables are exported.",
+		envNames,
+	)
+	if err != nil {
+		return fmt.Errorf("selecting environment for project %s: %w", o.ProjectName(), err)
+	}
+	o.envName = envName
+
+	return nil
+}
+
+// BuildVariableExportCmd exports the environment variables of an application.
+func BuildVariableExportCmd() *cobra.Command {
+	opts := VariableExportOpts{
+		VariableListOpts: VariableListOpts{
+			GlobalOpts: NewGlobalOpts(),
+			w:          os.Stdout,
+		},
+		fs: &afero.Afero{Fs: afero.NewOsFs()},
+	}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the environment variables of an application.",
+		Long: `Writes the environment variables the containers of an application see in an environment
+as a dotenv file, for example to run the application locally. Secrets are not exported.`,
+		Example: `
+  Writes the variables of the "frontend" application in the "test" environment to the .env file.
+  /code $ dw_run.sh variable export --app frontend --env test --file .env
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			store, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			opts.ws = ws
+			opts.storeReader = store
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Path of the file to write, the standard output by default.")
+	cmd.Flags().BoolVar(&opts.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
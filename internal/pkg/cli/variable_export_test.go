@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const exportTestManifest = `name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+variables:
+  LOG_LEVEL: info
+  GREETING: "Hello, \"world\" # not a comment"
+  CERTIFICATE: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+  DB_NAME: frontenddb
+  DB_HOST: '*auto-generated*'
+  DB_PORT: '*auto-generated*'
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+  DB_PASSWORD:
+    from: dw-run-frontend-database
+    backend: secretsmanager
+database:
+  engine: postgresql
+environments:
+  prod:
+    variables:
+      LOG_LEVEL: warn
+`
+
+func TestVariableExportOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inJSON bool
+
+		wantedContent string
+	}{
+		"writes the variables as dotenv without the secrets and generated variables": {
+			wantedContent: `CERTIFICATE="-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+DB_NAME=frontenddb
+GREETING="Hello, \"world\" # not a comment"
+LOG_LEVEL=warn
+`,
+		},
+		"writes the variables as JSON": {
+			inJSON: true,
+
+			wantedContent: `{"CERTIFICATE":"-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----","DB_NAME":"frontenddb","GREETING":"Hello, \"world\" # not a comment","LOG_LEVEL":"warn"}
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+			mockWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(exportTestManifest), nil)
+			b := &bytes.Buffer{}
+
+			opts := VariableExportOpts{
+				VariableListOpts: VariableListOpts{
+					appName:          "frontend",
+					envName:          "prod",
+					shouldOutputJSON: tc.inJSON,
+					ws:               mockWs,
+					w:                b,
+					GlobalOpts:       &GlobalOpts{projectName: "dw-run"},
+				},
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, b.String())
+		})
+	}
+}
+
+func TestVariableExportOpts_RoundTrip(t *testing.T) {
+	// GIVEN
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+	exportWs := mocks.NewMockWorkspace(ctrl)
+	exportWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+	exportWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(exportTestManifest), nil)
+	importWs := mocks.NewMockWorkspace(ctrl)
+	written := mockManifestEdit(importWs, `name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+`)
+
+	exportOpts := VariableExportOpts{
+		VariableListOpts: VariableListOpts{
+			appName:    "frontend",
+			envName:    "prod",
+			ws:         exportWs,
+			GlobalOpts: &GlobalOpts{projectName: "dw-run"},
+		},
+		file: ".env",
+		fs:   fs,
+	}
+	importOpts := VariableImportOpts{
+		VariableAddOpts: VariableAddOpts{
+			appName:    "frontend",
+			ws:         importWs,
+			GlobalOpts: &GlobalOpts{projectName: "dw-run"},
+		},
+		file: ".env",
+		fs:   fs,
+	}
+
+	// WHEN
+	require.NoError(t, exportOpts.Execute())
+	require.NoError(t, importOpts.Execute())
+
+	// THEN
+	mf, err := manifest.UnmarshalApp([]byte(*written))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"CERTIFICATE": "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+		"DB_NAME":     "frontenddb",
+		"GREETING":    `Hello, "world" # not a comment`,
+		"LOG_LEVEL":   "warn",
+	}, mf.(*manifest.LBFargateManifest).Variables)
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/dotenv"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var errMissingDotenvFile = errors.New("`--file` is required")
+
+// VariableImportOpts contains the fields to collect to add the environment variables of a dotenv file.
+type VariableImportOpts struct {
+	VariableAddOpts
+
+	file string
+	fs   afero.Fs
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *VariableImportOpts) Validate() error {
+	if o.file == "" {
+		return errMissingDotenvFile
+	}
+	return o.VariableAddOpts.Validate()
+}
+
+// Ask asks for fields that are required but not passed in.
+func (o *VariableImportOpts) Ask() error {
+	if err := o.askProject(); err != nil {
+		return err
+	}
+	return o.askAppName()
+}
+
+// Execute adds all the variables of the dotenv file to the manifest in a single edit.
+func (o *VariableImportOpts) Execute() error {
+	vars, err := readDotenvFile(o.fs, o.file)
+	if err != nil {
+		return err
+	}
+
+	o.manifestPath = o.ws.AppManifestFileName(o.appName)
+	mft, err := o.readManifest()
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		if err := mft.Set(v.Value, manifest.EnvPath(o.envName, manifest.VariablesKey, v.Name)...); err != nil {
+			return fmt.Errorf("add environment variable %s: %w", v.Name, err)
+		}
+	}
+	if err = o.writeManifest(mft); err != nil {
+		return err
+	}
+
+	log.Successf("Saved %d environment variables from %s to the manifest.\n", len(vars), color.HighlightResource(o.file))
+	return nil
+}
+
+// readDotenvFile parses the dotenv file and validates the names of its variables.
+func readDotenvFile(fs afero.Fs, path string) ([]dotenv.Variable, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open dotenv file: %w", err)
+	}
+	defer f.Close()
+
+	vars, err := dotenv.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse dotenv file %s: %w", path, err)
+	}
+	if len(vars) == 0 {
+		return nil, fmt.Errorf("no variables found in dotenv file %s", path)
+	}
+	for _, v := range vars {
+		if err := validateEnvVarName(v.Name); err != nil {
+			return nil, err
+		}
+	}
+	return vars, nil
+}
+
+// BuildVariableImportCmd adds the environment variables of a dotenv file.
+func BuildVariableImportCmd() *cobra.Command {
+	opts := VariableImportOpts{
+		VariableAddOpts: VariableAddOpts{
+			GlobalOpts: NewGlobalOpts(),
+		},
+		fs: &afero.Afero{Fs: afero.NewOsFs()},
+	}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Adds the environment variables of a dotenv file.",
+		Long: `Adds the environment variables of a dotenv file to the manifest.
+Variables that are already in the manifest are overwritten.`,
+		Example: `
+  Adds the variables of the .env file to the "frontend" application.
+  /code $ dw_run.sh variable import --app frontend --file .env
+
+  Adds the variables of the prod.env file to the overrides of the "prod" environment.
+  /code $ dw_run.sh variable import --app frontend --env prod --file prod.env
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			store, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			opts.ws = ws
+			opts.storeReader = store
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Path to the dotenv file.")
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
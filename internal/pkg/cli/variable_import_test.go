@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableImportOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inEnvName string
+		inDotenv  string
+
+		wantedErr     error
+		wantedContent string
+	}{
+		"merges into the existing variables and overwrites the conflicting ones": {
+			inDotenv: `# Local settings.
+LOG_LEVEL=debug
+FEATURE_FLAGS="search,checkout"
+`,
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: debug # Overridden in prod.
+  API_URL: https://api.example.com
+  FEATURE_FLAGS: search,checkout
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: warn
+`,
+		},
+		"merges into the overrides of an environment": {
+			inEnvName: "prod",
+			inDotenv: `LOG_LEVEL=error
+API_URL=https://api.prod.example.com
+`,
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  API_URL: https://api.example.com
+
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    count: 2
+    variables:
+      LOG_LEVEL: error
+      API_URL: https://api.prod.example.com
+`,
+		},
+		"leaves the manifest untouched if a name is invalid": {
+			inDotenv: `LOG_LEVEL=debug
+api_url=http://localhost
+`,
+
+			wantedErr: fmt.Errorf("env var name api_url is invalid: %w", errEnvVarValueBadFormat),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+			require.NoError(t, fs.WriteFile(".env", []byte(tc.inDotenv), 0600))
+			var written *string
+			if tc.wantedErr == nil {
+				written = mockManifestEdit(mockWs, editTestManifest)
+			}
+
+			opts := VariableImportOpts{
+				VariableAddOpts: VariableAddOpts{
+					appName:    "frontend",
+					envName:    tc.inEnvName,
+					ws:         mockWs,
+					GlobalOpts: &GlobalOpts{projectName: "dw-run"},
+				},
+				file: ".env",
+				fs:   fs,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, *written)
+		})
+	}
+}
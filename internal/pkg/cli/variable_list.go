@@ -0,0 +1,430 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Layers of the manifest a variable comes from.
+const (
+	variableSourceBase     = "base"     // Top-level variables and secrets.
+	variableSourceOverride = "override" // Variables and secrets of the environment.
+	variableSourceAuto     = "auto"     // Variables and secrets added by the database and s3 commands.
+)
+
+const maskedValue = "****"
+
+// Variables and secret added by the database command, the stack reads them to create and connect to the database.
+var databaseVariables = []string{"DB_NAME", "DB_USERNAME", "DB_HOST", "DB_PORT", manifest.DatabasePasswordSecret}
+
+// VariableListOpts contains the fields to collect to list the environment variables of an application.
+type VariableListOpts struct {
+	appName          string
+	envName          string
+	shouldOutputJSON bool
+
+	storeReader storeReader
+
+	ws archer.Workspace
+	w  io.Writer
+
+	*GlobalOpts
+}
+
+// variable is an environment variable or secret that the containers of an application see in an environment.
+type variable struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`   // "variable" or "secret".
+	Source    string `json:"source"` // Layer of the manifest the variable comes from.
+	Masked    bool   `json:"masked"`
+	Value     string `json:"value,omitempty"`
+	ValueFrom string `json:"valueFrom,omitempty"` // Name of the secret in its backend.
+	Backend   string `json:"backend,omitempty"`   // Backend storing the secret's value.
+}
+
+// displayValue returns the value shown for the variable, secrets are masked.
+func (v variable) displayValue() string {
+	if v.Masked {
+		return maskedValue
+	}
+	return v.Value
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *VariableListOpts) Validate() error {
+	if o.ProjectName() != "" {
+		_, err := o.storeReader.GetProject(o.ProjectName())
+		if err != nil {
+			return err
+		}
+	}
+	if o.appName != "" {
+		_, err := o.storeReader.GetApplication(o.ProjectName(), o.appName)
+		if err != nil {
+			return err
+		}
+	}
+	if o.envName != "" {
+		if _, err := o.storeReader.GetEnvironment(o.ProjectName(), o.envName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Ask asks for fields that are required but not passed in.
+func (o *VariableListOpts) Ask() error {
+	if err := o.askProject(); err != nil {
+		return err
+	}
+	return o.askAppName()
+}
+
+// Execute lists the environment variables and secrets of the application in one environment,
+// or as a matrix of all the environments of the project.
+func (o *VariableListOpts) Execute() error {
+	mf, err := o.appManifest()
+	if err != nil {
+		return err
+	}
+
+	if o.envName != "" {
+		variables, err := effectiveVariables(mf, o.ProjectName(), o.envName)
+		if err != nil {
+			return err
+		}
+		return o.writeVariables(variables)
+	}
+
+	envs, err := o.storeReader.ListEnvironments(o.ProjectName())
+	if err != nil {
+		return fmt.Errorf("list environments of project %s: %w", o.ProjectName(), err)
+	}
+	variablesByEnv := make(map[string][]variable)
+	var envNames []string
+	for _, env := range envs {
+		variables, err := effectiveVariables(mf, o.ProjectName(), env.Name)
+		if err != nil {
+			return err
+		}
+		variablesByEnv[env.Name] = variables
+		envNames = append(envNames, env.Name)
+	}
+	return o.writeMatrix(envNames, variablesByEnv)
+}
+
+func (o *VariableListOpts) appManifest() (archer.Manifest, error) {
+	raw, err := o.ws.ReadFile(o.ws.AppManifestFileName(o.appName))
+	if err != nil {
+		return nil, err
+	}
+	mf, err := manifest.UnmarshalApp(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal app manifest: %w", err)
+	}
+	return mf, nil
+}
+
+func (o *VariableListOpts) writeVariables(variables []variable) error {
+	if o.shouldOutputJSON {
+		return writeJSON(o.w, struct {
+			Variables []variable `json:"variables"`
+		}{Variables: variables})
+	}
+	writer := tabwriter.NewWriter(o.w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", "Name", "Type", "Source", "Masked", "Value")
+	for _, v := range variables {
+		masked := "no"
+		if v.Masked {
+			masked = "yes"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", v.Name, v.Type, v.Source, masked, v.displayValue())
+	}
+	return writer.Flush()
+}
+
+// writeMatrix writes the variables by environment, the ones that differ between environments are flagged.
+func (o *VariableListOpts) writeMatrix(envNames []string, variablesByEnv map[string][]variable) error {
+	if o.shouldOutputJSON {
+		return writeJSON(o.w, struct {
+			Environments map[string][]variable `json:"environments"`
+		}{Environments: variablesByEnv})
+	}
+
+	// Index the variables by name to line them up across environments.
+	byName := make(map[string]map[string]variable)
+	for env, variables := range variablesByEnv {
+		for _, v := range variables {
+			if byName[v.Name] == nil {
+				byName[v.Name] = make(map[string]variable)
+			}
+			byName[v.Name][env] = v
+		}
+	}
+	var names []string
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writer := tabwriter.NewWriter(o.w, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprintf(writer, "%s\t%s\n", "Name", strings.Join(envNames, "\t"))
+	var drift bool
+	for _, name := range names {
+		var cells []string
+		differs := false
+		var first *variable
+		for _, env := range envNames {
+			v, ok := byName[name][env]
+			if !ok {
+				cells = append(cells, "-")
+				differs = true
+				continue
+			}
+			cells = append(cells, v.displayValue())
+			if first == nil {
+				first = &v
+			} else if v.Value != first.Value || v.ValueFrom != first.ValueFrom {
+				differs = true
+			}
+		}
+		label := name
+		if differs && len(envNames) > 1 {
+			label += " (!)"
+			drift = true
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", label, strings.Join(cells, "\t"))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if drift {
+		fmt.Fprint(o.w, color.Yellow.Sprintln("\n(!) The value differs between environments."))
+	}
+	return nil
+}
+
+// effectiveVariables returns the variables and secrets of the application's containers in the environment,
+// merged with EnvConf, along with the layer of the manifest each of them comes from.
+func effectiveVariables(mf archer.Manifest, projectName, envName string) ([]variable, error) {
+	var overrides, merged manifest.ContainersConfig
+	var hasDatabase bool
+	switch m := mf.(type) {
+	case *manifest.LBFargateManifest:
+		conf := m.EnvConf(envName)
+		overrides, merged = m.Environments[envName].ContainersConfig, conf.ContainersConfig
+		hasDatabase = conf.Database != nil && conf.Database.Engine != ""
+	case *manifest.BackendAppManifest:
+		overrides, merged = m.Environments[envName].ContainersConfig, m.EnvConf(envName).ContainersConfig
+	case *manifest.WorkerServiceManifest:
+		overrides, merged = m.Environments[envName].ContainersConfig, m.EnvConf(envName).ContainersConfig
+	case *manifest.ScheduledJobManifest:
+		overrides, merged = m.Environments[envName].ContainersConfig, m.EnvConf(envName).ContainersConfig
+	default:
+		return nil, fmt.Errorf("list the variables of application %s of type %T", mf.AppName(), mf)
+	}
+
+	// The s3 command adds the bucket and prefix of the environment's storage to the environment's variables.
+	isAuto := func(name, value string) bool {
+		if hasDatabase && contains(name, databaseVariables) {
+			return true
+		}
+		switch name {
+		case "S3_BUCKET":
+			return value == s3Bucket(projectName, envName)
+		case "S3_PREFIX":
+			return value == s3Prefix(mf.AppName())
+		}
+		return false
+	}
+
+	var variables []variable
+	for name, value := range merged.Variables {
+		_, overridden := overrides.Variables[name]
+		variables = append(variables, variable{
+			Name:   name,
+			Type:   "variable",
+			Source: variableSource(isAuto(name, value), overridden),
+			Value:  value,
+		})
+	}
+	for name, secret := range merged.Secrets {
+		_, overridden := overrides.Secrets[name]
+		variables = append(variables, variable{
+			Name:      name,
+			Type:      "secret",
+			Source:    variableSource(isAuto(name, ""), overridden),
+			Masked:    true,
+			ValueFrom: secret.From,
+			Backend:   secret.StoredIn(),
+		})
+	}
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+	return variables, nil
+}
+
+func variableSource(auto, overridden bool) string {
+	if auto {
+		return variableSourceAuto
+	}
+	if overridden {
+		return variableSourceOverride
+	}
+	return variableSourceBase
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal to JSON: %w", err)
+	}
+	fmt.Fprintf(w, "%s\n", data)
+	return nil
+}
+
+func (o *VariableListOpts) askProject() error {
+	if o.ProjectName() != "" {
+		return nil
+	}
+	projNames, err := o.retrieveProjects()
+	if err != nil {
+		return err
+	}
+	if len(projNames) == 0 {
+		log.Infoln("There are no projects to select.")
+	}
+	proj, err := o.prompt.SelectOne(
+		"Which project:",
+		applicationShowProjectNameHelpPrompt,
+		projNames,
+	)
+	if err != nil {
+		return fmt.Errorf("selecting projects: %w", err)
+	}
+	o.projectName = proj
+
+	return nil
+}
+
+func (o *VariableListOpts) askAppName() error {
+	if o.appName != "" {
+		return nil
+	}
+	appNames, err := o.workspaceAppNames()
+	if err != nil {
+		return err
+	}
+	if len(appNames) == 0 {
+		return fmt.Errorf("no applications found in project %s", o.ProjectName())
+	}
+	if len(appNames) == 1 {
+		o.appName = appNames[0]
+		log.Infof("Found the app: %s\n", color.HighlightUserInput(o.appName))
+		return nil
+	}
+	appName, err := o.prompt.SelectOne(
+		"Which app:",
+		"The app whose environment variables are listed.",
+		appNames,
+	)
+	if err != nil {
+		return fmt.Errorf("selecting applications for project %s: %w", o.ProjectName(), err)
+	}
+	o.appName = appName
+
+	return nil
+}
+
+func (o *VariableListOpts) retrieveProjects() ([]string, error) {
+	projs, err := o.storeReader.ListProjects()
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %w", err)
+	}
+	projNames := make([]string, len(projs))
+	for ind, proj := range projs {
+		projNames[ind] = proj.Name
+	}
+	return projNames, nil
+}
+
+func (o *VariableListOpts) workspaceAppNames() ([]string, error) {
+	apps, err := o.ws.Apps()
+	if err != nil {
+		return nil, fmt.Errorf("get applications in the workspace: %w", err)
+	}
+	var names []string
+	for _, app := range apps {
+		names = append(names, app.AppName())
+	}
+	return names, nil
+}
+
+// BuildVariableListCmd lists the environment variables of an application.
+func BuildVariableListCmd() *cobra.Command {
+	opts := VariableListOpts{
+		GlobalOpts: NewGlobalOpts(),
+		w:          os.Stdout,
+	}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists the environment variables and secrets of an application.",
+		Long: `Lists the environment variables and secrets the containers of an application see in an environment,
+with the layer of the manifest each of them comes from. Without an environment, compares all the environments.`,
+		Example: `
+  Lists the variables of the "frontend" application in the "prod" environment.
+  /code $ dw_run.sh variable list --app frontend --env prod
+
+  Compares the variables of the "frontend" application across environments.
+  /code $ dw_run.sh variable list --app frontend
+`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			store, err := store.New()
+			if err != nil {
+				return fmt.Errorf("connect to environment datastore: %w", err)
+			}
+			ws, err := workspace.New()
+			if err != nil {
+				return fmt.Errorf("new workspace: %w", err)
+			}
+			opts.ws = ws
+			opts.storeReader = store
+
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if err := opts.Ask(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().StringVarP(&opts.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().BoolVar(&opts.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	cmd.Flags().StringP(projectFlag, projectFlagShort, "dw-run" /* default */, projectFlagDescription)
+	viper.BindPFlag(projectFlag, cmd.Flags().Lookup(projectFlag))
+
+	return cmd
+}
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	climocks "github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+const listTestManifest = `name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+variables:
+  LOG_LEVEL: info
+  API_URL: https://api.example.com
+secrets:
+  GITHUB_TOKEN: /ecs-cli-v2/dw-run/applications/frontend/secrets/github-token
+environments:
+  test:
+    variables:
+      S3_BUCKET: dw-run-test-storage
+      S3_PREFIX: /apps/frontend
+  prod:
+    variables:
+      LOG_LEVEL: warn
+      S3_BUCKET: frontend-archive
+    secrets:
+      GITHUB_TOKEN:
+        from: frontend-github-token
+        backend: secretsmanager
+`
+
+func TestEffectiveVariables(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+		inEnv      string
+
+		wantedVariables []variable
+	}{
+		"base variables and masked secrets": {
+			inManifest: listTestManifest,
+			inEnv:      "dev",
+
+			wantedVariables: []variable{
+				{Name: "API_URL", Type: "variable", Source: "base", Value: "https://api.example.com"},
+				{Name: "GITHUB_TOKEN", Type: "secret", Source: "base", Masked: true, ValueFrom: "/ecs-cli-v2/dw-run/applications/frontend/secrets/github-token", Backend: "ssm"},
+				{Name: "LOG_LEVEL", Type: "variable", Source: "base", Value: "info"},
+			},
+		},
+		"environment overrides take precedence": {
+			inManifest: listTestManifest,
+			inEnv:      "prod",
+
+			wantedVariables: []variable{
+				{Name: "API_URL", Type: "variable", Source: "base", Value: "https://api.example.com"},
+				{Name: "GITHUB_TOKEN", Type: "secret", Source: "override", Masked: true, ValueFrom: "frontend-github-token", Backend: "secretsmanager"},
+				{Name: "LOG_LEVEL", Type: "variable", Source: "override", Value: "warn"},
+				{Name: "S3_BUCKET", Type: "variable", Source: "override", Value: "frontend-archive"},
+			},
+		},
+		"variables added by the s3 command are auto": {
+			inManifest: listTestManifest,
+			inEnv:      "test",
+
+			wantedVariables: []variable{
+				{Name: "API_URL", Type: "variable", Source: "base", Value: "https://api.example.com"},
+				{Name: "GITHUB_TOKEN", Type: "secret", Source: "base", Masked: true, ValueFrom: "/ecs-cli-v2/dw-run/applications/frontend/secrets/github-token", Backend: "ssm"},
+				{Name: "LOG_LEVEL", Type: "variable", Source: "base", Value: "info"},
+				{Name: "S3_BUCKET", Type: "variable", Source: "auto", Value: "dw-run-test-storage"},
+				{Name: "S3_PREFIX", Type: "variable", Source: "auto", Value: "/apps/frontend"},
+			},
+		},
+		"variables added by the database command are auto": {
+			inManifest: `name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+variables:
+  DB_NAME: frontenddb
+  DB_USERNAME: admin
+  DB_HOST: '*auto-generated*'
+  DB_PORT: '*auto-generated*'
+  DB_POOL_SIZE: "10"
+secrets:
+  DB_PASSWORD:
+    from: dw-run-frontend-database
+    backend: secretsmanager
+database:
+  engine: postgresql
+`,
+			inEnv: "test",
+
+			wantedVariables: []variable{
+				{Name: "DB_HOST", Type: "variable", Source: "auto", Value: "*auto-generated*"},
+				{Name: "DB_NAME", Type: "variable", Source: "auto", Value: "frontenddb"},
+				{Name: "DB_PASSWORD", Type: "secret", Source: "auto", Masked: true, ValueFrom: "dw-run-frontend-database", Backend: "secretsmanager"},
+				{Name: "DB_POOL_SIZE", Type: "variable", Source: "base", Value: "10"},
+				{Name: "DB_PORT", Type: "variable", Source: "auto", Value: "*auto-generated*"},
+				{Name: "DB_USERNAME", Type: "variable", Source: "auto", Value: "admin"},
+			},
+		},
+		"database variables without a database are not auto": {
+			inManifest: `name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+variables:
+  DB_NAME: legacy
+environments:
+  test:
+    variables:
+      DB_NAME: legacy-test
+`,
+			inEnv: "test",
+
+			wantedVariables: []variable{
+				{Name: "DB_NAME", Type: "variable", Source: "override", Value: "legacy-test"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mf, err := manifest.UnmarshalApp([]byte(tc.inManifest))
+			require.NoError(t, err)
+
+			// WHEN
+			variables, err := effectiveVariables(mf, "dw-run", tc.inEnv)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedVariables, variables)
+		})
+	}
+}
+
+func TestVariableListOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inEnv  string
+		inJSON bool
+
+		setupMocks func(mockStore *climocks.MockstoreReader)
+
+		wantedContent string
+	}{
+		"one environment": {
+			inEnv:      "prod",
+			setupMocks: func(mockStore *climocks.MockstoreReader) {},
+
+			wantedContent: `Name                Type                Source              Masked              Value
+API_URL             variable            base                no                  https://api.example.com
+GITHUB_TOKEN        secret              override            yes                 ****
+LOG_LEVEL           variable            override            no                  warn
+S3_BUCKET           variable            override            no                  frontend-archive
+`,
+		},
+		"one environment in JSON": {
+			inEnv:      "test",
+			inJSON:     true,
+			setupMocks: func(mockStore *climocks.MockstoreReader) {},
+
+			wantedContent: `{"variables":[{"name":"API_URL","type":"variable","source":"base","masked":false,"value":"https://api.example.com"},{"name":"GITHUB_TOKEN","type":"secret","source":"base","masked":true,"valueFrom":"/ecs-cli-v2/dw-run/applications/frontend/secrets/github-token","backend":"ssm"},{"name":"LOG_LEVEL","type":"variable","source":"base","masked":false,"value":"info"},{"name":"S3_BUCKET","type":"variable","source":"auto","masked":false,"value":"dw-run-test-storage"},{"name":"S3_PREFIX","type":"variable","source":"auto","masked":false,"value":"/apps/frontend"}]}
+`,
+		},
+		"matrix of all the environments": {
+			setupMocks: func(mockStore *climocks.MockstoreReader) {
+				mockStore.EXPECT().ListEnvironments("dw-run").Return([]*archer.Environment{
+					{Name: "test"},
+					{Name: "prod"},
+				}, nil)
+			},
+
+			wantedContent: `Name                test                     prod
+API_URL             https://api.example.com  https://api.example.com
+GITHUB_TOKEN (!)    ****                     ****
+LOG_LEVEL (!)       info                     warn
+S3_BUCKET (!)       dw-run-test-storage      frontend-archive
+S3_PREFIX (!)       /apps/frontend           -
+
+(!) The value differs between environments.
+`,
+		},
+		"matrix of all the environments in JSON": {
+			inJSON: true,
+			setupMocks: func(mockStore *climocks.MockstoreReader) {
+				mockStore.EXPECT().ListEnvironments("dw-run").Return([]*archer.Environment{
+					{Name: "dev"},
+				}, nil)
+			},
+
+			wantedContent: `{"environments":{"dev":[{"name":"API_URL","type":"variable","source":"base","masked":false,"value":"https://api.example.com"},{"name":"GITHUB_TOKEN","type":"secret","source":"base","masked":true,"valueFrom":"/ecs-cli-v2/dw-run/applications/frontend/secrets/github-token","backend":"ssm"},{"name":"LOG_LEVEL","type":"variable","source":"base","masked":false,"value":"info"}]}}
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockWorkspace(ctrl)
+			mockWs.EXPECT().AppManifestFileName("frontend").Return("frontend-app.yml")
+			mockWs.EXPECT().ReadFile("frontend-app.yml").Return([]byte(listTestManifest), nil)
+			mockStore := climocks.NewMockstoreReader(ctrl)
+			tc.setupMocks(mockStore)
+			b := &bytes.Buffer{}
+
+			opts := VariableListOpts{
+				appName:          "frontend",
+				envName:          tc.inEnv,
+				shouldOutputJSON: tc.inJSON,
+				storeReader:      mockStore,
+				ws:               mockWs,
+				w:                b,
+				GlobalOpts:       &GlobalOpts{projectName: "dw-run"},
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, b.String())
+		})
+	}
+}
@@ -24,4 +24,36 @@ type CreateLBFargateAppInput struct {
 	Env          *archer.Environment
 	ImageRepoURL string
 	ImageTag     string
+	ImageDigest  string            // Deployed instead of the tag if set, so that the image can't be replaced by pushing the tag again.
+	EnvOutputs   map[string]string // Outputs of the environment stack referenced by "${env.outputs.OutputName}" in the manifest.
+}
+
+// CreateBackendAppInput holds the fields required to deploy a backend AWS Fargate application.
+type CreateBackendAppInput struct {
+	App          *manifest.BackendAppManifest
+	Env          *archer.Environment
+	ImageRepoURL string
+	ImageTag     string
+	ImageDigest  string // Deployed instead of the tag if set, so that the image can't be replaced by pushing the tag again.
+	EnvOutputs   map[string]string
+}
+
+// CreateScheduledJobInput holds the fields required to deploy a scheduled AWS Fargate job.
+type CreateScheduledJobInput struct {
+	App          *manifest.ScheduledJobManifest
+	Env          *archer.Environment
+	ImageRepoURL string
+	ImageTag     string
+	ImageDigest  string // Deployed instead of the tag if set, so that the image can't be replaced by pushing the tag again.
+	EnvOutputs   map[string]string
+}
+
+// CreateWorkerServiceInput holds the fields required to deploy a queue-driven AWS Fargate service.
+type CreateWorkerServiceInput struct {
+	App          *manifest.WorkerServiceManifest
+	Env          *archer.Environment
+	ImageRepoURL string
+	ImageTag     string
+	ImageDigest  string // Deployed instead of the tag if set, so that the image can't be replaced by pushing the tag again.
+	EnvOutputs   map[string]string
 }
@@ -0,0 +1,46 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+// Resource types that hold data which is lost if the resource is replaced or removed.
+var statefulResourceTypes = map[string]bool{
+	"AWS::RDS::DBCluster":      true,
+	"AWS::RDS::DBInstance":     true,
+	"AWS::S3::Bucket":          true,
+	"AWS::DynamoDB::Table":     true,
+	"AWS::EFS::FileSystem":     true,
+	"AWS::ECR::Repository":     true,
+	"AWS::SQS::Queue":          true,
+	"AWS::KMS::Key":            true,
+	"AWS::Route53::HostedZone": true,
+}
+
+// Actions and replacement values of a ResourceChange.
+const (
+	changeActionRemove     = "Remove"
+	replacementTrue        = "True"
+	replacementConditional = "Conditional"
+)
+
+// ResourceChange represents a change that a deployment would make to a resource of a stack.
+type ResourceChange struct {
+	LogicalID   string   `json:"logicalID"`
+	Type        string   `json:"type"`
+	Action      string   `json:"action"`                // Add, Modify, Remove, Import or Dynamic.
+	Replacement string   `json:"replacement,omitempty"` // True, False or Conditional if the resource is modified.
+	Properties  []string `json:"properties,omitempty"`  // Names of the modified properties.
+}
+
+// Replaced returns true if the resource is or may be replaced by a new one.
+func (c ResourceChange) Replaced() bool {
+	return c.Replacement == replacementTrue || c.Replacement == replacementConditional
+}
+
+// Dangerous returns true if the change replaces or removes a resource that holds data.
+func (c ResourceChange) Dangerous() bool {
+	if !statefulResourceTypes[c.Type] {
+		return false
+	}
+	return c.Action == changeActionRemove || c.Replaced()
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -15,13 +16,7 @@ import (
 // DeployApp wraps the application deployment flow and handles orchestration of
 // creating a stack versus updating a stack.
 func (cf CloudFormation) DeployApp(template, stackName, changeSetName, cfExecutionRole string, tags map[string]string) error {
-	var cfnTags []*cloudformation.Tag
-	for k, v := range tags {
-		cfnTags = append(cfnTags, &cloudformation.Tag{
-			Key:   aws.String(k),
-			Value: aws.String(v),
-		})
-	}
+	cfnTags := toCfnTags(tags)
 
 	_, err := cf.client.CreateStack(&cloudformation.CreateStackInput{
 		StackName:    aws.String(stackName),
@@ -111,3 +106,39 @@ func (cf CloudFormation) DeployApp(template, stackName, changeSetName, cfExecuti
 
 	return nil
 }
+
+// StreamAppDeployment deploys the application's stack like DeployApp and streams the resource events of the deployment while it is taking place.
+// Once the deployment halts, the events channel is closed and the result of the deployment is sent to the second channel.
+func (cf CloudFormation) StreamAppDeployment(template, stackName, changeSetName, cfExecutionRole string, tags map[string]string) (<-chan []deploy.ResourceEvent, <-chan error) {
+	done := make(chan struct{})
+	events := make(chan []deploy.ResourceEvent)
+	resp := make(chan error, 1)
+
+	since := cf.latestStackEventTime(stackName)
+	go cf.streamResourceEvents(done, events, stackName, since)
+	go func() {
+		defer close(done)
+		resp <- cf.DeployApp(template, stackName, changeSetName, cfExecutionRole, tags)
+	}()
+	return events, resp
+}
+
+// DiffApp returns the changes that deploying the template would make to the application's stack without deploying it.
+func (cf CloudFormation) DiffApp(template, stackName, cfExecutionRole string, tags map[string]string) ([]deploy.ResourceChange, error) {
+	in, err := createChangeSetInput(stackName, template, withTags(toCfnTags(tags)), withRoleARN(cfExecutionRole))
+	if err != nil {
+		return nil, err
+	}
+	return cf.diff(in)
+}
+
+func toCfnTags(tags map[string]string) []*cloudformation.Tag {
+	var cfnTags []*cloudformation.Tag
+	for k, v := range tags {
+		cfnTags = append(cfnTags, &cloudformation.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return cfnTags
+}
@@ -7,7 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/stretchr/testify/require"
@@ -204,3 +207,220 @@ func TestDeployApp(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamAppDeployment(t *testing.T) {
+	// GIVEN
+	deployedAt := time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC)
+	previousEvent := &cloudformation.StackEvent{
+		LogicalResourceId: aws.String("Service"),
+		ResourceType:      aws.String("AWS::ECS::Service"),
+		ResourceStatus:    aws.String(cloudformation.ResourceStatusUpdateComplete),
+		Timestamp:         aws.Time(deployedAt),
+	}
+	calls := 0
+	cf := CloudFormation{
+		client: mockCloudFormation{
+			t: t,
+			mockDescribeStackEvents: func(t *testing.T, in *cloudformation.DescribeStackEventsInput) (*cloudformation.DescribeStackEventsOutput, error) {
+				require.Equal(t, "mockStackName", *in.StackName)
+				calls++
+				if calls == 1 {
+					return &cloudformation.DescribeStackEventsOutput{
+						StackEvents: []*cloudformation.StackEvent{previousEvent},
+					}, nil
+				}
+				return &cloudformation.DescribeStackEventsOutput{
+					StackEvents: []*cloudformation.StackEvent{
+						{
+							LogicalResourceId:    aws.String("Service"),
+							ResourceType:         aws.String("AWS::ECS::Service"),
+							ResourceStatus:       aws.String(cloudformation.ResourceStatusUpdateFailed),
+							ResourceStatusReason: aws.String("Container port 80 is not valid. Status Code: 400"),
+							Timestamp:            aws.Time(deployedAt.Add(time.Hour)),
+						},
+						previousEvent,
+					},
+				}, nil
+			},
+			mockCreateStack: func(t *testing.T, in *cloudformation.CreateStackInput) (*cloudformation.CreateStackOutput, error) {
+				return nil, errors.New("some error")
+			},
+		},
+	}
+
+	// WHEN
+	events, resp := cf.StreamAppDeployment("mockTemplate", "mockStackName", "mockChangeSetName", "mockExecutionRole", nil)
+
+	// THEN
+	require.Equal(t, []deploy.ResourceEvent{
+		{
+			Resource: deploy.Resource{
+				LogicalName: "Service",
+				Type:        "AWS::ECS::Service",
+			},
+			Status:       cloudformation.ResourceStatusUpdateFailed,
+			StatusReason: "Container port 80 is not valid",
+		},
+	}, <-events)
+	require.EqualError(t, <-resp, "create stack: some error")
+}
+
+func TestDiffApp(t *testing.T) {
+	changeSetOutput := &cloudformation.DescribeChangeSetOutput{
+		ExecutionStatus: aws.String(cloudformation.ExecutionStatusAvailable),
+		Changes: []*cloudformation.Change{
+			{
+				ResourceChange: &cloudformation.ResourceChange{
+					LogicalResourceId: aws.String("Bucket"),
+					ResourceType:      aws.String("AWS::S3::Bucket"),
+					Action:            aws.String(cloudformation.ChangeActionModify),
+					Replacement:       aws.String(cloudformation.ReplacementTrue),
+					Details: []*cloudformation.ResourceChangeDetail{
+						{Target: &cloudformation.ResourceTargetDefinition{Attribute: aws.String(cloudformation.ResourceAttributeProperties), Name: aws.String("BucketName")}},
+						{Target: &cloudformation.ResourceTargetDefinition{Attribute: aws.String(cloudformation.ResourceAttributeProperties), Name: aws.String("BucketName")}},
+						{Target: &cloudformation.ResourceTargetDefinition{Attribute: aws.String(cloudformation.ResourceAttributeTags)}},
+					},
+				},
+			},
+		},
+	}
+	wantedChanges := []deploy.ResourceChange{
+		{
+			LogicalID:   "Bucket",
+			Type:        "AWS::S3::Bucket",
+			Action:      "Modify",
+			Replacement: "True",
+			Properties:  []string{"BucketName"},
+		},
+	}
+
+	testCases := map[string]struct {
+		mockDescribeStacks    func(t *testing.T, in *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error)
+		mockWaitForChangeSet  func(t *testing.T, in *cloudformation.DescribeChangeSetInput) error
+		mockDescribeChangeSet func(t *testing.T, in *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error)
+
+		wantedChangeSetType string
+		wantedDeletedStack  bool
+		wantedChanges       []deploy.ResourceChange
+		wantedErr           error
+	}{
+		"existing stack deletes the change set": {
+			mockDescribeStacks: func(t *testing.T, in *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{{StackStatus: aws.String("UPDATE_COMPLETE")}},
+				}, nil
+			},
+			mockWaitForChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) error {
+				return nil
+			},
+			mockDescribeChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error) {
+				return changeSetOutput, nil
+			},
+			wantedChangeSetType: cloudformation.ChangeSetTypeUpdate,
+			wantedChanges:       wantedChanges,
+		},
+		"new stack deletes the stack in review": {
+			mockDescribeStacks: func(t *testing.T, in *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return nil, awserr.New("ValidationError", "Stack with id mockStackName does not exist", nil)
+			},
+			mockWaitForChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) error {
+				return nil
+			},
+			mockDescribeChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error) {
+				return changeSetOutput, nil
+			},
+			wantedChangeSetType: cloudformation.ChangeSetTypeCreate,
+			wantedDeletedStack:  true,
+			wantedChanges:       wantedChanges,
+		},
+		"change set without changes": {
+			mockDescribeStacks: func(t *testing.T, in *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{{StackStatus: aws.String("UPDATE_COMPLETE")}},
+				}, nil
+			},
+			mockWaitForChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) error {
+				return errors.New("ResourceNotReady: failed waiting for successful resource state")
+			},
+			mockDescribeChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error) {
+				return &cloudformation.DescribeChangeSetOutput{
+					ExecutionStatus: aws.String(cloudformation.ExecutionStatusUnavailable),
+					StatusReason:    aws.String("The submitted information didn't contain changes."),
+				}, nil
+			},
+			wantedChangeSetType: cloudformation.ChangeSetTypeUpdate,
+		},
+		"change set that fails with a reason": {
+			mockDescribeStacks: func(t *testing.T, in *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{{StackStatus: aws.String("UPDATE_COMPLETE")}},
+				}, nil
+			},
+			mockWaitForChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) error {
+				return errors.New("ResourceNotReady: failed waiting for successful resource state")
+			},
+			mockDescribeChangeSet: func(t *testing.T, in *cloudformation.DescribeChangeSetInput) (*cloudformation.DescribeChangeSetOutput, error) {
+				return &cloudformation.DescribeChangeSetOutput{
+					ExecutionStatus: aws.String(cloudformation.ExecutionStatusUnavailable),
+					Status:          aws.String(cloudformation.ChangeSetStatusFailed),
+					StatusReason:    aws.String("Template format error: Unresolved resource dependencies [Cluster] in the Resources block of the template"),
+				}, nil
+			},
+			wantedChangeSetType: cloudformation.ChangeSetTypeUpdate,
+			wantedErr: fmt.Errorf("failed to wait for changeSet creation name=%s, stackID=%s: %s", mockChangeSetID, mockStackID,
+				"ResourceNotReady: failed waiting for successful resource state: "+
+					"Template format error: Unresolved resource dependencies [Cluster] in the Resources block of the template"),
+		},
+		"error describing the stack": {
+			mockDescribeStacks: func(t *testing.T, in *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var deletedStack, deletedChangeSet bool
+			cf := CloudFormation{
+				client: mockCloudFormation{
+					t: t,
+
+					mockDescribeStacks: tc.mockDescribeStacks,
+					mockCreateChangeSet: func(t *testing.T, in *cloudformation.CreateChangeSetInput) (*cloudformation.CreateChangeSetOutput, error) {
+						require.Equal(t, "mockStackName", *in.StackName)
+						require.Equal(t, tc.wantedChangeSetType, *in.ChangeSetType)
+						require.Equal(t, "mockExecutionRole", *in.RoleARN)
+						return &cloudformation.CreateChangeSetOutput{
+							Id:      aws.String(mockChangeSetID),
+							StackId: aws.String(mockStackID),
+						}, nil
+					},
+					mockWaitUntilChangeSetCreateCompleteWithContext: tc.mockWaitForChangeSet,
+					mockDescribeChangeSet:                           tc.mockDescribeChangeSet,
+					mockDeleteStack: func(t *testing.T, in *cloudformation.DeleteStackInput) (*cloudformation.DeleteStackOutput, error) {
+						require.Equal(t, mockStackID, *in.StackName)
+						deletedStack = true
+						return nil, nil
+					},
+					mockDeleteChangeSet: func(t *testing.T, in *cloudformation.DeleteChangeSetInput) (*cloudformation.DeleteChangeSetOutput, error) {
+						require.Equal(t, mockChangeSetID, *in.ChangeSetName)
+						deletedChangeSet = true
+						return nil, nil
+					},
+				},
+			}
+
+			gotChanges, gotErr := cf.DiffApp("mockTemplate", "mockStackName", "mockExecutionRole", nil)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, gotErr, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+			require.Equal(t, tc.wantedChanges, gotChanges)
+			require.Equal(t, tc.wantedDeletedStack, deletedStack)
+			require.Equal(t, !tc.wantedDeletedStack, deletedChangeSet)
+		})
+	}
+}
@@ -6,7 +6,9 @@ package cloudformation
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -18,6 +20,10 @@ import (
 const (
 	noChangesReason = "NO_CHANGES_REASON"
 	noUpdatesReason = "NO_UPDATES_REASON"
+
+	// Messages of the status reasons above, as returned by DescribeChangeSet.
+	noChangesMessage = "didn't contain changes"
+	noUpdatesMessage = "No updates are to be performed"
 )
 
 // changeSet represents a CloudFormation Change Set.
@@ -84,10 +90,7 @@ func (set *changeSet) execute() error {
 	}
 	if set.executionStatus != cloudformation.ExecutionStatusAvailable {
 		// Ignore execute request if the change set does not contain any modifications.
-		if set.statusReason == noChangesReason {
-			return nil
-		}
-		if set.statusReason == noUpdatesReason {
+		if set.hasNoChanges() {
 			return nil
 		}
 		return &ErrNotExecutableChangeSet{
@@ -103,6 +106,63 @@ func (set *changeSet) execute() error {
 	return nil
 }
 
+// resourceChanges waits for the change set to be created and returns the changes it would make to the stack's resources.
+func (set *changeSet) resourceChanges() ([]deploy.ResourceChange, error) {
+	waitErr := set.waitForCreation()
+	if err := set.describe(); err != nil {
+		return nil, err
+	}
+	if waitErr != nil {
+		// The change set fails to be created if it doesn't contain any change.
+		if set.hasNoChanges() {
+			return nil, nil
+		}
+		// Invalid templates, parameters or capabilities fail the change set as well, with the reason in its status.
+		if set.statusReason != "" {
+			return nil, fmt.Errorf("%w: %s", waitErr, set.statusReason)
+		}
+		return nil, waitErr
+	}
+	var changes []deploy.ResourceChange
+	for _, change := range set.changes {
+		rc := change.ResourceChange
+		if rc == nil {
+			continue
+		}
+		var props []string
+		seen := make(map[string]bool)
+		for _, detail := range rc.Details {
+			if detail.Target == nil || aws.StringValue(detail.Target.Attribute) != cloudformation.ResourceAttributeProperties {
+				continue
+			}
+			name := aws.StringValue(detail.Target.Name)
+			if !seen[name] {
+				seen[name] = true
+				props = append(props, name)
+			}
+		}
+		changes = append(changes, deploy.ResourceChange{
+			LogicalID:   aws.StringValue(rc.LogicalResourceId),
+			Type:        aws.StringValue(rc.ResourceType),
+			Action:      aws.StringValue(rc.Action),
+			Replacement: aws.StringValue(rc.Replacement),
+			Properties:  props,
+		})
+	}
+	return changes, nil
+}
+
+// hasNoChanges returns true if the change set failed because it doesn't modify the stack.
+func (set *changeSet) hasNoChanges() bool {
+	switch {
+	case set.statusReason == noChangesReason, set.statusReason == noUpdatesReason:
+		return true
+	case strings.Contains(set.statusReason, noChangesMessage), strings.Contains(set.statusReason, noUpdatesMessage):
+		return true
+	}
+	return false
+}
+
 func (set *changeSet) delete() error {
 	if _, err := set.c.DeleteChangeSet(&cloudformation.DeleteChangeSetInput{
 		ChangeSetName: aws.String(set.name),
@@ -158,3 +218,9 @@ func withTags(tags []*cloudformation.Tag) createChangeSetOpt {
 		in.Tags = tags
 	}
 }
+
+func withRoleARN(roleARN string) createChangeSetOpt {
+	return func(in *cloudformation.CreateChangeSetInput) {
+		in.RoleARN = aws.String(roleARN)
+	}
+}
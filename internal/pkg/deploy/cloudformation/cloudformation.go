@@ -74,9 +74,10 @@ func New(sess *session.Session) CloudFormation {
 }
 
 // streamResourceEvents sends a list of ResourceEvent every 3 seconds to the events channel.
+// If since is not zero, only the events that happened after it are sent so that the events of previous deployments are left out.
 // The events channel is closed only when the done channel receives a message.
 // If an error occurs while describing stack events, it is ignored so that the stream is not interrupted.
-func (cf CloudFormation) streamResourceEvents(done <-chan struct{}, events chan []deploy.ResourceEvent, stackName string) {
+func (cf CloudFormation) streamResourceEvents(done <-chan struct{}, events chan []deploy.ResourceEvent, stackName string, since time.Time) {
 	sendStatusUpdates := func() {
 		// Send a list of ResourceEvent to events if there was no error.
 		cfEvents, err := cf.describeStackEvents(stackName)
@@ -85,6 +86,9 @@ func (cf CloudFormation) streamResourceEvents(done <-chan struct{}, events chan
 		}
 		var transformedEvents []deploy.ResourceEvent
 		for _, cfEvent := range cfEvents {
+			if !since.IsZero() && !aws.TimeValue(cfEvent.Timestamp).After(since) {
+				continue
+			}
 			transformedEvents = append(transformedEvents, deploy.ResourceEvent{
 				Resource: deploy.Resource{
 					LogicalName: aws.StringValue(cfEvent.LogicalResourceId),
@@ -110,6 +114,19 @@ func (cf CloudFormation) streamResourceEvents(done <-chan struct{}, events chan
 	}
 }
 
+// latestStackEventTime returns the time of the most recent event of the stack.
+// If the stack doesn't exist or its events can't be described, returns the zero time.
+func (cf CloudFormation) latestStackEventTime(stackName string) time.Time {
+	out, err := cf.client.DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil || len(out.StackEvents) == 0 {
+		return time.Time{}
+	}
+	// Events are returned in reverse chronological order.
+	return aws.TimeValue(out.StackEvents[0].Timestamp)
+}
+
 // describeStackEvents gathers all stack resource events in **chronological** order.
 // If an error occurs while collecting events, returns a wrapped error.
 func (cf CloudFormation) describeStackEvents(stackName string) ([]*cloudformation.StackEvent, error) {
@@ -305,6 +322,53 @@ func (cf CloudFormation) deployChangeSet(in *cloudformation.CreateChangeSetInput
 	return nil
 }
 
+// diffStack returns the changes that deploying the stack configuration would make to the stack.
+func (cf CloudFormation) diffStack(stackConfig stackConfiguration) ([]deploy.ResourceChange, error) {
+	template, err := stackConfig.Template()
+	if err != nil {
+		return nil, fmt.Errorf("template creation: %w", err)
+	}
+	in, err := createChangeSetInput(stackConfig.StackName(),
+		template,
+		withTags(stackConfig.Tags()),
+		withParameters(stackConfig.Parameters()))
+	if err != nil {
+		return nil, err
+	}
+	return cf.diff(in)
+}
+
+// diff creates a change set for the stack to list the changes it would make, and deletes it without executing it.
+// If the stack doesn't exist yet, the stack created to hold the change set is deleted as well.
+func (cf CloudFormation) diff(in *cloudformation.CreateChangeSetInput) ([]deploy.ResourceChange, error) {
+	in.ChangeSetType = aws.String(cloudformation.ChangeSetTypeUpdate)
+	if _, err := cf.describeStack(&cloudformation.DescribeStacksInput{StackName: in.StackName}); err != nil {
+		var stackNotFound *ErrStackNotFound
+		if !errors.As(err, &stackNotFound) {
+			return nil, err
+		}
+		in.ChangeSetType = aws.String(cloudformation.ChangeSetTypeCreate)
+	}
+	set, err := cf.createChangeSet(in)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := set.resourceChanges()
+	if aws.StringValue(in.ChangeSetType) == cloudformation.ChangeSetTypeCreate {
+		// Deleting the stack in review also deletes its change set.
+		if _, delErr := cf.client.DeleteStack(&cloudformation.DeleteStackInput{
+			StackName: aws.String(set.stackID),
+		}); delErr != nil && err == nil {
+			err = fmt.Errorf("delete stack %s created for the change set: %w", aws.StringValue(in.StackName), delErr)
+		}
+		return changes, err
+	}
+	if delErr := set.delete(); delErr != nil && err == nil {
+		err = delErr
+	}
+	return changes, err
+}
+
 func (cf CloudFormation) createChangeSet(in *cloudformation.CreateChangeSetInput) (*changeSet, error) {
 	out, err := cf.client.CreateChangeSet(in)
 	if err != nil {
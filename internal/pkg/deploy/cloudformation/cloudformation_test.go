@@ -46,6 +46,8 @@ type mockCloudFormation struct {
 	mockDescribeStackSetOperation                   func(t *testing.T, in *cloudformation.DescribeStackSetOperationInput) (*cloudformation.DescribeStackSetOperationOutput, error)
 	mockDescribeStackEvents                         func(t *testing.T, in *cloudformation.DescribeStackEventsInput) (*cloudformation.DescribeStackEventsOutput, error)
 	mockCreateStack                                 func(t *testing.T, in *cloudformation.CreateStackInput) (*cloudformation.CreateStackOutput, error)
+	mockGetTemplate                                 func(t *testing.T, in *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error)
+	mockDescribeStackResource                       func(t *testing.T, in *cloudformation.DescribeStackResourceInput) (*cloudformation.DescribeStackResourceOutput, error)
 	mockWaitUntilChangeSetCreateCompleteWithContext func(t *testing.T, in *cloudformation.DescribeChangeSetInput) error
 	mockWaitUntilStackCreateCompleteWithContext     func(t *testing.T, in *cloudformation.DescribeStacksInput) error
 	mockWaitUntilStackUpdateCompleteWithContext     func(t *testing.T, in *cloudformation.DescribeStacksInput) error
@@ -61,6 +63,10 @@ func (cf mockCloudFormation) ExecuteChangeSet(in *cloudformation.ExecuteChangeSe
 	return cf.mockExecuteChangeSet(cf.t, in)
 }
 
+func (cf mockCloudFormation) DescribeStackResource(in *cloudformation.DescribeStackResourceInput) (*cloudformation.DescribeStackResourceOutput, error) {
+	return cf.mockDescribeStackResource(cf.t, in)
+}
+
 func (cf mockCloudFormation) DeleteStack(in *cloudformation.DeleteStackInput) (*cloudformation.DeleteStackOutput, error) {
 	return cf.mockDeleteStack(cf.t, in)
 }
@@ -109,6 +115,10 @@ func (cf mockCloudFormation) CreateStack(in *cloudformation.CreateStackInput) (*
 	return cf.mockCreateStack(cf.t, in)
 }
 
+func (cf mockCloudFormation) GetTemplate(in *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error) {
+	return cf.mockGetTemplate(cf.t, in)
+}
+
 func (cf mockCloudFormation) WaitUntilStackUpdateCompleteWithContext(context context.Context, in *cloudformation.DescribeStacksInput, opts ...request.WaiterOption) error {
 	return cf.mockWaitUntilStackUpdateCompleteWithContext(cf.t, in)
 }
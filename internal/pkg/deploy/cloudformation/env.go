@@ -5,6 +5,8 @@
 package cloudformation
 
 import (
+	"time"
+
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/aws-sdk-go/aws"
@@ -21,6 +23,11 @@ func (cf CloudFormation) DeployEnvironment(env *deploy.CreateEnvironmentInput) e
 	return cf.create(stack.NewEnvStackConfig(env, cf.box))
 }
 
+// DiffEnvironment returns the changes that deploying the environment would make to its stack without deploying it.
+func (cf CloudFormation) DiffEnvironment(env *deploy.CreateEnvironmentInput) ([]deploy.ResourceChange, error) {
+	return cf.diffStack(stack.NewEnvStackConfig(env, cf.box))
+}
+
 // StreamEnvironmentCreation streams resource update events while a deployment is taking place.
 // Once the CloudFormation stack operation halts, the update channel is closed and a
 // CreateEnvironmentResponse is sent to the second channel.
@@ -30,7 +37,7 @@ func (cf CloudFormation) StreamEnvironmentCreation(env *deploy.CreateEnvironment
 	resp := make(chan deploy.CreateEnvironmentResponse, 1)
 
 	stack := stack.NewEnvStackConfig(env, cf.box)
-	go cf.streamResourceEvents(done, events, stack.StackName())
+	go cf.streamResourceEvents(done, events, stack.StackName(), time.Time{})
 	go cf.streamEnvironmentResponse(done, resp, stack)
 	return events, resp
 }
@@ -63,3 +63,8 @@ func (cf CloudFormation) UpdatePipeline(in *deploy.CreatePipelineInput) error {
 			StackName: aws.String(pipelineConfig.StackName()),
 		}, cf.waiters...)
 }
+
+// DiffPipeline returns the changes that deploying the pipeline would make to its stack without deploying it.
+func (cf CloudFormation) DiffPipeline(in *deploy.CreatePipelineInput) ([]deploy.ResourceChange, error) {
+	return cf.diffStack(stack.NewPipelineStackConfig(in))
+}
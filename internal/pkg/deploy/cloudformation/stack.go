@@ -40,3 +40,35 @@ func (cf CloudFormation) DeleteStackAndWait(stackName string) error {
 
 	return cf.WaitForStackDelete(stackName)
 }
+
+// StackTemplate returns the template that the stack was last deployed with.
+// If the stack doesn't exist, returns an ErrStackNotFound.
+func (cf CloudFormation) StackTemplate(stackName string) (string, error) {
+	out, err := cf.client.GetTemplate(&cloudformation.GetTemplateInput{
+		StackName:     aws.String(stackName),
+		TemplateStage: aws.String(cloudformation.TemplateStageOriginal),
+	})
+	if err != nil {
+		if stackDoesNotExist(err) {
+			return "", &ErrStackNotFound{stackName: stackName}
+		}
+		return "", fmt.Errorf("get template of stack %s: %w", stackName, err)
+	}
+	return aws.StringValue(out.TemplateBody), nil
+}
+
+// StackResourceID returns the physical ID of a resource of the stack from its logical ID.
+// If the stack doesn't exist, returns an ErrStackNotFound.
+func (cf CloudFormation) StackResourceID(stackName, logicalID string) (string, error) {
+	out, err := cf.client.DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String(stackName),
+		LogicalResourceId: aws.String(logicalID),
+	})
+	if err != nil {
+		if stackDoesNotExist(err) {
+			return "", &ErrStackNotFound{stackName: stackName}
+		}
+		return "", fmt.Errorf("describe resource %s of stack %s: %w", logicalID, stackName, err)
+	}
+	return aws.StringValue(out.StackResourceDetail.PhysicalResourceId), nil
+}
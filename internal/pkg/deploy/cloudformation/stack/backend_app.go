@@ -0,0 +1,190 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/templates"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/gobuffalo/packd"
+)
+
+const (
+	backendAppTemplatePath = "backend-service/cf.yml"
+	backendAppParamsPath   = "backend-service/params.json"
+)
+
+// BackendStackConfig represents the configuration needed to create a CloudFormation stack from a
+// backend AWS Fargate application.
+type BackendStackConfig struct {
+	*deploy.CreateBackendAppInput
+	box packd.Box
+}
+
+// NewBackendStack creates a new BackendStackConfig from a backend AWS Fargate application.
+func NewBackendStack(in *deploy.CreateBackendAppInput) *BackendStackConfig {
+	return &BackendStackConfig{
+		CreateBackendAppInput: in,
+		box:                   templates.Box(),
+	}
+}
+
+// StackName returns the name of the stack.
+func (c *BackendStackConfig) StackName() string {
+	return NameForApp(c.Env.Project, c.Env.Name, c.App.Name)
+}
+
+// Template returns the CloudFormation template for the application parametrized for the environment.
+func (c *BackendStackConfig) Template() (string, error) {
+	content, err := c.box.FindString(backendAppTemplatePath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: backendAppTemplatePath, parentErr: err}
+	}
+	tpl, err := template.New("template").Funcs(templateFunctions).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse CloudFormation template for %s: %w", c.App.Type, err)
+	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("execute CloudFormation template for %s: %w", c.App.Type, err)
+	}
+	return buf.String(), nil
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+func (c *BackendStackConfig) Parameters() []*cloudformation.Parameter {
+	conf := c.App.EnvConf(c.Env.Name)
+	return []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
+			ParameterValue: aws.String(c.Env.Project),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
+			ParameterValue: aws.String(c.Env.Name),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamAppNameKey),
+			ParameterValue: aws.String(c.App.Name),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
+			ParameterValue: aws.String(c.imageURL()),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerPortKey),
+			ParameterValue: aws.String(strconv.Itoa(c.App.Image.Port)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCPUKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.CPU)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Memory)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCountKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Count)),
+		},
+	}
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized
+// to a YAML document annotated with comments for readability to users.
+func (c *BackendStackConfig) SerializedParameters() (string, error) {
+	content, err := c.box.FindString(backendAppParamsPath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: backendAppParamsPath, parentErr: err}
+	}
+	tpl, err := template.New("template").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse stack configuration for %s: %w", c.App.Type, err)
+	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("execute stack configuration for %s: %w", c.App.Type, err)
+	}
+	return buf.String(), nil
+}
+
+// Tags returns the list of tags to apply to the CloudFormation stack.
+func (c *BackendStackConfig) Tags() []*cloudformation.Tag {
+	return []*cloudformation.Tag{
+		{
+			Key:   aws.String(ProjectTagKey),
+			Value: aws.String(c.Env.Project),
+		},
+		{
+			Key:   aws.String(EnvTagKey),
+			Value: aws.String(c.Env.Name),
+		},
+		{
+			Key:   aws.String(AppTagKey),
+			Value: aws.String(c.App.Name),
+		},
+	}
+}
+
+// backendTemplateParams holds the data to render the CloudFormation template for a backend application.
+type backendTemplateParams struct {
+	*deploy.CreateBackendAppInput
+
+	SecretsPolicy secretsPolicy
+
+	// Field types to override.
+	Image struct {
+		URL  string
+		Port int
+	}
+}
+
+func (c *BackendStackConfig) toTemplateParams() (*backendTemplateParams, error) {
+	conf := c.CreateBackendAppInput.App.EnvConf(c.Env.Name) // Get environment specific app configuration.
+	if err := manifest.ResolveEnvOutputs(&conf, c.EnvOutputs); err != nil {
+		return nil, fmt.Errorf("interpolate manifest of %s for environment %s: %w", c.App.Name, c.Env.Name, err)
+	}
+	return &backendTemplateParams{
+		CreateBackendAppInput: &deploy.CreateBackendAppInput{
+			App: &manifest.BackendAppManifest{
+				AppManifest:      c.App.AppManifest,
+				BackendAppConfig: conf,
+			},
+			Env: c.Env,
+		},
+		Image: struct {
+			URL  string
+			Port int
+		}{
+			URL:  c.imageURL(),
+			Port: c.App.Image.Port,
+		},
+		SecretsPolicy: appSecretsPolicy(conf.ContainersConfig, nil),
+	}, nil
+}
+
+func (c *BackendStackConfig) imageURL() string {
+	if c.App.Image.Location != "" {
+		return c.App.Image.Location
+	}
+	if c.ImageDigest != "" {
+		return fmt.Sprintf("%s@%s", c.ImageRepoURL, c.ImageDigest)
+	}
+	return fmt.Sprintf("%s:%s", c.ImageRepoURL, c.ImageTag)
+}
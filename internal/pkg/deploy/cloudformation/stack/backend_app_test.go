@@ -0,0 +1,146 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/gobuffalo/packd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendStackConfig_Template(t *testing.T) {
+	testCases := map[string]struct {
+		in *deploy.CreateBackendAppInput
+
+		mockBox func(box *packd.MemoryBox)
+
+		wantedTemplate string
+		wantedError    error
+	}{
+		"unavailable app template": {
+			mockBox:        func(box *packd.MemoryBox) {},
+			wantedTemplate: "",
+			wantedError: &ErrTemplateNotFound{
+				templateLocation: backendAppTemplatePath,
+				parentErr:        os.ErrNotExist,
+			},
+		},
+		"render default template": {
+			in: &deploy.CreateBackendAppInput{
+				App: manifest.NewBackendAppManifest("subscribers", "subscribers/Dockerfile", 8080),
+				Env: &archer.Environment{
+					Project:   "phonetool",
+					Name:      "test",
+					Region:    "us-west-2",
+					AccountID: "12345",
+				},
+				ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/subscribers",
+				ImageTag:     "manual-bf3678c",
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(backendAppTemplatePath, `Parameters:
+  ProjectName: {{.Env.Project}}
+  EnvName: {{.Env.Name}}
+  AppName: {{.App.Name}}
+  ContainerImage: {{.Image.URL}}
+  ContainerPort: {{.Image.Port}}
+  TaskCPU: '{{.App.CPU}}'
+  TaskMemory: '{{.App.Memory}}'
+  TaskCount: {{.App.Count}}`)
+			},
+
+			wantedTemplate: `Parameters:
+  ProjectName: phonetool
+  EnvName: test
+  AppName: subscribers
+  ContainerImage: 12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/subscribers:manual-bf3678c
+  ContainerPort: 8080
+  TaskCPU: '256'
+  TaskMemory: '512'
+  TaskCount: 1`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			box := packd.NewMemoryBox()
+			tc.mockBox(box)
+
+			conf := &BackendStackConfig{
+				CreateBackendAppInput: tc.in,
+				box:                   box,
+			}
+
+			// WHEN
+			template, err := conf.Template()
+
+			// THEN
+			require.True(t, errors.Is(err, tc.wantedError), "expected: %v, got: %v", tc.wantedError, err)
+			require.Equal(t, tc.wantedTemplate, template)
+		})
+	}
+}
+
+func TestBackendStackConfig_Parameters(t *testing.T) {
+	// GIVEN
+	conf := &BackendStackConfig{
+		CreateBackendAppInput: &deploy.CreateBackendAppInput{
+			App: manifest.NewBackendAppManifest("subscribers", "subscribers/Dockerfile", 8080),
+			Env: &archer.Environment{
+				Project: "phonetool",
+				Name:    "test",
+			},
+			ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/subscribers",
+			ImageTag:     "manual-bf3678c",
+		},
+	}
+
+	// WHEN
+	params := conf.Parameters()
+
+	// THEN
+	require.Equal(t, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
+			ParameterValue: aws.String("phonetool"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
+			ParameterValue: aws.String("test"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamAppNameKey),
+			ParameterValue: aws.String("subscribers"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
+			ParameterValue: aws.String("12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/subscribers:manual-bf3678c"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerPortKey),
+			ParameterValue: aws.String("8080"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCPUKey),
+			ParameterValue: aws.String("256"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
+			ParameterValue: aws.String("512"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCountKey),
+			ParameterValue: aws.String("1"),
+		},
+	}, params)
+}
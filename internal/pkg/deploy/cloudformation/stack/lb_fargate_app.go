@@ -5,8 +5,10 @@ package stack
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
@@ -37,6 +39,23 @@ const (
 	LBFargateTaskCountKey           = "TaskCount"
 )
 
+// Output logical IDs for a load balanced Fargate service.
+const (
+	LBFargateOutputRoutesKey = "Routes" // JSON list of the conditions of every listener rule forwarding to the service.
+)
+
+// Default values for the health check fields of a load balanced Fargate service that can be omitted.
+// The target group checks the application every 10 seconds and considers it healthy after 2 successes,
+// which is faster than the Elastic Load Balancing defaults of 30 seconds and 5 successes.
+const (
+	defaultHealthCheckInterval           = 10
+	defaultHealthCheckTimeout            = 5
+	defaultHealthCheckHealthyThreshold   = 2
+	defaultHealthCheckUnhealthyThreshold = 2
+	defaultHealthCheckSuccessCodes       = "200"
+	defaultHealthCheckGracePeriod        = 60
+)
+
 // LBFargateStackConfig represents the configuration needed to create a CloudFormation stack from a
 // load balanced Fargate application.
 type LBFargateStackConfig struct {
@@ -83,17 +102,21 @@ func (c *LBFargateStackConfig) Template() (string, error) {
 		return "", &ErrTemplateNotFound{templateLocation: lbFargateAppTemplatePath, parentErr: err}
 	}
 
-	tpl, err := template.New("template").Parse(content)
+	tpl, err := template.New("template").Funcs(templateFunctions).Parse(content)
 	if err != nil {
 		return "", fmt.Errorf("parse CloudFormation template for %s: %w", c.App.Type, err)
 	}
 
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
 	templateData := struct {
 		RulePriorityLambda string
 		*lbFargateTemplateParams
 	}{
 		RulePriorityLambda:      rulePriority,
-		lbFargateTemplateParams: c.toTemplateParams(),
+		lbFargateTemplateParams: params,
 	}
 
 	var buf bytes.Buffer
@@ -105,43 +128,43 @@ func (c *LBFargateStackConfig) Template() (string, error) {
 
 // Parameters returns the list of CloudFormation parameters used by the template.
 func (c *LBFargateStackConfig) Parameters() []*cloudformation.Parameter {
-	templateParams := c.toTemplateParams()
+	conf := c.App.EnvConf(c.Env.Name)
 	return []*cloudformation.Parameter{
 		{
 			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
-			ParameterValue: aws.String(templateParams.Env.Project),
+			ParameterValue: aws.String(c.Env.Project),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
-			ParameterValue: aws.String(templateParams.Env.Name),
+			ParameterValue: aws.String(c.Env.Name),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateParamAppNameKey),
-			ParameterValue: aws.String(templateParams.App.Name),
+			ParameterValue: aws.String(c.App.Name),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
-			ParameterValue: aws.String(templateParams.Image.URL),
+			ParameterValue: aws.String(c.imageURL()),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateParamContainerPortKey),
-			ParameterValue: aws.String(strconv.Itoa(templateParams.Image.Port)),
+			ParameterValue: aws.String(strconv.Itoa(c.App.Image.Port)),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateRulePathKey),
-			ParameterValue: aws.String(templateParams.App.Path),
+			ParameterValue: aws.String(conf.Path),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateTaskCPUKey),
-			ParameterValue: aws.String(strconv.Itoa(templateParams.App.CPU)),
+			ParameterValue: aws.String(strconv.Itoa(conf.CPU)),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
-			ParameterValue: aws.String(strconv.Itoa(templateParams.App.Memory)),
+			ParameterValue: aws.String(strconv.Itoa(conf.Memory)),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateTaskCountKey),
-			ParameterValue: aws.String(strconv.Itoa(templateParams.App.Count)),
+			ParameterValue: aws.String(strconv.Itoa(conf.Count)),
 		},
 		{
 			ParameterKey:   aws.String(LBFargateParamHTTPSKey),
@@ -161,8 +184,12 @@ func (c *LBFargateStackConfig) SerializedParameters() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("parse stack configuration for %s: %w", c.App.Type, err)
 	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
 	var buf bytes.Buffer
-	if err := tpl.Execute(&buf, c.toTemplateParams()); err != nil {
+	if err := tpl.Execute(&buf, params); err != nil {
 		return "", fmt.Errorf("execute stack configuration for %s: %w", c.App.Type, err)
 	}
 	return buf.String(), nil
@@ -190,7 +217,11 @@ func (c *LBFargateStackConfig) Tags() []*cloudformation.Tag {
 type lbFargateTemplateParams struct {
 	*deploy.CreateLBFargateAppInput
 
-	HTTPSEnabled string
+	HTTPSEnabled  string
+	ListenerRules []*listenerRule
+	HTTPRoutes    string // Serialized conditions of the HTTP listener rules.
+	HTTPSRoutes   string // Serialized conditions of the HTTPS listener rules.
+	SecretsPolicy secretsPolicy
 	// Field types to override.
 	Image struct {
 		URL  string
@@ -198,9 +229,18 @@ type lbFargateTemplateParams struct {
 	}
 }
 
-func (c *LBFargateStackConfig) toTemplateParams() *lbFargateTemplateParams {
+// listenerRule holds the conditions of a listener rule forwarding requests to the service.
+type listenerRule struct {
+	ID         string // Suffix of the logical IDs of the rule's resources.
+	PreviousID string // Suffix of the logical IDs of the rule created before this one.
+	First      bool
+
+	HTTP  manifest.Route
+	HTTPS manifest.Route // The hosts are left empty to match the application's default domains.
+}
+
+func (c *LBFargateStackConfig) toTemplateParams() (*lbFargateTemplateParams, error) {
 	db := &deploy.Database{}
-	url := fmt.Sprintf("%s:%s", c.ImageRepoURL, c.ImageTag)
 
 	if c.App.Database == nil {
 		c.App.Database = &manifest.DatabaseConfig{}
@@ -210,7 +250,7 @@ func (c *LBFargateStackConfig) toTemplateParams() *lbFargateTemplateParams {
 	if c.App.Variables["DB_NAME"] != "" {
 		db.Name = c.App.Variables["DB_NAME"]
 		db.Username = c.App.Variables["DB_USERNAME"]
-		db.Password = c.App.Secrets["DB_PASSWORD"]
+		db.Password = c.App.Secrets[manifest.DatabasePasswordSecret].From
 
 		switch c.App.Database.Engine {
 		case "mysql":
@@ -219,29 +259,121 @@ func (c *LBFargateStackConfig) toTemplateParams() *lbFargateTemplateParams {
 			db.Engine = "aurora-postgresql"
 		}
 
-		c.App.Secrets["DB_PASSWORD"] = fmt.Sprintf("!Sub arn:aws:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:%s",
-			c.App.Secrets["DB_PASSWORD"])
-
 		delete(c.App.Variables, "DB_HOST")
 		delete(c.App.Variables, "DB_PORT")
 	}
 
+	conf := c.CreateLBFargateAppInput.App.EnvConf(c.Env.Name) // Get environment specific app configuration.
+	if err := manifest.ResolveEnvOutputs(&conf, c.EnvOutputs); err != nil {
+		return nil, fmt.Errorf("interpolate manifest of %s for environment %s: %w", c.App.Name, c.Env.Name, err)
+	}
+	conf.HealthCheck = healthCheckWithDefaults(conf.HealthCheck)
+	rules := listenerRules(conf.RoutingRule)
+	var httpRoutes, httpsRoutes []manifest.Route
+	for _, rule := range rules {
+		httpRoutes = append(httpRoutes, rule.HTTP)
+		httpsRoutes = append(httpsRoutes, rule.HTTPS)
+	}
+	serializedHTTPRoutes, err := serializeRoutes(httpRoutes)
+	if err != nil {
+		return nil, err
+	}
+	serializedHTTPSRoutes, err := serializeRoutes(httpsRoutes)
+	if err != nil {
+		return nil, err
+	}
 	return &lbFargateTemplateParams{
 		CreateLBFargateAppInput: &deploy.CreateLBFargateAppInput{
 			App: &manifest.LBFargateManifest{
 				AppManifest:     c.App.AppManifest,
-				LBFargateConfig: c.CreateLBFargateAppInput.App.EnvConf(c.Env.Name), // Get environment specific app configuration.
+				LBFargateConfig: conf,
 			},
 			Database: db,
 			Env:      c.Env,
 		},
-		HTTPSEnabled: strconv.FormatBool(c.httpsEnabled),
+		HTTPSEnabled:  strconv.FormatBool(c.httpsEnabled),
+		ListenerRules: rules,
+		HTTPRoutes:    serializedHTTPRoutes,
+		HTTPSRoutes:   serializedHTTPSRoutes,
+		SecretsPolicy: appSecretsPolicy(conf.ContainersConfig, conf.Sidecars),
 		Image: struct {
 			URL  string
 			Port int
 		}{
-			URL:  url,
+			URL:  c.imageURL(),
 			Port: c.App.Image.Port,
 		},
+	}, nil
+}
+
+// imageURL returns the pre-built image location from the manifest if there is one, otherwise the pushed image.
+func (c *LBFargateStackConfig) imageURL() string {
+	if c.App.Image.Location != "" {
+		return c.App.Image.Location
+	}
+	if c.ImageDigest != "" {
+		return fmt.Sprintf("%s@%s", c.ImageRepoURL, c.ImageDigest)
+	}
+	return fmt.Sprintf("%s:%s", c.ImageRepoURL, c.ImageTag)
+}
+
+// healthCheckWithDefaults returns the health check with defaults for the omitted target group and service fields.
+func healthCheckWithDefaults(hc manifest.HealthCheck) manifest.HealthCheck {
+	if hc.Interval == 0 {
+		hc.Interval = defaultHealthCheckInterval
+	}
+	if hc.Timeout == 0 {
+		hc.Timeout = defaultHealthCheckTimeout
+	}
+	if hc.HealthyThreshold == 0 {
+		hc.HealthyThreshold = defaultHealthCheckHealthyThreshold
+	}
+	if hc.UnhealthyThreshold == 0 {
+		hc.UnhealthyThreshold = defaultHealthCheckUnhealthyThreshold
+	}
+	if hc.SuccessCodes == "" {
+		hc.SuccessCodes = defaultHealthCheckSuccessCodes
+	}
+	if hc.GracePeriod == 0 {
+		hc.GracePeriod = defaultHealthCheckGracePeriod
+	}
+	return hc
+}
+
+// listenerRules returns a listener rule for each route to the service.
+// The rules are created one after the other so that the custom resource allocating their priority
+// doesn't return the same priority twice. The first rule keeps the logical IDs of stacks created before
+// routes were introduced so that it isn't replaced.
+func listenerRules(r manifest.RoutingRule) []*listenerRule {
+	routes := r.Routes()
+	if len(routes) == 0 {
+		routes = []manifest.Route{{}}
+	}
+	var rules []*listenerRule
+	for i, route := range routes {
+		rule := &listenerRule{
+			First: i == 0,
+			HTTP:  route,
+			HTTPS: route,
+		}
+		if i > 0 {
+			rule.ID = strconv.Itoa(i + 1)
+			rule.PreviousID = rules[i-1].ID
+		}
+		if i == 0 && r.Path != "" {
+			// On HTTPS, the application is served on its own domain so the default path isn't needed.
+			rule.HTTPS.Paths = nil
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// serializeRoutes returns the routes as a JSON document quoted for a single-quoted YAML string.
+func serializeRoutes(routes []manifest.Route) (string, error) {
+	out, err := json.Marshal(routes)
+	if err != nil {
+		return "", fmt.Errorf("marshal routes: %w", err)
 	}
+	return strings.ReplaceAll(string(out), "'", "''"), nil
 }
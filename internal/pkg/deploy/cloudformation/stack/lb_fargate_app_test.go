@@ -158,25 +158,40 @@ func TestLBFargateStackConfig_Template(t *testing.T) {
 
 func TestLBFargateStackConfig_Parameters(t *testing.T) {
 	testCases := map[string]struct {
-		httpsEnabled bool
-		expectedHTTP string
+		httpsEnabled  bool
+		imageLocation string
+		expectedHTTP  string
+		expectedImage string
 	}{
 		"HTTPS Enabled": {
-			httpsEnabled: true,
-			expectedHTTP: "true",
+			httpsEnabled:  true,
+			expectedHTTP:  "true",
+			expectedImage: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:manual-bf3678c",
 		},
 		"HTTPS Not Enabled": {
-			httpsEnabled: false,
-			expectedHTTP: "false",
+			httpsEnabled:  false,
+			expectedHTTP:  "false",
+			expectedImage: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:manual-bf3678c",
+		},
+		"Pre-built image": {
+			httpsEnabled:  false,
+			imageLocation: "nginx:1.17",
+			expectedHTTP:  "false",
+			expectedImage: "nginx:1.17",
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 
 			// GIVEN
+			mft := manifest.NewLoadBalancedFargateManifest("frontend", "frontend/Dockerfile", 80)
+			if tc.imageLocation != "" {
+				mft.Image.Build = manifest.BuildArgsOrString{}
+				mft.Image.Location = tc.imageLocation
+			}
 			conf := &LBFargateStackConfig{
 				CreateLBFargateAppInput: &deploy.CreateLBFargateAppInput{
-					App: manifest.NewLoadBalancedFargateManifest("frontend", "frontend/Dockerfile", 80),
+					App: mft,
 					Env: &archer.Environment{
 						Project:   "phonetool",
 						Name:      "test",
@@ -209,7 +224,7 @@ func TestLBFargateStackConfig_Parameters(t *testing.T) {
 				},
 				{
 					ParameterKey:   aws.String(LBFargateParamContainerImageKey),
-					ParameterValue: aws.String("12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:manual-bf3678c"),
+					ParameterValue: aws.String(tc.expectedImage),
 				},
 				{
 					ParameterKey:   aws.String(LBFargateParamContainerPortKey),
@@ -353,6 +368,54 @@ func TestLBFargateStackConfig_SerializedParameters(t *testing.T) {
   }
 }`,
 		},
+		"resolve environment outputs": {
+			in: &LBFargateStackConfig{
+				CreateLBFargateAppInput: &deploy.CreateLBFargateAppInput{
+					App: func() *manifest.LBFargateManifest {
+						mft := manifest.NewLoadBalancedFargateManifest("frontend", "frontend/Dockerfile", 80)
+						mft.Variables = map[string]string{
+							"SUBNETS": "${env.outputs.PrivateSubnets}",
+						}
+						return mft
+					}(),
+					Env: &archer.Environment{
+						Project: "phonetool",
+						Name:    "test",
+					},
+					EnvOutputs: map[string]string{
+						"PrivateSubnets": "subnet-1,subnet-2",
+					},
+				},
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(lbFargateAppParamsPath, `{{index .App.Variables "SUBNETS"}}`)
+			},
+			wantedParams: "subnet-1,subnet-2",
+		},
+		"unknown environment output": {
+			in: &LBFargateStackConfig{
+				CreateLBFargateAppInput: &deploy.CreateLBFargateAppInput{
+					App: func() *manifest.LBFargateManifest {
+						mft := manifest.NewLoadBalancedFargateManifest("frontend", "frontend/Dockerfile", 80)
+						mft.Variables = map[string]string{
+							"SUBNETS": "${env.outputs.PrivateSubnets}",
+						}
+						return mft
+					}(),
+					Env: &archer.Environment{
+						Project: "phonetool",
+						Name:    "test",
+					},
+				},
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(lbFargateAppParamsPath, `{{index .App.Variables "SUBNETS"}}`)
+			},
+			wantedError: &manifest.ErrUnresolvedReference{
+				Key:       "variables.SUBNETS",
+				Reference: "${env.outputs.PrivateSubnets}",
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -372,6 +435,106 @@ func TestLBFargateStackConfig_SerializedParameters(t *testing.T) {
 	}
 }
 
+func TestHealthCheckWithDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.HealthCheck
+		wanted manifest.HealthCheck
+	}{
+		"fills omitted fields": {
+			in: manifest.HealthCheck{Path: "/"},
+			wanted: manifest.HealthCheck{
+				Path:               "/",
+				HealthyThreshold:   defaultHealthCheckHealthyThreshold,
+				UnhealthyThreshold: defaultHealthCheckUnhealthyThreshold,
+				Interval:           defaultHealthCheckInterval,
+				Timeout:            defaultHealthCheckTimeout,
+				SuccessCodes:       defaultHealthCheckSuccessCodes,
+				GracePeriod:        defaultHealthCheckGracePeriod,
+			},
+		},
+		"keeps fields set in the manifest": {
+			in: manifest.HealthCheck{
+				Path:               "/health",
+				HealthyThreshold:   3,
+				UnhealthyThreshold: 5,
+				Interval:           30,
+				Timeout:            10,
+				SuccessCodes:       "200-299",
+				GracePeriod:        300,
+			},
+			wanted: manifest.HealthCheck{
+				Path:               "/health",
+				HealthyThreshold:   3,
+				UnhealthyThreshold: 5,
+				Interval:           30,
+				Timeout:            10,
+				SuccessCodes:       "200-299",
+				GracePeriod:        300,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, healthCheckWithDefaults(tc.in))
+		})
+	}
+}
+
+func TestListenerRules(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.RoutingRule
+		wanted []*listenerRule
+	}{
+		"no routes": {
+			wanted: []*listenerRule{
+				{First: true},
+			},
+		},
+		"path": {
+			in: manifest.RoutingRule{Path: "*"},
+			wanted: []*listenerRule{
+				{
+					First: true,
+					HTTP:  manifest.Route{Paths: []string{"*"}},
+				},
+			},
+		},
+		"path and rules": {
+			in: manifest.RoutingRule{
+				Path: "/api/*",
+				Rules: []manifest.Route{
+					{Paths: []string{"/v2/*"}},
+					{Hosts: []string{"api.example.com"}},
+				},
+			},
+			wanted: []*listenerRule{
+				{
+					First: true,
+					HTTP:  manifest.Route{Paths: []string{"/api/*"}},
+				},
+				{
+					ID:    "2",
+					HTTP:  manifest.Route{Paths: []string{"/v2/*"}},
+					HTTPS: manifest.Route{Paths: []string{"/v2/*"}},
+				},
+				{
+					ID:         "3",
+					PreviousID: "2",
+					HTTP:       manifest.Route{Hosts: []string{"api.example.com"}},
+					HTTPS:      manifest.Route{Hosts: []string{"api.example.com"}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, listenerRules(tc.in))
+		})
+	}
+}
+
 func TestLBFargateStackConfig_Tags(t *testing.T) {
 	// GIVEN
 	conf := &LBFargateStackConfig{
@@ -407,3 +570,49 @@ func TestLBFargateStackConfig_Tags(t *testing.T) {
 		},
 	}, tags)
 }
+
+func TestLBFargateStackConfig_imageURL(t *testing.T) {
+	testCases := map[string]struct {
+		inLocation string
+		inDigest   string
+
+		wantedURL string
+	}{
+		"pushed image by tag": {
+			wantedURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:manual-bf3678c",
+		},
+		"pushed image by digest": {
+			inDigest: "sha256:abc",
+
+			wantedURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend@sha256:abc",
+		},
+		"pre-built image": {
+			inLocation: "nginx:1.17",
+			inDigest:   "sha256:abc",
+
+			wantedURL: "nginx:1.17",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mft := manifest.NewLoadBalancedFargateManifest("frontend", "frontend/Dockerfile", 80)
+			mft.Image.Location = tc.inLocation
+			conf := &LBFargateStackConfig{
+				CreateLBFargateAppInput: &deploy.CreateLBFargateAppInput{
+					App:          mft,
+					ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend",
+					ImageTag:     "manual-bf3678c",
+					ImageDigest:  tc.inDigest,
+				},
+			}
+
+			// WHEN
+			url := conf.imageURL()
+
+			// THEN
+			require.Equal(t, tc.wantedURL, url)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/templates"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/gobuffalo/packd"
+)
+
+const (
+	scheduledJobTemplatePath = "scheduled-job/cf.yml"
+	scheduledJobParamsPath   = "scheduled-job/params.json"
+)
+
+// Parameter logical IDs for a scheduled job.
+const (
+	ScheduledJobScheduleKey = "Schedule"
+)
+
+// ScheduledJobStackConfig represents the configuration needed to create a CloudFormation stack from a
+// scheduled AWS Fargate job.
+type ScheduledJobStackConfig struct {
+	*deploy.CreateScheduledJobInput
+	box packd.Box
+}
+
+// NewScheduledJobStack creates a new ScheduledJobStackConfig from a scheduled AWS Fargate job.
+func NewScheduledJobStack(in *deploy.CreateScheduledJobInput) *ScheduledJobStackConfig {
+	return &ScheduledJobStackConfig{
+		CreateScheduledJobInput: in,
+		box:                     templates.Box(),
+	}
+}
+
+// StackName returns the name of the stack.
+func (c *ScheduledJobStackConfig) StackName() string {
+	return NameForApp(c.Env.Project, c.Env.Name, c.App.Name)
+}
+
+// Template returns the CloudFormation template for the job parametrized for the environment.
+func (c *ScheduledJobStackConfig) Template() (string, error) {
+	content, err := c.box.FindString(scheduledJobTemplatePath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: scheduledJobTemplatePath, parentErr: err}
+	}
+	tpl, err := template.New("template").Funcs(templateFunctions).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse CloudFormation template for %s: %w", c.App.Type, err)
+	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("execute CloudFormation template for %s: %w", c.App.Type, err)
+	}
+	return buf.String(), nil
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+func (c *ScheduledJobStackConfig) Parameters() []*cloudformation.Parameter {
+	conf := c.App.EnvConf(c.Env.Name)
+	return []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
+			ParameterValue: aws.String(c.Env.Project),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
+			ParameterValue: aws.String(c.Env.Name),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamAppNameKey),
+			ParameterValue: aws.String(c.App.Name),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
+			ParameterValue: aws.String(c.imageURL()),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCPUKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.CPU)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Memory)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCountKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Count)),
+		},
+		{
+			ParameterKey:   aws.String(ScheduledJobScheduleKey),
+			ParameterValue: aws.String(conf.Schedule),
+		},
+	}
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized
+// to a YAML document annotated with comments for readability to users.
+func (c *ScheduledJobStackConfig) SerializedParameters() (string, error) {
+	content, err := c.box.FindString(scheduledJobParamsPath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: scheduledJobParamsPath, parentErr: err}
+	}
+	tpl, err := template.New("template").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse stack configuration for %s: %w", c.App.Type, err)
+	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("execute stack configuration for %s: %w", c.App.Type, err)
+	}
+	return buf.String(), nil
+}
+
+// Tags returns the list of tags to apply to the CloudFormation stack.
+func (c *ScheduledJobStackConfig) Tags() []*cloudformation.Tag {
+	return []*cloudformation.Tag{
+		{
+			Key:   aws.String(ProjectTagKey),
+			Value: aws.String(c.Env.Project),
+		},
+		{
+			Key:   aws.String(EnvTagKey),
+			Value: aws.String(c.Env.Name),
+		},
+		{
+			Key:   aws.String(AppTagKey),
+			Value: aws.String(c.App.Name),
+		},
+	}
+}
+
+// scheduledJobTemplateParams holds the data to render the CloudFormation template for a scheduled job.
+type scheduledJobTemplateParams struct {
+	*deploy.CreateScheduledJobInput
+
+	// Field types to override.
+	Image struct {
+		URL string
+	}
+
+	TimeoutSeconds int
+	SecretsPolicy  secretsPolicy
+}
+
+func (c *ScheduledJobStackConfig) toTemplateParams() (*scheduledJobTemplateParams, error) {
+	conf := c.App.EnvConf(c.Env.Name) // Get environment specific job configuration.
+	if err := manifest.ResolveEnvOutputs(&conf, c.EnvOutputs); err != nil {
+		return nil, fmt.Errorf("interpolate manifest of %s for environment %s: %w", c.App.Name, c.Env.Name, err)
+	}
+	timeout, err := conf.TimeoutSeconds()
+	if err != nil {
+		return nil, fmt.Errorf("get timeout for job %s: %w", c.App.Name, err)
+	}
+	return &scheduledJobTemplateParams{
+		CreateScheduledJobInput: &deploy.CreateScheduledJobInput{
+			App: &manifest.ScheduledJobManifest{
+				AppManifest:        c.App.AppManifest,
+				ScheduledJobConfig: conf,
+			},
+			Env: c.Env,
+		},
+		Image: struct {
+			URL string
+		}{
+			URL: c.imageURL(),
+		},
+		TimeoutSeconds: timeout,
+		SecretsPolicy:  appSecretsPolicy(conf.ContainersConfig, nil),
+	}, nil
+}
+
+func (c *ScheduledJobStackConfig) imageURL() string {
+	if c.App.Image.Location != "" {
+		return c.App.Image.Location
+	}
+	if c.ImageDigest != "" {
+		return fmt.Sprintf("%s@%s", c.ImageRepoURL, c.ImageDigest)
+	}
+	return fmt.Sprintf("%s:%s", c.ImageRepoURL, c.ImageTag)
+}
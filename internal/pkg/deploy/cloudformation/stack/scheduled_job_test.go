@@ -0,0 +1,166 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/gobuffalo/packd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledJobStackConfig_Template(t *testing.T) {
+	testEnv := &archer.Environment{
+		Project:   "phonetool",
+		Name:      "test",
+		Region:    "us-west-2",
+		AccountID: "12345",
+	}
+	testCases := map[string]struct {
+		in *deploy.CreateScheduledJobInput
+
+		mockBox func(box *packd.MemoryBox)
+
+		wantedTemplate string
+		wantedError    error
+	}{
+		"unavailable job template": {
+			mockBox:        func(box *packd.MemoryBox) {},
+			wantedTemplate: "",
+			wantedError: &ErrTemplateNotFound{
+				templateLocation: scheduledJobTemplatePath,
+				parentErr:        os.ErrNotExist,
+			},
+		},
+		"invalid timeout": {
+			in: &deploy.CreateScheduledJobInput{
+				App: func() *manifest.ScheduledJobManifest {
+					m := manifest.NewScheduledJobManifest("reports", "reports/Dockerfile")
+					m.Timeout = "2d"
+					return m
+				}(),
+				Env: testEnv,
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(scheduledJobTemplatePath, "Schedule: {{.App.Schedule}}")
+			},
+			wantedTemplate: "",
+			wantedError:    &manifest.ErrInvalidJobTimeout{Timeout: "2d"},
+		},
+		"render template with environment overrides": {
+			in: &deploy.CreateScheduledJobInput{
+				App: func() *manifest.ScheduledJobManifest {
+					m := manifest.NewScheduledJobManifest("reports", "reports/Dockerfile")
+					m.Retries = 2
+					m.Environments = map[string]manifest.ScheduledJobConfig{
+						"test": {
+							Schedule: "rate(1 hour)",
+							Timeout:  "10m",
+						},
+					}
+					return m
+				}(),
+				Env:          testEnv,
+				ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/reports",
+				ImageTag:     "manual-bf3678c",
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(scheduledJobTemplatePath, `Parameters:
+  ContainerImage: {{.Image.URL}}
+  Schedule: '{{.App.Schedule}}'
+RetryPolicy:
+  MaximumRetryAttempts: {{.App.Retries}}
+  MaximumEventAgeInSeconds: {{.TimeoutSeconds}}`)
+			},
+
+			wantedTemplate: `Parameters:
+  ContainerImage: 12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/reports:manual-bf3678c
+  Schedule: 'rate(1 hour)'
+RetryPolicy:
+  MaximumRetryAttempts: 2
+  MaximumEventAgeInSeconds: 600`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			box := packd.NewMemoryBox()
+			tc.mockBox(box)
+
+			conf := &ScheduledJobStackConfig{
+				CreateScheduledJobInput: tc.in,
+				box:                     box,
+			}
+
+			// WHEN
+			template, err := conf.Template()
+
+			// THEN
+			require.True(t, errors.Is(err, tc.wantedError), "expected: %v, got: %v", tc.wantedError, err)
+			require.Equal(t, tc.wantedTemplate, template)
+		})
+	}
+}
+
+func TestScheduledJobStackConfig_Parameters(t *testing.T) {
+	// GIVEN
+	conf := &ScheduledJobStackConfig{
+		CreateScheduledJobInput: &deploy.CreateScheduledJobInput{
+			App: manifest.NewScheduledJobManifest("reports", "reports/Dockerfile"),
+			Env: &archer.Environment{
+				Project: "phonetool",
+				Name:    "test",
+			},
+			ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/reports",
+			ImageTag:     "manual-bf3678c",
+		},
+	}
+
+	// WHEN
+	params := conf.Parameters()
+
+	// THEN
+	require.Equal(t, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
+			ParameterValue: aws.String("phonetool"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
+			ParameterValue: aws.String("test"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamAppNameKey),
+			ParameterValue: aws.String("reports"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
+			ParameterValue: aws.String("12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/reports:manual-bf3678c"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCPUKey),
+			ParameterValue: aws.String("256"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
+			ParameterValue: aws.String("512"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCountKey),
+			ParameterValue: aws.String("1"),
+		},
+		{
+			ParameterKey:   aws.String(ScheduledJobScheduleKey),
+			ParameterValue: aws.String("rate(1 day)"),
+		},
+	}, params)
+}
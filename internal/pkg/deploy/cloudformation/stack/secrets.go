@@ -0,0 +1,89 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+)
+
+const (
+	fmtSSMParameterARN         = "!Sub 'arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/%s'"
+	fmtSecretsManagerSecretARN = "!Sub 'arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:%s'"
+
+	// Secrets Manager appends 6 random characters to the ARN of a secret.
+	secretsManagerARNSuffixWildcard = "-??????"
+)
+
+// secretsPolicy holds the secrets that the task execution role reads to inject them into the containers, by backend.
+type secretsPolicy struct {
+	SSMParameters         []string // ARNs of the SSM parameters.
+	SecretsManagerSecrets []string // ARNs of the Secrets Manager secrets.
+}
+
+// newSecretsPolicy returns the policy granting access to exactly the secrets of the containers.
+func newSecretsPolicy(containerSecrets ...map[string]manifest.Secret) secretsPolicy {
+	ssmParams := make(map[string]bool)
+	smSecrets := make(map[string]bool)
+	for _, secrets := range containerSecrets {
+		for _, secret := range secrets {
+			switch secret.StoredIn() {
+			case manifest.SecretBackendSecretsManager:
+				if isARN(secret.From) {
+					smSecrets[secret.From] = true
+					continue
+				}
+				smSecrets[fmt.Sprintf(fmtSecretsManagerSecretARN, secret.From+secretsManagerARNSuffixWildcard)] = true
+			default:
+				ssmParams[secretValueFrom(secret)] = true
+			}
+		}
+	}
+	return secretsPolicy{
+		SSMParameters:         sortedKeys(ssmParams),
+		SecretsManagerSecrets: sortedKeys(smSecrets),
+	}
+}
+
+// IsEmpty returns true if the containers have no secrets, so that the policy is omitted.
+func (p secretsPolicy) IsEmpty() bool {
+	return len(p.SSMParameters) == 0 && len(p.SecretsManagerSecrets) == 0
+}
+
+// appSecretsPolicy returns the policy for the secrets of the application's container and of its sidecars.
+func appSecretsPolicy(conf manifest.ContainersConfig, sidecars map[string]*manifest.SidecarConfig) secretsPolicy {
+	secrets := []map[string]manifest.Secret{conf.Secrets}
+	for _, sidecar := range sidecars {
+		secrets = append(secrets, sidecar.Secrets)
+	}
+	return newSecretsPolicy(secrets...)
+}
+
+// secretValueFrom returns the ARN of the secret to set in the "ValueFrom" field of a container definition.
+func secretValueFrom(secret manifest.Secret) string {
+	if isARN(secret.From) {
+		return secret.From
+	}
+	if secret.StoredIn() == manifest.SecretBackendSecretsManager {
+		return fmt.Sprintf(fmtSecretsManagerSecretARN, secret.From)
+	}
+	// The ARN of a parameter doesn't repeat the leading "/" of its name.
+	return fmt.Sprintf(fmtSSMParameterARN, strings.TrimPrefix(secret.From, "/"))
+}
+
+func isARN(s string) bool {
+	return strings.HasPrefix(s, "arn:")
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecretsPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		inSecrets []map[string]manifest.Secret
+
+		wantedPolicy secretsPolicy
+	}{
+		"no secrets": {
+			wantedPolicy: secretsPolicy{},
+		},
+		"secrets in both backends": {
+			inSecrets: []map[string]manifest.Secret{
+				{
+					"API_KEY":     {From: "/ecs-cli-v2/phonetool/applications/api/secrets/api-key"},
+					"DB_PASSWORD": {From: "phonetool-api-database", Backend: manifest.SecretBackendSecretsManager},
+				},
+				{
+					"API_KEY": {From: "/ecs-cli-v2/phonetool/applications/api/secrets/api-key", Backend: manifest.SecretBackendSSM},
+					"TOKEN":   {From: "arn:aws:secretsmanager:us-west-2:1234:secret:token-AbCdEf", Backend: manifest.SecretBackendSecretsManager},
+				},
+			},
+			wantedPolicy: secretsPolicy{
+				SSMParameters: []string{
+					"!Sub 'arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/ecs-cli-v2/phonetool/applications/api/secrets/api-key'",
+				},
+				SecretsManagerSecrets: []string{
+					"!Sub 'arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:phonetool-api-database-??????'",
+					"arn:aws:secretsmanager:us-west-2:1234:secret:token-AbCdEf",
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			policy := newSecretsPolicy(tc.inSecrets...)
+
+			// THEN
+			require.Equal(t, tc.wantedPolicy, policy)
+			require.Equal(t, len(tc.inSecrets) == 0, policy.IsEmpty())
+		})
+	}
+}
+
+func TestSecretValueFrom(t *testing.T) {
+	testCases := map[string]struct {
+		inSecret manifest.Secret
+
+		wantedValueFrom string
+	}{
+		"ssm parameter": {
+			inSecret:        manifest.Secret{From: "/ecs-cli-v2/phonetool/applications/api/secrets/api-key"},
+			wantedValueFrom: "!Sub 'arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/ecs-cli-v2/phonetool/applications/api/secrets/api-key'",
+		},
+		"secrets manager secret": {
+			inSecret:        manifest.Secret{From: "phonetool-api-database", Backend: manifest.SecretBackendSecretsManager},
+			wantedValueFrom: "!Sub 'arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:phonetool-api-database'",
+		},
+		"arn": {
+			inSecret:        manifest.Secret{From: "arn:aws:ssm:us-west-2:1234:parameter/api-key"},
+			wantedValueFrom: "arn:aws:ssm:us-west-2:1234:parameter/api-key",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wantedValueFrom, secretValueFrom(tc.inSecret))
+		})
+	}
+}
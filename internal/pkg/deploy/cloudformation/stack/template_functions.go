@@ -10,7 +10,8 @@ const (
 )
 
 var templateFunctions = map[string]interface{}{
-	"logicalIDSafe": logicalIDSafe,
+	"logicalIDSafe":   logicalIDSafe,
+	"secretValueFrom": secretValueFrom,
 }
 
 // logicalIDSafe takes a CloudFormation logical ID, and
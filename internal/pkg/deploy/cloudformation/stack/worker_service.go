@@ -0,0 +1,228 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/templates"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/gobuffalo/packd"
+)
+
+const (
+	workerServiceTemplatePath = "worker-service/cf.yml"
+	workerServiceParamsPath   = "worker-service/params.json"
+)
+
+// Parameter logical IDs for a worker service.
+const (
+	WorkerQueueVisibilityTimeoutKey = "QueueVisibilityTimeout"
+	WorkerQueueMaxReceiveCountKey   = "QueueMaxReceiveCount"
+)
+
+// Default values for the fields of a worker service manifest that can be omitted.
+const (
+	defaultWorkerQueueVisibilityTimeout = 30
+	defaultWorkerQueueMaxReceiveCount   = 3
+	defaultWorkerTargetBacklog          = 100
+)
+
+// WorkerServiceStackConfig represents the configuration needed to create a CloudFormation stack from a
+// queue-driven AWS Fargate service.
+type WorkerServiceStackConfig struct {
+	*deploy.CreateWorkerServiceInput
+	box packd.Box
+}
+
+// NewWorkerServiceStack creates a new WorkerServiceStackConfig from a queue-driven AWS Fargate service.
+func NewWorkerServiceStack(in *deploy.CreateWorkerServiceInput) *WorkerServiceStackConfig {
+	return &WorkerServiceStackConfig{
+		CreateWorkerServiceInput: in,
+		box:                      templates.Box(),
+	}
+}
+
+// StackName returns the name of the stack.
+func (c *WorkerServiceStackConfig) StackName() string {
+	return NameForApp(c.Env.Project, c.Env.Name, c.App.Name)
+}
+
+// Template returns the CloudFormation template for the service parametrized for the environment.
+func (c *WorkerServiceStackConfig) Template() (string, error) {
+	content, err := c.box.FindString(workerServiceTemplatePath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: workerServiceTemplatePath, parentErr: err}
+	}
+	tpl, err := template.New("template").Funcs(templateFunctions).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse CloudFormation template for %s: %w", c.App.Type, err)
+	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("execute CloudFormation template for %s: %w", c.App.Type, err)
+	}
+	return buf.String(), nil
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+func (c *WorkerServiceStackConfig) Parameters() []*cloudformation.Parameter {
+	conf := c.envConf()
+	return []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
+			ParameterValue: aws.String(c.Env.Project),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
+			ParameterValue: aws.String(c.Env.Name),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamAppNameKey),
+			ParameterValue: aws.String(c.App.Name),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
+			ParameterValue: aws.String(c.imageURL()),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCPUKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.CPU)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Memory)),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCountKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Count)),
+		},
+		{
+			ParameterKey:   aws.String(WorkerQueueVisibilityTimeoutKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Queue.VisibilityTimeout)),
+		},
+		{
+			ParameterKey:   aws.String(WorkerQueueMaxReceiveCountKey),
+			ParameterValue: aws.String(strconv.Itoa(conf.Queue.MaxReceiveCount)),
+		},
+	}
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized
+// to a YAML document annotated with comments for readability to users.
+func (c *WorkerServiceStackConfig) SerializedParameters() (string, error) {
+	content, err := c.box.FindString(workerServiceParamsPath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: workerServiceParamsPath, parentErr: err}
+	}
+	tpl, err := template.New("template").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse stack configuration for %s: %w", c.App.Type, err)
+	}
+	params, err := c.toTemplateParams()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("execute stack configuration for %s: %w", c.App.Type, err)
+	}
+	return buf.String(), nil
+}
+
+// Tags returns the list of tags to apply to the CloudFormation stack.
+func (c *WorkerServiceStackConfig) Tags() []*cloudformation.Tag {
+	return []*cloudformation.Tag{
+		{
+			Key:   aws.String(ProjectTagKey),
+			Value: aws.String(c.Env.Project),
+		},
+		{
+			Key:   aws.String(EnvTagKey),
+			Value: aws.String(c.Env.Name),
+		},
+		{
+			Key:   aws.String(AppTagKey),
+			Value: aws.String(c.App.Name),
+		},
+	}
+}
+
+// workerServiceTemplateParams holds the data to render the CloudFormation template for a worker service.
+type workerServiceTemplateParams struct {
+	*deploy.CreateWorkerServiceInput
+
+	SecretsPolicy secretsPolicy
+
+	// Field types to override.
+	Image struct {
+		URL string
+	}
+}
+
+func (c *WorkerServiceStackConfig) toTemplateParams() (*workerServiceTemplateParams, error) {
+	conf := c.envConf()
+	if err := manifest.ResolveEnvOutputs(&conf, c.EnvOutputs); err != nil {
+		return nil, fmt.Errorf("interpolate manifest of %s for environment %s: %w", c.App.Name, c.Env.Name, err)
+	}
+	if s := conf.Scaling; s != nil {
+		if s.MaxCount < 1 || s.MaxCount < s.MinCount {
+			return nil, fmt.Errorf("scaling for %s: maxCount %d must be at least 1 and greater than or equal to minCount %d",
+				c.App.Name, s.MaxCount, s.MinCount)
+		}
+	}
+	return &workerServiceTemplateParams{
+		CreateWorkerServiceInput: &deploy.CreateWorkerServiceInput{
+			App: &manifest.WorkerServiceManifest{
+				AppManifest:         c.App.AppManifest,
+				WorkerServiceConfig: conf,
+			},
+			Env: c.Env,
+		},
+		Image: struct {
+			URL string
+		}{
+			URL: c.imageURL(),
+		},
+		SecretsPolicy: appSecretsPolicy(conf.ContainersConfig, nil),
+	}, nil
+}
+
+func (c *WorkerServiceStackConfig) imageURL() string {
+	if c.App.Image.Location != "" {
+		return c.App.Image.Location
+	}
+	if c.ImageDigest != "" {
+		return fmt.Sprintf("%s@%s", c.ImageRepoURL, c.ImageDigest)
+	}
+	return fmt.Sprintf("%s:%s", c.ImageRepoURL, c.ImageTag)
+}
+
+// envConf returns the environment specific service configuration with defaults for the omitted queue and scaling fields.
+func (c *WorkerServiceStackConfig) envConf() manifest.WorkerServiceConfig {
+	conf := c.App.EnvConf(c.Env.Name)
+	if conf.Queue.VisibilityTimeout == 0 {
+		conf.Queue.VisibilityTimeout = defaultWorkerQueueVisibilityTimeout
+	}
+	if conf.Queue.MaxReceiveCount == 0 {
+		conf.Queue.MaxReceiveCount = defaultWorkerQueueMaxReceiveCount
+	}
+	if conf.Scaling != nil && conf.Scaling.TargetBacklog == 0 {
+		// Copy so that the defaults don't leak into the manifest.
+		scaling := *conf.Scaling
+		scaling.TargetBacklog = defaultWorkerTargetBacklog
+		conf.Scaling = &scaling
+	}
+	return conf
+}
@@ -0,0 +1,186 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/gobuffalo/packd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerServiceStackConfig_Template(t *testing.T) {
+	testEnv := &archer.Environment{
+		Project:   "phonetool",
+		Name:      "test",
+		Region:    "us-west-2",
+		AccountID: "12345",
+	}
+	testTemplate := `Parameters:
+  ContainerImage: {{.Image.URL}}
+  QueueVisibilityTimeout: {{.App.Queue.VisibilityTimeout}}{{if .App.Scaling}}
+Scaling:
+  MinCapacity: {{.App.Scaling.MinCount}}
+  MaxCapacity: {{.App.Scaling.MaxCount}}
+  TargetValue: {{.App.Scaling.TargetBacklog}}{{end}}`
+
+	testCases := map[string]struct {
+		inScaling *manifest.QueueScalingConfig
+
+		mockBox func(box *packd.MemoryBox)
+
+		wantedTemplate string
+		wantedError    error
+	}{
+		"unavailable service template": {
+			mockBox:        func(box *packd.MemoryBox) {},
+			wantedTemplate: "",
+			wantedError: &ErrTemplateNotFound{
+				templateLocation: workerServiceTemplatePath,
+				parentErr:        os.ErrNotExist,
+			},
+		},
+		"render template without scaling": {
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(workerServiceTemplatePath, testTemplate)
+			},
+			wantedTemplate: `Parameters:
+  ContainerImage: 12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/thumbnails:manual-bf3678c
+  QueueVisibilityTimeout: 30`,
+		},
+		"render template with default target backlog": {
+			inScaling: &manifest.QueueScalingConfig{
+				MinCount: 0,
+				MaxCount: 10,
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(workerServiceTemplatePath, testTemplate)
+			},
+			wantedTemplate: `Parameters:
+  ContainerImage: 12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/thumbnails:manual-bf3678c
+  QueueVisibilityTimeout: 30
+Scaling:
+  MinCapacity: 0
+  MaxCapacity: 10
+  TargetValue: 100`,
+		},
+		"invalid scaling range": {
+			inScaling: &manifest.QueueScalingConfig{
+				MinCount: 5,
+				MaxCount: 2,
+			},
+			mockBox: func(box *packd.MemoryBox) {
+				box.AddString(workerServiceTemplatePath, testTemplate)
+			},
+			wantedTemplate: "",
+			wantedError:    errors.New("scaling for thumbnails: maxCount 2 must be at least 1 and greater than or equal to minCount 5"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			box := packd.NewMemoryBox()
+			tc.mockBox(box)
+
+			mft := manifest.NewWorkerServiceManifest("thumbnails", "thumbnails/Dockerfile")
+			mft.Queue = manifest.QueueConfig{} // Omitted fields should fall back to defaults.
+			mft.Scaling = tc.inScaling
+			conf := &WorkerServiceStackConfig{
+				CreateWorkerServiceInput: &deploy.CreateWorkerServiceInput{
+					App:          mft,
+					Env:          testEnv,
+					ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/thumbnails",
+					ImageTag:     "manual-bf3678c",
+				},
+				box: box,
+			}
+
+			// WHEN
+			template, err := conf.Template()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.Error(t, err)
+				if _, ok := tc.wantedError.(*ErrTemplateNotFound); ok {
+					require.True(t, errors.Is(err, tc.wantedError), "expected: %v, got: %v", tc.wantedError, err)
+				} else {
+					require.EqualError(t, err, tc.wantedError.Error())
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedTemplate, template)
+			require.Equal(t, manifest.QueueConfig{}, mft.Queue, "defaults should not be written back to the manifest")
+			if mft.Scaling != nil {
+				require.Zero(t, mft.Scaling.TargetBacklog, "defaults should not be written back to the manifest")
+			}
+		})
+	}
+}
+
+func TestWorkerServiceStackConfig_Parameters(t *testing.T) {
+	// GIVEN
+	conf := &WorkerServiceStackConfig{
+		CreateWorkerServiceInput: &deploy.CreateWorkerServiceInput{
+			App: manifest.NewWorkerServiceManifest("thumbnails", "thumbnails/Dockerfile"),
+			Env: &archer.Environment{
+				Project: "phonetool",
+				Name:    "test",
+			},
+			ImageRepoURL: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/thumbnails",
+			ImageTag:     "manual-bf3678c",
+		},
+	}
+
+	// WHEN
+	params := conf.Parameters()
+
+	// THEN
+	require.Equal(t, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(LBFargateParamProjectNameKey),
+			ParameterValue: aws.String("phonetool"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamEnvNameKey),
+			ParameterValue: aws.String("test"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamAppNameKey),
+			ParameterValue: aws.String("thumbnails"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateParamContainerImageKey),
+			ParameterValue: aws.String("12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/thumbnails:manual-bf3678c"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCPUKey),
+			ParameterValue: aws.String("256"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskMemoryKey),
+			ParameterValue: aws.String("512"),
+		},
+		{
+			ParameterKey:   aws.String(LBFargateTaskCountKey),
+			ParameterValue: aws.String("1"),
+		},
+		{
+			ParameterKey:   aws.String(WorkerQueueVisibilityTimeoutKey),
+			ParameterValue: aws.String("30"),
+		},
+		{
+			ParameterKey:   aws.String(WorkerQueueMaxReceiveCountKey),
+			ParameterValue: aws.String("3"),
+		},
+	}, params)
+}
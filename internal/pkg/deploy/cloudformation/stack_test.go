@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/stretchr/testify/require"
 )
@@ -99,3 +101,93 @@ func TestWaitForStackDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestStackTemplate(t *testing.T) {
+	tests := map[string]struct {
+		mockGetTemplate func(t *testing.T, in *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error)
+
+		wantTemplate string
+		wantErr      error
+	}{
+		"should return ErrStackNotFound if the stack doesn't exist": {
+			mockGetTemplate: func(t *testing.T, in *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error) {
+				return nil, awserr.New("ValidationError", "Stack with id mockStackName does not exist", nil)
+			},
+			wantErr: &ErrStackNotFound{stackName: "mockStackName"},
+		},
+		"should return the original template": {
+			mockGetTemplate: func(t *testing.T, in *cloudformation.GetTemplateInput) (*cloudformation.GetTemplateOutput, error) {
+				require.Equal(t, "mockStackName", *in.StackName)
+				require.Equal(t, cloudformation.TemplateStageOriginal, *in.TemplateStage)
+				return &cloudformation.GetTemplateOutput{
+					TemplateBody: aws.String("mockTemplate"),
+				}, nil
+			},
+			wantTemplate: "mockTemplate",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cf := CloudFormation{
+				client: mockCloudFormation{
+					t: t,
+
+					mockGetTemplate: test.mockGetTemplate,
+				},
+			}
+
+			got, err := cf.StackTemplate("mockStackName")
+
+			require.Equal(t, test.wantErr, err)
+			require.Equal(t, test.wantTemplate, got)
+		})
+	}
+}
+
+func TestStackResourceID(t *testing.T) {
+	mockStackName := "mockStackName"
+
+	tests := map[string]struct {
+		mockDescribeStackResource func(*testing.T, *cloudformation.DescribeStackResourceInput) (*cloudformation.DescribeStackResourceOutput, error)
+
+		wantID  string
+		wantErr error
+	}{
+		"should return the physical ID of the resource": {
+			mockDescribeStackResource: func(t *testing.T, in *cloudformation.DescribeStackResourceInput) (*cloudformation.DescribeStackResourceOutput, error) {
+				require.Equal(t, mockStackName, aws.StringValue(in.StackName))
+				require.Equal(t, "Service", aws.StringValue(in.LogicalResourceId))
+				return &cloudformation.DescribeStackResourceOutput{
+					StackResourceDetail: &cloudformation.StackResourceDetail{
+						PhysicalResourceId: aws.String("arn:aws:ecs:us-west-2:1234:service/cluster/frontend"),
+					},
+				}, nil
+			},
+			wantID: "arn:aws:ecs:us-west-2:1234:service/cluster/frontend",
+		},
+		"should return ErrStackNotFound if the stack doesn't exist": {
+			mockDescribeStackResource: func(t *testing.T, in *cloudformation.DescribeStackResourceInput) (*cloudformation.DescribeStackResourceOutput, error) {
+				return nil, awserr.New("ValidationError", "Stack 'mockStackName' does not exist", nil)
+			},
+			wantErr: &ErrStackNotFound{stackName: mockStackName},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cf := CloudFormation{
+				client: mockCloudFormation{
+					t: t,
+
+					mockDescribeStackResource: test.mockDescribeStackResource,
+				},
+			}
+
+			id, err := cf.StackResourceID(mockStackName, "Service")
+
+			require.Equal(t, test.wantErr, err)
+			require.Equal(t, test.wantID, id)
+		})
+	}
+}
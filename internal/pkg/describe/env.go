@@ -0,0 +1,65 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+)
+
+// EnvDescriber retrieves information about the environments of a project.
+type EnvDescriber struct {
+	project string
+
+	stackDescribers map[string]stackDescriber
+	sessProvider    sessionFromRoleProvider
+}
+
+// NewEnvDescriber instantiates a describer for the environments of a project.
+func NewEnvDescriber(project string) *EnvDescriber {
+	return &EnvDescriber{
+		project:         project,
+		stackDescribers: make(map[string]stackDescriber),
+		sessProvider:    session.NewProvider(),
+	}
+}
+
+// Outputs returns the outputs of the environment's CloudFormation stack keyed by their logical ID.
+func (d *EnvDescriber) Outputs(env *archer.Environment) (map[string]string, error) {
+	svc, err := d.stackDescriber(env.ManagerRoleARN, env.Region)
+	if err != nil {
+		return nil, err
+	}
+	stackName := stack.NameForEnv(d.project, env.Name)
+	out, err := svc.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe stack %s: %w", stackName, err)
+	}
+	if len(out.Stacks) == 0 {
+		return nil, fmt.Errorf("stack %s not found", stackName)
+	}
+	outputs := make(map[string]string)
+	for _, output := range out.Stacks[0].Outputs {
+		outputs[aws.StringValue(output.OutputKey)] = aws.StringValue(output.OutputValue)
+	}
+	return outputs, nil
+}
+
+func (d *EnvDescriber) stackDescriber(roleARN, region string) (stackDescriber, error) {
+	if _, ok := d.stackDescribers[roleARN]; !ok {
+		sess, err := d.sessProvider.FromRole(roleARN, region)
+		if err != nil {
+			return nil, fmt.Errorf("session for role %s and region %s: %w", roleARN, region, err)
+		}
+		d.stackDescribers[roleARN] = cloudformation.New(sess)
+	}
+	return d.stackDescribers[roleARN], nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvDescriber_Outputs(t *testing.T) {
+	const (
+		testProject        = "phonetool"
+		testEnv            = "test"
+		testManagerRoleARN = "arn:aws:iam::1111:role/manager"
+	)
+	testCases := map[string]struct {
+		mockStackDescriber func(m *mocks.MockstackDescriber)
+
+		wantedOutputs map[string]string
+		wantedError   error
+	}{
+		"cfn error": {
+			mockStackDescriber: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().DescribeStacks(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: fmt.Errorf("describe stack %s: %s", stack.NameForEnv(testProject, testEnv), "some error"),
+		},
+		"stack does not exist": {
+			mockStackDescriber: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().DescribeStacks(gomock.Any()).Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{},
+				}, nil)
+			},
+			wantedError: fmt.Errorf("stack %s not found", stack.NameForEnv(testProject, testEnv)),
+		},
+		"returns the stack outputs": {
+			mockStackDescriber: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+					StackName: aws.String(stack.NameForEnv(testProject, testEnv)),
+				}).Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{
+						{
+							Outputs: []*cloudformation.Output{
+								{
+									OutputKey:   aws.String("PrivateSubnets"),
+									OutputValue: aws.String("subnet-1,subnet-2"),
+								},
+								{
+									OutputKey:   aws.String(stack.EnvOutputSubdomain),
+									OutputValue: aws.String("test.phonetool.com"),
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+			wantedOutputs: map[string]string{
+				"PrivateSubnets":         "subnet-1,subnet-2",
+				stack.EnvOutputSubdomain: "test.phonetool.com",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockstackDescriber(ctrl)
+			tc.mockStackDescriber(m)
+			d := &EnvDescriber{
+				project: testProject,
+				stackDescribers: map[string]stackDescriber{
+					testManagerRoleARN: m,
+				},
+			}
+
+			// WHEN
+			outputs, err := d.Outputs(&archer.Environment{
+				Project:        testProject,
+				Name:           testEnv,
+				ManagerRoleARN: testManagerRoleARN,
+			})
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedOutputs, outputs)
+			}
+		})
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/aws-sdk-go/aws"
@@ -33,6 +36,14 @@ const (
 type WebAppURI struct {
 	DNSName string // The environment's subdomain if the application is served on HTTPS. Otherwise, the public load balancer's DNS.
 	Path    string // Empty if the application is served on HTTPS. Otherwise, the pattern used to match the application.
+
+	Routes []*WebAppRouteURI // Every route to the application, if it isn't only reachable at DNSName and Path.
+}
+
+// WebAppRouteURI represents one of the routes of the load balancer to a web application.
+type WebAppRouteURI struct {
+	URL        string // The URL of the host matched by the route.
+	Conditions string // The path, header and query string conditions of the route, empty if any request to the host matches.
 }
 
 // CfnResource contains application resources created by cloudformation.
@@ -93,12 +104,26 @@ type envGetter interface {
 }
 
 func (uri *WebAppURI) String() string {
+	if len(uri.Routes) != 0 {
+		var routes []string
+		for _, route := range uri.Routes {
+			routes = append(routes, route.String())
+		}
+		return strings.Join(routes, ", ")
+	}
 	if uri.Path != "" {
 		return fmt.Sprintf("%s and path %s", color.HighlightResource("http://"+uri.DNSName), color.HighlightResource(uri.Path))
 	}
 	return color.HighlightResource("https://" + uri.DNSName)
 }
 
+func (r *WebAppRouteURI) String() string {
+	if r.Conditions == "" {
+		return color.HighlightResource(r.URL)
+	}
+	return fmt.Sprintf("%s with %s", color.HighlightResource(r.URL), color.HighlightResource(r.Conditions))
+}
+
 // WebAppDescriber retrieves information about a load balanced web application.
 type WebAppDescriber struct {
 	app *archer.Application
@@ -148,6 +173,24 @@ func (d *WebAppDescriber) ECSParams(envName string) (*WebAppECSParams, error) {
 	}, nil
 }
 
+// Image returns the URI of the container image deployed to an environment.
+func (d *WebAppDescriber) Image(envName string) (string, error) {
+	env, err := d.store.GetEnvironment(d.app.Project, envName)
+	if err != nil {
+		return "", err
+	}
+
+	appParams, err := d.appParams(env)
+	if err != nil {
+		return "", err
+	}
+	image, ok := appParams[stack.LBFargateParamContainerImageKey]
+	if !ok {
+		return "", fmt.Errorf("parameter %s not found in stack of application %s in environment %s", stack.LBFargateParamContainerImageKey, d.app.Name, envName)
+	}
+	return image, nil
+}
+
 // StackResources returns the physical ID of stack resources created by cloudformation.
 func (d *WebAppDescriber) StackResources(envName string) ([]*CfnResource, error) {
 	env, err := d.store.GetEnvironment(d.app.Project, envName)
@@ -181,7 +224,7 @@ func (d *WebAppDescriber) URI(envName string) (*WebAppURI, error) {
 	if err != nil {
 		return nil, err
 	}
-	appParams, err := d.appParams(env)
+	appParams, appOutputs, err := d.appStackDetails(env)
 	if err != nil {
 		return nil, err
 	}
@@ -190,16 +233,77 @@ func (d *WebAppDescriber) URI(envName string) (*WebAppURI, error) {
 		DNSName: envOutputs[stack.EnvOutputPublicLoadBalancerDNSName],
 		Path:    appParams[stack.LBFargateRulePathKey],
 	}
+	scheme := "http"
 	_, isHTTPS := envOutputs[stack.EnvOutputSubdomain]
 	if isHTTPS {
 		dnsName := fmt.Sprintf("%s.%s", d.app.Name, envOutputs[stack.EnvOutputSubdomain])
 		uri = &WebAppURI{
 			DNSName: dnsName,
 		}
+		scheme = "https"
+	}
+
+	serializedRoutes, ok := appOutputs[stack.LBFargateOutputRoutesKey]
+	if !ok {
+		// The application was deployed before it could have more than one route.
+		return uri, nil
+	}
+	var routes []manifest.Route
+	if err := json.Unmarshal([]byte(serializedRoutes), &routes); err != nil {
+		return nil, fmt.Errorf("unmarshal routes of application %s: %w", d.app.Name, err)
+	}
+	if len(routes) == 1 && len(routes[0].Hosts) == 0 && len(routes[0].Headers) == 0 && len(routes[0].QueryStrings) == 0 {
+		// The application is only reachable at its default DNS name and path.
+		if isHTTPS && len(routes[0].Paths) == 0 {
+			return uri, nil
+		}
+		if !isHTTPS && len(routes[0].Paths) == 1 {
+			uri.Path = routes[0].Paths[0]
+			return uri, nil
+		}
+	}
+	for _, route := range routes {
+		hosts := route.Hosts
+		if len(hosts) == 0 {
+			hosts = []string{uri.DNSName}
+		}
+		for _, host := range hosts {
+			uri.Routes = append(uri.Routes, &WebAppRouteURI{
+				URL:        fmt.Sprintf("%s://%s", scheme, host),
+				Conditions: routeConditions(route),
+			})
+		}
 	}
 	return uri, nil
 }
 
+// routeConditions returns a human readable description of the path, header and query string conditions of a route.
+func routeConditions(route manifest.Route) string {
+	var conditions []string
+	if len(route.Paths) == 1 {
+		conditions = append(conditions, "path "+route.Paths[0])
+	} else if len(route.Paths) > 1 {
+		conditions = append(conditions, "paths "+strings.Join(route.Paths, ", "))
+	}
+	var names []string
+	for name := range route.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		conditions = append(conditions, fmt.Sprintf("header %s: %s", name, strings.Join(route.Headers[name], " or ")))
+	}
+	var keys []string
+	for key := range route.QueryStrings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conditions = append(conditions, fmt.Sprintf("query %s=%s", key, route.QueryStrings[key]))
+	}
+	return strings.Join(conditions, " and ")
+}
+
 func (d *WebAppDescriber) envOutputs(env *archer.Environment) (map[string]string, error) {
 	envStack, err := d.stack(env.ManagerRoleARN, env.Region, stack.NameForEnv(d.app.Project, env.Name))
 	if err != nil {
@@ -213,15 +317,25 @@ func (d *WebAppDescriber) envOutputs(env *archer.Environment) (map[string]string
 }
 
 func (d *WebAppDescriber) appParams(env *archer.Environment) (map[string]string, error) {
+	params, _, err := d.appStackDetails(env)
+	return params, err
+}
+
+// appStackDetails returns the parameters and the outputs of the application's stack.
+func (d *WebAppDescriber) appStackDetails(env *archer.Environment) (params map[string]string, outputs map[string]string, err error) {
 	appStack, err := d.stack(env.ManagerRoleARN, env.Region, stack.NameForApp(d.app.Project, env.Name, d.app.Name))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	params := make(map[string]string)
+	params = make(map[string]string)
 	for _, param := range appStack.Parameters {
 		params[*param.ParameterKey] = *param.ParameterValue
 	}
-	return params, nil
+	outputs = make(map[string]string)
+	for _, out := range appStack.Outputs {
+		outputs[*out.OutputKey] = *out.OutputValue
+	}
+	return params, outputs, nil
 }
 
 func (d *WebAppDescriber) describeStackResources(roleARN, region, stackName string) ([]*cloudformation.StackResource, error) {
@@ -21,6 +21,7 @@ func TestWebAppURI_String(t *testing.T) {
 	testCases := map[string]struct {
 		dnsName string
 		path    string
+		routes  []*WebAppRouteURI
 
 		wanted string
 	}{
@@ -36,6 +37,15 @@ func TestWebAppURI_String(t *testing.T) {
 
 			wanted: "https://jobs.test.phonetool.com",
 		},
+		"multiple routes": {
+			dnsName: "jobs.test.phonetool.com",
+			routes: []*WebAppRouteURI{
+				{URL: "https://jobs.test.phonetool.com"},
+				{URL: "https://api.phonetool.com", Conditions: "path /v2/*"},
+			},
+
+			wanted: "https://jobs.test.phonetool.com, https://api.phonetool.com with path /v2/*",
+		},
 	}
 
 	for name, tc := range testCases {
@@ -43,6 +53,7 @@ func TestWebAppURI_String(t *testing.T) {
 			uri := &WebAppURI{
 				DNSName: tc.dnsName,
 				Path:    tc.path,
+				Routes:  tc.routes,
 			}
 
 			require.Equal(t, tc.wanted, uri.String())
@@ -221,6 +232,67 @@ func TestWebAppDescriber_URI(t *testing.T) {
 				Path:    testAppPath,
 			},
 		},
+		"http web application with routes": {
+			mockStore: func(ctrl *gomock.Controller) *mocks.MockenvGetter {
+				m := mocks.NewMockenvGetter(ctrl)
+				m.EXPECT().GetEnvironment(testProject, testEnv).Return(&archer.Environment{
+					Project:        testProject,
+					Name:           testEnv,
+					ManagerRoleARN: testManagerRoleARN,
+				}, nil)
+				return m
+			},
+			mockStackDescribers: func(ctrl *gomock.Controller) map[string]stackDescriber {
+				m := mocks.NewMockstackDescriber(ctrl)
+				describers := make(map[string]stackDescriber)
+				m.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+					StackName: aws.String(stack.NameForEnv(testProject, testEnv)),
+				}).Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{
+						{
+							Outputs: []*cloudformation.Output{
+								{
+									OutputKey:   aws.String(stack.EnvOutputPublicLoadBalancerDNSName),
+									OutputValue: aws.String(testEnvLBDNSName),
+								},
+							},
+						},
+					},
+				}, nil)
+				m.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+					StackName: aws.String(stack.NameForApp(testProject, testEnv, testApp)),
+				}).Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{
+						{
+							Parameters: []*cloudformation.Parameter{
+								{
+									ParameterKey:   aws.String(stack.LBFargateRulePathKey),
+									ParameterValue: aws.String(testAppPath),
+								},
+							},
+							Outputs: []*cloudformation.Output{
+								{
+									OutputKey:   aws.String(stack.LBFargateOutputRoutesKey),
+									OutputValue: aws.String(`[{"paths":["*"]},{"paths":["/v2/*"],"headers":{"X-Version":["2"]}},{"hosts":["api.example.com"]}]`),
+								},
+							},
+						},
+					},
+				}, nil)
+				describers[testManagerRoleARN] = m
+				return describers
+			},
+
+			wantedURI: &WebAppURI{
+				DNSName: testEnvLBDNSName,
+				Path:    testAppPath,
+				Routes: []*WebAppRouteURI{
+					{URL: "http://" + testEnvLBDNSName, Conditions: "path *"},
+					{URL: "http://" + testEnvLBDNSName, Conditions: "path /v2/* and header X-Version: 2"},
+					{URL: "http://api.example.com"},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -353,6 +425,81 @@ func TestWebAppDescriber_ECSParams(t *testing.T) {
 	}
 }
 
+func TestWebAppDescriber_Image(t *testing.T) {
+	const (
+		testProject        = "phonetool"
+		testEnv            = "test"
+		testManagerRoleARN = "arn:aws:iam::1111:role/manager"
+		testApp            = "jobs"
+		testImage          = "1111.dkr.ecr.us-west-2.amazonaws.com/phonetool/jobs:v1.2"
+	)
+	testCases := map[string]struct {
+		params []*cloudformation.Parameter
+
+		wantedImage string
+		wantedError error
+	}{
+		"returns the deployed image": {
+			params: []*cloudformation.Parameter{
+				{
+					ParameterKey:   aws.String(stack.LBFargateParamContainerImageKey),
+					ParameterValue: aws.String(testImage),
+				},
+			},
+			wantedImage: testImage,
+		},
+		"stack without image parameter": {
+			wantedError: fmt.Errorf("parameter %s not found in stack of application %s in environment %s", stack.LBFargateParamContainerImageKey, testApp, testEnv),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockStore := mocks.NewMockenvGetter(ctrl)
+			mockStore.EXPECT().GetEnvironment(testProject, testEnv).Return(&archer.Environment{
+				Project:        testProject,
+				Name:           testEnv,
+				ManagerRoleARN: testManagerRoleARN,
+			}, nil)
+			mockStackDescriber := mocks.NewMockstackDescriber(ctrl)
+			mockStackDescriber.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+				StackName: aws.String(stack.NameForApp(testProject, testEnv, testApp)),
+			}).Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []*cloudformation.Stack{
+					{
+						Parameters: tc.params,
+					},
+				},
+			}, nil)
+
+			d := &WebAppDescriber{
+				app: &archer.Application{
+					Project: testProject,
+					Name:    testApp,
+				},
+				store: mockStore,
+				stackDescribers: map[string]stackDescriber{
+					testManagerRoleARN: mockStackDescriber,
+				},
+			}
+
+			// WHEN
+			actual, err := d.Image(testEnv)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedImage, actual)
+			}
+		})
+	}
+}
+
 func TestWebAppDescriber_StackResources(t *testing.T) {
 	const (
 		testProject        = "phonetool"
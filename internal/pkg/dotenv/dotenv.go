@@ -0,0 +1,177 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dotenv reads and writes environment variables in the dotenv file format.
+package dotenv
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+var (
+	keyRegexp         = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	unquotedValRegexp = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+)
+
+// Variable is an environment variable of a dotenv file.
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// ErrInvalidLine occurs when a line of a dotenv file can't be parsed.
+type ErrInvalidLine struct {
+	Line   int
+	Reason string
+}
+
+func (e *ErrInvalidLine) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+}
+
+// Parse reads the variables of a dotenv file in the order they are declared.
+//
+// Lines are of the form "KEY=value", optionally prefixed with "export". Blank lines and lines
+// starting with "#" are ignored. Unquoted values are trimmed and end at the first " #".
+// Single-quoted values are taken literally, and double-quoted values expand the escape
+// sequences \n, \r, \t, \" and \\. Quoted values can span multiple lines.
+// If a key is declared more than once, the last value wins.
+func Parse(r io.Reader) ([]Variable, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read dotenv file: %w", err)
+	}
+	p := &parser{
+		lines: strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"),
+		index: make(map[string]int),
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.vars, nil
+}
+
+// Write writes the variables in the dotenv file format, quoting the values that need it.
+func Write(w io.Writer, vars []Variable) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", v.Name, quote(v.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type parser struct {
+	lines []string
+	pos   int // Index of the next line to parse.
+
+	vars  []Variable
+	index map[string]int // Position of each key in vars.
+}
+
+func (p *parser) parse() error {
+	for p.pos < len(p.lines) {
+		lineNo := p.pos + 1
+		line := strings.TrimSpace(p.lines[p.pos])
+		p.pos++
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		eq := strings.Index(line, "=")
+		if eq == -1 {
+			return &ErrInvalidLine{Line: lineNo, Reason: `missing "="`}
+		}
+		key := strings.TrimSpace(line[:eq])
+		if !keyRegexp.MatchString(key) {
+			return &ErrInvalidLine{Line: lineNo, Reason: fmt.Sprintf("invalid key %q", key)}
+		}
+		value, err := p.value(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return &ErrInvalidLine{Line: lineNo, Reason: err.Error()}
+		}
+		p.add(key, value)
+	}
+	return nil
+}
+
+// value parses the value starting on the current line, consuming the following lines of a multiline value.
+func (p *parser) value(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	switch quote := raw[0]; quote {
+	case '"', '\'':
+		return p.quotedValue(raw[1:], quote)
+	default:
+		if i := strings.Index(raw, " #"); i != -1 {
+			raw = raw[:i]
+		}
+		return strings.TrimSpace(raw), nil
+	}
+}
+
+func (p *parser) quotedValue(rest string, quote byte) (string, error) {
+	var sb strings.Builder
+	for {
+		for i := 0; i < len(rest); i++ {
+			c := rest[i]
+			if c == quote {
+				if trailing := strings.TrimSpace(rest[i+1:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return "", fmt.Errorf("unexpected characters after the closing quote: %s", trailing)
+				}
+				return sb.String(), nil
+			}
+			if c == '\\' && quote == '"' && i+1 < len(rest) {
+				i++
+				sb.WriteString(unescape(rest[i]))
+				continue
+			}
+			sb.WriteByte(c)
+		}
+		if p.pos >= len(p.lines) {
+			return "", fmt.Errorf("missing the closing quote %c", quote)
+		}
+		sb.WriteByte('\n')
+		rest = p.lines[p.pos]
+		p.pos++
+	}
+}
+
+func (p *parser) add(key, value string) {
+	if i, ok := p.index[key]; ok {
+		p.vars[i].Value = value
+		return
+	}
+	p.index[key] = len(p.vars)
+	p.vars = append(p.vars, Variable{Name: key, Value: value})
+}
+
+func unescape(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case '"', '\\':
+		return string(c)
+	default:
+		// Unknown escape sequences are kept as is.
+		return "\\" + string(c)
+	}
+}
+
+// quote returns the value as is if it only contains safe characters, otherwise double-quoted and escaped.
+func quote(value string) string {
+	if unquotedValRegexp.MatchString(value) {
+		return value
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(value) + `"`
+}
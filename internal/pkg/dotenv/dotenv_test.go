@@ -0,0 +1,113 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package dotenv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		in string
+
+		wantedVars []Variable
+		wantedErr  error
+	}{
+		"unquoted values and comments": {
+			in: `# Database
+DB_HOST=localhost
+export DB_PORT = 5432 # default port
+
+LOG_LEVEL=
+`,
+			wantedVars: []Variable{
+				{Name: "DB_HOST", Value: "localhost"},
+				{Name: "DB_PORT", Value: "5432"},
+				{Name: "LOG_LEVEL", Value: ""},
+			},
+		},
+		"quoted values": {
+			in: `GREETING="hello # not a comment"
+ESCAPED="line1\nline2 \"quoted\""
+LITERAL='no\nescape' # comment`,
+			wantedVars: []Variable{
+				{Name: "GREETING", Value: "hello # not a comment"},
+				{Name: "ESCAPED", Value: "line1\nline2 \"quoted\""},
+				{Name: "LITERAL", Value: `no\nescape`},
+			},
+		},
+		"multiline value": {
+			in: `PRIVATE_KEY="-----BEGIN KEY-----
+abc
+-----END KEY-----"
+NEXT=1`,
+			wantedVars: []Variable{
+				{Name: "PRIVATE_KEY", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----"},
+				{Name: "NEXT", Value: "1"},
+			},
+		},
+		"last declaration wins": {
+			in: "A=1\nB=2\nA=3\n",
+			wantedVars: []Variable{
+				{Name: "A", Value: "3"},
+				{Name: "B", Value: "2"},
+			},
+		},
+		"missing equal sign": {
+			in:        "A=1\nB\n",
+			wantedErr: &ErrInvalidLine{Line: 2, Reason: `missing "="`},
+		},
+		"invalid key": {
+			in:        "1A=1",
+			wantedErr: &ErrInvalidLine{Line: 1, Reason: `invalid key "1A"`},
+		},
+		"unterminated quote": {
+			in:        "A=\"abc\nB=1",
+			wantedErr: &ErrInvalidLine{Line: 1, Reason: "missing the closing quote \""},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			vars, err := Parse(strings.NewReader(tc.in))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedVars, vars)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	// GIVEN
+	vars := []Variable{
+		{Name: "URL", Value: "https://example.com/path"},
+		{Name: "GREETING", Value: "hello world"},
+		{Name: "MULTILINE", Value: "a\n\"b\""},
+	}
+	b := &bytes.Buffer{}
+
+	// WHEN
+	err := Write(b, vars)
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, `URL=https://example.com/path
+GREETING="hello world"
+MULTILINE="a\n\"b\""
+`, b.String())
+
+	parsed, err := Parse(b)
+	require.NoError(t, err)
+	require.Equal(t, vars, parsed)
+}
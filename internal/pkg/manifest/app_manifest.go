@@ -5,6 +5,9 @@
 package manifest
 
 import (
+	"path/filepath"
+	"reflect"
+
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"gopkg.in/yaml.v3"
 )
@@ -12,11 +15,20 @@ import (
 const (
 	// LoadBalancedWebApplication is a web application with a load balancer and Fargate as compute.
 	LoadBalancedWebApplication = "Load Balanced Web App"
+	// BackendApplication is a service that is not reachable from the internet and runs on Fargate in private subnets.
+	BackendApplication = "Backend App"
+	// ScheduledJob is a task that runs on Fargate on a schedule triggered by an EventBridge rule.
+	ScheduledJob = "Scheduled Job"
+	// WorkerService is a service that processes messages from an SQS queue and runs on Fargate in private subnets.
+	WorkerService = "Worker Service"
 )
 
 // AppTypes are the supported manifest types.
 var AppTypes = []string{
 	LoadBalancedWebApplication,
+	BackendApplication,
+	ScheduledJob,
+	WorkerService,
 }
 
 // AppManifest holds the basic data that every manifest file need to have.
@@ -32,7 +44,111 @@ func (a *AppManifest) AppName() string {
 
 // AppImage represents the application's container image.
 type AppImage struct {
-	Build string `yaml:"build"` // Path to the Dockerfile.
+	Build    BuildArgsOrString `yaml:"build,omitempty"`    // Path to the Dockerfile, or the options to build it with.
+	Location string            `yaml:"location,omitempty"` // URI of a pre-built image, deployed as is instead of building the Dockerfile.
+}
+
+// BuildConfig returns the options to build the image with, or nil if the image isn't built from a Dockerfile.
+// A missing Dockerfile defaults to the "Dockerfile" in the context directory, and a missing context
+// defaults to the directory of the Dockerfile.
+func (i AppImage) BuildConfig() *DockerBuildArgs {
+	args := i.Build.BuildArgs
+	if i.Build.BuildString != "" {
+		args = DockerBuildArgs{
+			Dockerfile: i.Build.BuildString,
+		}
+	}
+	switch {
+	case args.Dockerfile == "" && args.Context == "":
+		return nil
+	case args.Dockerfile == "":
+		args.Dockerfile = filepath.Join(args.Context, "Dockerfile")
+	case args.Context == "":
+		args.Context = filepath.Dir(args.Dockerfile)
+	}
+	return &args
+}
+
+func (i AppImage) dockerfilePath() string {
+	args := i.BuildConfig()
+	if args == nil {
+		return ""
+	}
+	return args.Dockerfile
+}
+
+// BuildArgsOrString is the "build" field of an image. It is either the path to the Dockerfile
+// or a mapping with the options of the "docker build" command.
+type BuildArgsOrString struct {
+	BuildString string
+	BuildArgs   DockerBuildArgs
+}
+
+// DockerBuildArgs holds the options of the "docker build" command.
+// The paths are relative to the root of the workspace.
+type DockerBuildArgs struct {
+	Dockerfile string            `yaml:"dockerfile,omitempty"`
+	Context    string            `yaml:"context,omitempty"`
+	Target     string            `yaml:"target,omitempty"`     // Stage of a multi-stage Dockerfile to build.
+	Args       map[string]string `yaml:"args,omitempty"`       // Values of the build-time variables.
+	CacheFrom  []string          `yaml:"cache_from,omitempty"` // Images to use as cache sources.
+}
+
+// UnmarshalYAML decodes either a string or a mapping into the build field.
+func (b *BuildArgsOrString) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		b.BuildArgs = DockerBuildArgs{}
+		return value.Decode(&b.BuildString)
+	}
+	b.BuildString = ""
+	return value.Decode(&b.BuildArgs)
+}
+
+// MarshalYAML encodes the build field in the same form that it was written in.
+func (b BuildArgsOrString) MarshalYAML() (interface{}, error) {
+	if b.BuildString != "" {
+		return b.BuildString, nil
+	}
+	return b.BuildArgs, nil
+}
+
+// IsZero returns true if the image isn't built, so that the field is omitted.
+func (b BuildArgsOrString) IsZero() bool {
+	return b.BuildString == "" && reflect.DeepEqual(b.BuildArgs, DockerBuildArgs{})
+}
+
+// DeploymentConfig holds the settings of the deployments of a service's tasks.
+type DeploymentConfig struct {
+	Timeout int `yaml:"timeout,omitempty"` // In seconds, to wait for the new tasks to reach a steady state.
+}
+
+// override replaces the fields of the deployment settings with the ones set in target.
+func (d *DeploymentConfig) override(target DeploymentConfig) {
+	if target.Timeout != 0 {
+		d.Timeout = target.Timeout
+	}
+}
+
+// DeployHooks holds the one-off tasks run while deploying a service.
+type DeployHooks struct {
+	PreDeploy  *DeployHook `yaml:"pre_deploy,omitempty"`  // Run with the new image before the service is updated, for example to migrate a database.
+	PostDeploy *DeployHook `yaml:"post_deploy,omitempty"` // Run once the new tasks of the service reach a steady state, for example to smoke test them.
+}
+
+// DeployHook is a one-off task running the service's image with another command.
+type DeployHook struct {
+	Command []string `yaml:"command"`
+	Timeout int      `yaml:"timeout,omitempty"` // In seconds, to wait for the task to stop.
+}
+
+// override replaces the hooks with the ones set in target.
+func (h *DeployHooks) override(target DeployHooks) {
+	if target.PreDeploy != nil {
+		h.PreDeploy = target.PreDeploy
+	}
+	if target.PostDeploy != nil {
+		h.PostDeploy = target.PostDeploy
+	}
 }
 
 // CreateApp returns a manifest object based on the application's type.
@@ -41,6 +157,12 @@ func CreateApp(appName, appType, dockerfile string, port int) (archer.Manifest,
 	switch appType {
 	case LoadBalancedWebApplication:
 		return NewLoadBalancedFargateManifest(appName, dockerfile, port), nil
+	case BackendApplication:
+		return NewBackendAppManifest(appName, dockerfile, port), nil
+	case ScheduledJob:
+		return NewScheduledJobManifest(appName, dockerfile), nil
+	case WorkerService:
+		return NewWorkerServiceManifest(appName, dockerfile), nil
 	default:
 		return nil, &ErrInvalidAppManifestType{Type: appType}
 	}
@@ -49,18 +171,54 @@ func CreateApp(appName, appType, dockerfile string, port int) (archer.Manifest,
 // UnmarshalApp deserializes the YAML input stream into a manifest object.
 // If an error occurs during deserialization, then returns the error.
 // If the application type in the manifest is invalid, then returns an ErrInvalidManifestType.
+// Manifests written by older versions of the CLI are migrated in memory first.
 func UnmarshalApp(in []byte) (archer.Manifest, error) {
+	e, err := NewEditor(in)
+	if err != nil {
+		return nil, err
+	}
+	doc := e.root()
 	am := AppManifest{}
-	if err := yaml.Unmarshal(in, &am); err != nil {
+	if err := doc.Decode(&am); err != nil {
 		return nil, &ErrUnmarshalAppManifest{parent: err}
 	}
 
 	switch am.Type {
 	case LoadBalancedWebApplication:
 		m := LBFargateManifest{}
-		if err := yaml.Unmarshal(in, &m); err != nil {
+		if err := doc.Decode(&m); err != nil {
 			return nil, &ErrUnmarshalLBFargateManifest{parent: err}
 		}
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+		return &m, nil
+	case BackendApplication:
+		m := BackendAppManifest{}
+		if err := doc.Decode(&m); err != nil {
+			return nil, &ErrUnmarshalBackendAppManifest{parent: err}
+		}
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+		return &m, nil
+	case ScheduledJob:
+		m := ScheduledJobManifest{}
+		if err := doc.Decode(&m); err != nil {
+			return nil, &ErrUnmarshalScheduledJobManifest{parent: err}
+		}
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+		return &m, nil
+	case WorkerService:
+		m := WorkerServiceManifest{}
+		if err := doc.Decode(&m); err != nil {
+			return nil, &ErrUnmarshalWorkerServiceManifest{parent: err}
+		}
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
 		return &m, nil
 	default:
 		return nil, &ErrInvalidAppManifestType{Type: am.Type}
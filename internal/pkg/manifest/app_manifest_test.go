@@ -4,9 +4,11 @@
 package manifest
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestCreate(t *testing.T) {
@@ -28,6 +30,36 @@ func TestCreate(t *testing.T) {
 				require.True(t, ok)
 			},
 		},
+		"backend application": {
+			inAppName:    "ChickenApp",
+			inAppType:    BackendApplication,
+			inDockerfile: "ChickenApp/Dockerfile",
+
+			requireCorrectType: func(t *testing.T, i interface{}) {
+				_, ok := i.(*BackendAppManifest)
+				require.True(t, ok)
+			},
+		},
+		"scheduled job": {
+			inAppName:    "ChickenJob",
+			inAppType:    ScheduledJob,
+			inDockerfile: "ChickenJob/Dockerfile",
+
+			requireCorrectType: func(t *testing.T, i interface{}) {
+				_, ok := i.(*ScheduledJobManifest)
+				require.True(t, ok)
+			},
+		},
+		"worker service": {
+			inAppName:    "ChickenWorker",
+			inAppType:    WorkerService,
+			inDockerfile: "ChickenWorker/Dockerfile",
+
+			requireCorrectType: func(t *testing.T, i interface{}) {
+				_, ok := i.(*WorkerServiceManifest)
+				require.True(t, ok)
+			},
+		},
 		"invalid app type": {
 			inAppName:    "CowApp",
 			inAppType:    "Cow App",
@@ -87,7 +119,7 @@ environments:
 				require.True(t, ok)
 				wantedManifest := &LBFargateManifest{
 					AppManifest: AppManifest{Name: "frontend", Type: LoadBalancedWebApplication},
-					Image:       ImageWithPort{AppImage: AppImage{Build: "frontend/Dockerfile"}, Port: 80},
+					Image:       ImageWithPort{AppImage: AppImage{Build: BuildArgsOrString{BuildString: "frontend/Dockerfile"}}, Port: 80},
 					LBFargateConfig: LBFargateConfig{
 						RoutingRule: RoutingRule{
 							Path: "*",
@@ -99,8 +131,8 @@ environments:
 							Variables: map[string]string{
 								"LOG_LEVEL": "WARN",
 							},
-							Secrets: map[string]string{
-								"DB_PASSWORD": "MYSQL_DB_PASSWORD",
+							Secrets: map[string]Secret{
+								"DB_PASSWORD": {From: "MYSQL_DB_PASSWORD"},
 							},
 						},
 						Scaling: &AutoScalingConfig{
@@ -120,6 +152,212 @@ environments:
 				require.Equal(t, wantedManifest, actualManifest)
 			},
 		},
+		"backend application": {
+			inContent: `
+name: subscribers
+type: "Backend App"
+image:
+  build: subscribers/Dockerfile
+  port: 8080
+cpu: 256
+memory: 512
+count: 1
+variables:
+  LOG_LEVEL: "WARN"
+environments:
+  prod:
+    count: 2
+`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*BackendAppManifest)
+				require.True(t, ok)
+				wantedManifest := &BackendAppManifest{
+					AppManifest: AppManifest{Name: "subscribers", Type: BackendApplication},
+					Image:       ImageWithPort{AppImage: AppImage{Build: BuildArgsOrString{BuildString: "subscribers/Dockerfile"}}, Port: 8080},
+					BackendAppConfig: BackendAppConfig{
+						ContainersConfig: ContainersConfig{
+							CPU:    256,
+							Memory: 512,
+							Count:  1,
+							Variables: map[string]string{
+								"LOG_LEVEL": "WARN",
+							},
+						},
+					},
+					Environments: map[string]BackendAppConfig{
+						"prod": {
+							ContainersConfig: ContainersConfig{
+								Count: 2,
+							},
+						},
+					},
+				}
+				require.Equal(t, wantedManifest, actualManifest)
+			},
+		},
+		"scheduled job": {
+			inContent: `
+name: reports
+type: "Scheduled Job"
+image:
+  build: reports/Dockerfile
+schedule: "cron(0 3 * * ? *)"
+cpu: 256
+memory: 512
+count: 1
+retries: 2
+timeout: 1h
+environments:
+  test:
+    schedule: "rate(1 hour)"
+`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*ScheduledJobManifest)
+				require.True(t, ok)
+				wantedManifest := &ScheduledJobManifest{
+					AppManifest: AppManifest{Name: "reports", Type: ScheduledJob},
+					Image:       AppImage{Build: BuildArgsOrString{BuildString: "reports/Dockerfile"}},
+					ScheduledJobConfig: ScheduledJobConfig{
+						ContainersConfig: ContainersConfig{
+							CPU:    256,
+							Memory: 512,
+							Count:  1,
+						},
+						Schedule: "cron(0 3 * * ? *)",
+						Retries:  2,
+						Timeout:  "1h",
+					},
+					Environments: map[string]ScheduledJobConfig{
+						"test": {
+							Schedule: "rate(1 hour)",
+						},
+					},
+				}
+				require.Equal(t, wantedManifest, actualManifest)
+			},
+		},
+		"worker service": {
+			inContent: `
+name: thumbnails
+type: "Worker Service"
+image:
+  build: thumbnails/Dockerfile
+queue:
+  visibilityTimeout: 60
+cpu: 256
+memory: 512
+count: 1
+scaling:
+  minCount: 0
+  maxCount: 10
+  targetBacklog: 50
+environments:
+  prod:
+    scaling:
+      maxCount: 20
+`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*WorkerServiceManifest)
+				require.True(t, ok)
+				wantedManifest := &WorkerServiceManifest{
+					AppManifest: AppManifest{Name: "thumbnails", Type: WorkerService},
+					Image:       AppImage{Build: BuildArgsOrString{BuildString: "thumbnails/Dockerfile"}},
+					WorkerServiceConfig: WorkerServiceConfig{
+						ContainersConfig: ContainersConfig{
+							CPU:    256,
+							Memory: 512,
+							Count:  1,
+						},
+						Queue: QueueConfig{
+							VisibilityTimeout: 60,
+						},
+						Scaling: &QueueScalingConfig{
+							MinCount:      0,
+							MaxCount:      10,
+							TargetBacklog: 50,
+						},
+					},
+					Environments: map[string]WorkerServiceConfig{
+						"prod": {
+							Scaling: &QueueScalingConfig{
+								MaxCount: 20,
+							},
+						},
+					},
+				}
+				require.Equal(t, wantedManifest, actualManifest)
+			},
+		},
+		"load balanced web application with sidecar port conflict in an environment": {
+			inContent: `
+name: frontend
+type: "Load Balanced Web App"
+image:
+  build: frontend/Dockerfile
+  port: 80
+sidecars:
+  nginx:
+    image: nginx:latest
+    port: 8080
+environments:
+  prod:
+    sidecars:
+      nginx:
+        port: 80
+`,
+			wantedErr: fmt.Errorf("environment prod: %w", &ErrSidecarPortConflict{Name: "nginx", Port: 80, Container: "frontend"}),
+		},
+		"scheduled job with invalid schedule": {
+			inContent: `
+name: reports
+type: "Scheduled Job"
+image:
+  build: reports/Dockerfile
+schedule: "every night"
+`,
+			wantedErr: &ErrInvalidSchedule{Schedule: "every night"},
+		},
+		"scheduled job with invalid timeout override": {
+			inContent: `
+name: reports
+type: "Scheduled Job"
+image:
+  build: reports/Dockerfile
+schedule: "rate(1 day)"
+environments:
+  prod:
+    timeout: 48h
+`,
+			wantedErr: &ErrInvalidJobTimeout{Timeout: "48h"},
+		},
+		"backend application with unknown secret backend in an environment": {
+			inContent: `
+name: api
+type: "Backend App"
+image:
+  build: api/Dockerfile
+  port: 8080
+environments:
+  prod:
+    secrets:
+      API_KEY:
+        from: api-key
+        backend: vault
+`,
+			wantedErr: fmt.Errorf("environment prod: %w", &ErrInvalidSecretBackend{Name: "API_KEY", Backend: "vault"}),
+		},
+		"worker service with invalid scaling": {
+			inContent: `
+name: thumbnails
+type: "Worker Service"
+image:
+  build: thumbnails/Dockerfile
+scaling:
+  minCount: 3
+  maxCount: 1
+`,
+			wantedErr: &ErrInvalidScaling{MinCount: 3, MaxCount: 1},
+		},
 		"invalid app type": {
 			inContent: `
 name: CowApp
@@ -141,3 +379,94 @@ type: 'OH NO'
 		})
 	}
 }
+
+func TestBuildArgsOrString_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent string
+
+		wanted BuildArgsOrString
+	}{
+		"path to the Dockerfile": {
+			inContent: `build: frontend/Dockerfile`,
+			wanted: BuildArgsOrString{
+				BuildString: "frontend/Dockerfile",
+			},
+		},
+		"build options": {
+			inContent: `build:
+  dockerfile: services/api/Dockerfile
+  context: .
+  target: release
+  args:
+    VERSION: 1.2.3
+  cache_from:
+    - api:latest
+`,
+			wanted: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: "services/api/Dockerfile",
+					Context:    ".",
+					Target:     "release",
+					Args:       map[string]string{"VERSION": "1.2.3"},
+					CacheFrom:  []string{"api:latest"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			var img AppImage
+			err := yaml.Unmarshal([]byte(tc.inContent), &img)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, img.Build)
+
+			out, err := yaml.Marshal(img)
+			require.NoError(t, err)
+			require.YAMLEq(t, tc.inContent, string(out))
+		})
+	}
+}
+
+func TestAppImage_BuildConfig(t *testing.T) {
+	testCases := map[string]struct {
+		in AppImage
+
+		wanted *DockerBuildArgs
+	}{
+		"pre-built image": {
+			in: AppImage{Location: "nginx:latest"},
+		},
+		"context of the Dockerfile path is its directory": {
+			in: AppImage{Build: BuildArgsOrString{BuildString: "frontend/Dockerfile"}},
+			wanted: &DockerBuildArgs{
+				Dockerfile: "frontend/Dockerfile",
+				Context:    "frontend",
+			},
+		},
+		"Dockerfile defaults to the one in the context": {
+			in: AppImage{Build: BuildArgsOrString{BuildArgs: DockerBuildArgs{Context: "frontend", Target: "release"}}},
+			wanted: &DockerBuildArgs{
+				Dockerfile: "frontend/Dockerfile",
+				Context:    "frontend",
+				Target:     "release",
+			},
+		},
+		"separate context directory": {
+			in: AppImage{Build: BuildArgsOrString{BuildArgs: DockerBuildArgs{Dockerfile: "services/api/Dockerfile", Context: "."}}},
+			wanted: &DockerBuildArgs{
+				Dockerfile: "services/api/Dockerfile",
+				Context:    ".",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.BuildConfig())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/aws/amazon-ecs-cli-v2/templates"
+)
+
+// BackendAppManifest holds the configuration to build a container image with an exposed port that is only
+// reachable from within the environment's VPC with AWS Fargate as the compute engine.
+type BackendAppManifest struct {
+	AppManifest      `yaml:",inline,omitempty"`
+	Image            ImageWithPort `yaml:",omitempty"`
+	BackendAppConfig `yaml:",inline,omitempty"`
+	Environments     map[string]BackendAppConfig `yaml:",omitempty"` // Fields to override per environment.
+}
+
+// BackendAppConfig represents a backend application with AWS Fargate as compute.
+type BackendAppConfig struct {
+	ContainersConfig `yaml:",inline,omitempty"`
+	Deployment       DeploymentConfig `yaml:"deployment,omitempty"`
+	Hooks            DeployHooks      `yaml:"hooks,omitempty"`
+}
+
+// NewBackendAppManifest creates a new backend application with an exposed port that has a single task
+// with minimal CPU and Memory thresholds.
+func NewBackendAppManifest(appName, dockerfile string, port int) *BackendAppManifest {
+	return &BackendAppManifest{
+		AppManifest: AppManifest{
+			Name: appName,
+			Type: BackendApplication,
+		},
+		Image: ImageWithPort{
+			AppImage: AppImage{
+				Build: BuildArgsOrString{
+					BuildString: dockerfile,
+				},
+			},
+			Port: port,
+		},
+		BackendAppConfig: BackendAppConfig{
+			ContainersConfig: ContainersConfig{
+				CPU:    256,
+				Memory: 512,
+				Count:  1,
+			},
+		},
+	}
+}
+
+// Marshal serializes the manifest object into a YAML document.
+func (m *BackendAppManifest) Marshal() ([]byte, error) {
+	box := templates.Box()
+	content, err := box.FindString("backend-service/manifest.yml")
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := template.New("template").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, *m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DockerfilePath returns the image build path.
+func (m BackendAppManifest) DockerfilePath() string {
+	return m.Image.dockerfilePath()
+}
+
+// BuildConfig returns the options to build the image with, or nil if the image isn't built from a Dockerfile.
+func (m BackendAppManifest) BuildConfig() *DockerBuildArgs {
+	return m.Image.BuildConfig()
+}
+
+// ImageLocation returns the URI of the pre-built image, or an empty string if the image is built from the Dockerfile.
+func (m BackendAppManifest) ImageLocation() string {
+	return m.Image.Location
+}
+
+// DeploymentConfig returns the settings of the application's deployments to the environment.
+func (m *BackendAppManifest) DeploymentConfig(envName string) DeploymentConfig {
+	return m.EnvConf(envName).Deployment
+}
+
+// DeployHooks returns the one-off tasks run while deploying the application to the environment.
+func (m *BackendAppManifest) DeployHooks(envName string) DeployHooks {
+	return m.EnvConf(envName).Hooks
+}
+
+// EnvConf returns the application configuration with environment overrides.
+// If the environment passed in does not have any overrides then we return the default values.
+func (m *BackendAppManifest) EnvConf(envName string) BackendAppConfig {
+	if _, ok := m.Environments[envName]; !ok {
+		return m.BackendAppConfig
+	}
+
+	// We don't want to modify the default settings, so deep copy into a "conf" variable.
+	envVars := make(map[string]string, len(m.Variables))
+	for k, v := range m.Variables {
+		envVars[k] = v
+	}
+	secrets := make(map[string]Secret, len(m.Secrets))
+	for k, v := range m.Secrets {
+		secrets[k] = v
+	}
+	conf := BackendAppConfig{
+		ContainersConfig: ContainersConfig{
+			CPU:       m.CPU,
+			Memory:    m.Memory,
+			Count:     m.Count,
+			Variables: envVars,
+			Secrets:   secrets,
+		},
+		Deployment: m.Deployment,
+		Hooks:      m.Hooks,
+	}
+
+	// Override with fields set in the environment.
+	target := m.Environments[envName]
+	if target.CPU != 0 {
+		conf.CPU = target.CPU
+	}
+	if target.Memory != 0 {
+		conf.Memory = target.Memory
+	}
+	if target.Count != 0 {
+		conf.Count = target.Count
+	}
+	for k, v := range target.Variables {
+		conf.Variables[k] = v
+	}
+	for k, v := range target.Secrets {
+		conf.Secrets[k] = v
+	}
+	conf.Deployment.override(target.Deployment)
+	conf.Hooks.override(target.Hooks)
+	return conf
+}
+
+// validate returns an error if the secrets of the default configuration or of any environment can't be deployed.
+func (m *BackendAppManifest) validate() error {
+	if err := validateSecrets(m.Secrets); err != nil {
+		return err
+	}
+	for envName := range m.Environments {
+		if err := validateSecrets(m.EnvConf(envName).Secrets); err != nil {
+			return fmt.Errorf("environment %s: %w", envName, err)
+		}
+	}
+	return nil
+}
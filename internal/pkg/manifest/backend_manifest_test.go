@@ -0,0 +1,178 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendAppManifest_Marshal(t *testing.T) {
+	// GIVEN
+	wantedContent := `# yaml-language-server: $schema=./app.schema.json
+# The manifest for the "subscribers" application.
+# Read the full specification for the "Backend App" type at:
+#  https://github.com/aws/amazon-ecs-cli-v2/wiki/Manifests#backend-app
+
+# Your application name will be used in naming your resources like log groups, services, etc.
+name: subscribers
+# The "architecture" of the application you're running.
+type: Backend App
+
+image:
+  # Path to your application's Dockerfile.
+  build: subscribers/Dockerfile
+  # Or, the options of the "docker build" command, with paths relative to the root of your workspace.
+  # Build args can take their value from your shell, for example to pass secrets from your CI system.
+  # build:
+  #   dockerfile: subscribers/Dockerfile
+  #   context: .
+  #   target: release
+  #   args:
+  #     NPM_TOKEN: ${NPM_TOKEN}
+  #   cache_from:
+  #     - 12345.dkr.ecr.us-west-2.amazonaws.com/project/app:latest
+  # Or, instead of building the Dockerfile, the URI of a pre-built image to deploy as is.
+  # location: nginx:latest
+  # Port exposed through your container, only reachable from within the environment's VPC.
+  port: 8080
+
+# Number of CPU units for the task.
+cpu: 256
+# Amount of memory in MiB used by the task.
+memory: 512
+# Number of tasks that should be running in your service.
+count: 1
+
+# Optional fields for more advanced use-cases.
+#
+#variables:                    # Pass environment variables as key value pairs.
+#  LOG_LEVEL: info
+#
+#secrets:                      # Pass secrets from AWS Systems Manager (SSM) Parameter Store.
+#  GITHUB_TOKEN: GITHUB_TOKEN  # The key is the name of the environment variable, the value is the name of the SSM parameter.
+
+# You can override any of the values defined above by environment.
+#environments:
+#  test:
+#    count: 2               # Number of tasks to run for the "test" environment.
+`
+	m := NewBackendAppManifest("subscribers", "subscribers/Dockerfile", 8080)
+
+	// WHEN
+	b, err := m.Marshal()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, wantedContent, strings.Replace(string(b), "\r\n", "\n", -1))
+}
+
+func TestBackendAppManifest_EnvConf(t *testing.T) {
+	testCases := map[string]struct {
+		inDefaultConfig  BackendAppConfig
+		inEnvNameToQuery string
+		inEnvOverride    map[string]BackendAppConfig
+
+		wantedConfig BackendAppConfig
+	}{
+		"with no existing environments": {
+			inDefaultConfig: BackendAppConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+
+			wantedConfig: BackendAppConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+			},
+		},
+		"with partial overrides": {
+			inDefaultConfig: BackendAppConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+					Variables: map[string]string{
+						"LOG_LEVEL":      "DEBUG",
+						"DDB_TABLE_NAME": "awards",
+					},
+					Secrets: map[string]Secret{
+						"GITHUB_TOKEN": {From: "1111"},
+					},
+				},
+				Deployment: DeploymentConfig{
+					Timeout: 300,
+				},
+				Hooks: DeployHooks{
+					PreDeploy:  &DeployHook{Command: []string{"./migrate"}},
+					PostDeploy: &DeployHook{Command: []string{"./smoke-test"}},
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+			inEnvOverride: map[string]BackendAppConfig{
+				"prod-iad": {
+					ContainersConfig: ContainersConfig{
+						Count: 3,
+						Variables: map[string]string{
+							"DDB_TABLE_NAME": "awards-prod",
+						},
+					},
+					Deployment: DeploymentConfig{
+						Timeout: 900,
+					},
+					Hooks: DeployHooks{
+						PostDeploy: &DeployHook{Command: []string{"./smoke-test", "--prod"}, Timeout: 60},
+					},
+				},
+			},
+
+			wantedConfig: BackendAppConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  3,
+					Variables: map[string]string{
+						"LOG_LEVEL":      "DEBUG",
+						"DDB_TABLE_NAME": "awards-prod",
+					},
+					Secrets: map[string]Secret{
+						"GITHUB_TOKEN": {From: "1111"},
+					},
+				},
+				Deployment: DeploymentConfig{
+					Timeout: 900,
+				},
+				Hooks: DeployHooks{
+					PreDeploy:  &DeployHook{Command: []string{"./migrate"}},
+					PostDeploy: &DeployHook{Command: []string{"./smoke-test", "--prod"}, Timeout: 60},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mft := &BackendAppManifest{
+				BackendAppConfig: tc.inDefaultConfig,
+				Environments:     tc.inEnvOverride,
+			}
+
+			// WHEN
+			conf := mft.EnvConf(tc.inEnvNameToQuery)
+
+			// THEN
+			require.Equal(t, tc.wantedConfig, conf, "returned configuration should have overrides from the environment")
+		})
+	}
+}
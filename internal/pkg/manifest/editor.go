@@ -0,0 +1,334 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keys of the manifest that are edited by commands.
+const (
+	VariablesKey = "variables"
+	SecretsKey   = "secrets"
+	DatabaseKey  = "database"
+
+	environmentsKey = "environments"
+)
+
+const defaultIndent = 2
+
+// Editor modifies the keys of a manifest document in place.
+// Unlike unmarshaling the manifest into a struct and marshaling it back, only the keys that are set or deleted
+// change: comments, key ordering and the formatting of the rest of the document are preserved.
+type Editor struct {
+	original []byte
+	doc      yaml.Node
+	indent   int
+	migrated bool
+}
+
+// NewEditor parses the manifest document so that it can be edited.
+// Manifests written by older versions of the CLI are migrated in memory, see Migrated.
+func NewEditor(in []byte) (*Editor, error) {
+	e := &Editor{original: in}
+	if err := yaml.Unmarshal(in, &e.doc); err != nil {
+		return nil, &ErrUnmarshalAppManifest{parent: err}
+	}
+	if e.doc.Kind != yaml.DocumentNode || len(e.doc.Content) == 0 {
+		return nil, &ErrUnmarshalAppManifest{parent: fmt.Errorf("document is empty")}
+	}
+	root := e.doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, &ErrUnmarshalAppManifest{parent: fmt.Errorf("line %d: document must be a mapping", root.Line)}
+	}
+	e.indent = detectIndent(root)
+	migrated, err := migrateDatabasePassword(e)
+	if err != nil {
+		return nil, err
+	}
+	e.migrated = migrated
+	return e, nil
+}
+
+// Migrated returns whether the document was written by an older version of the CLI and was updated while parsed.
+// The workspace is left untouched until the edited document is written back.
+func (e *Editor) Migrated() bool {
+	return e.migrated
+}
+
+// EnvPath returns the path to the keys under an environment's overrides.
+// If envName is empty, the path of the default configuration is returned.
+func EnvPath(envName string, path ...string) []string {
+	if envName == "" {
+		return path
+	}
+	return append([]string{environmentsKey, envName}, path...)
+}
+
+// Get returns the scalar value at path, and whether it exists.
+func (e *Editor) Get(path ...string) (string, bool) {
+	node := e.lookup(path)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// Keys returns the keys of the mapping at path in the order they are written.
+func (e *Editor) Keys(path ...string) []string {
+	node := e.lookup(path)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var keys []string
+	for i := 0; i < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// Set writes the scalar value at path, creating any missing parent mappings.
+// The value keeps the style of the value it replaces, if any.
+func (e *Editor) Set(value string, path ...string) error {
+	return e.set(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, path)
+}
+
+// SetInt writes the integer value at path, creating any missing parent mappings.
+func (e *Editor) SetInt(value int, path ...string) error {
+	return e.set(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: fmt.Sprintf("%d", value)}, path)
+}
+
+// GetSecret returns the secret at path, written either as the name of an SSM parameter or as a mapping,
+// and whether it exists.
+func (e *Editor) GetSecret(path ...string) (Secret, bool) {
+	node := e.lookup(path)
+	if node == nil || isNull(node) {
+		return Secret{}, false
+	}
+	var secret Secret
+	if err := node.Decode(&secret); err != nil {
+		return Secret{}, false
+	}
+	return secret, true
+}
+
+// SetSecret writes the secret at path. Secrets stored in SSM are written as the name of their parameter,
+// the others as a mapping with their backend.
+func (e *Editor) SetSecret(secret Secret, path ...string) error {
+	if secret.Backend == "" || secret.Backend == SecretBackendSSM {
+		return e.Set(secret.From, path...)
+	}
+	str := func(value string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	}
+	return e.set(&yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+		Content: []*yaml.Node{
+			str("from"), str(secret.From),
+			str("backend"), str(secret.Backend),
+		},
+	}, path)
+}
+
+// Delete removes the key at path. Parent mappings that are left empty are removed as well.
+// Deleting a key that doesn't exist is a no-op.
+func (e *Editor) Delete(path ...string) {
+	if len(path) == 0 {
+		return
+	}
+	parents := []*yaml.Node{e.root()}
+	for _, key := range path[:len(path)-1] {
+		_, value := findKey(parents[len(parents)-1], key)
+		if value == nil || value.Kind != yaml.MappingNode {
+			return
+		}
+		parents = append(parents, value)
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		parent := parents[i]
+		if !removeKey(parent, path[i]) {
+			return
+		}
+		if len(parent.Content) > 0 || i == 0 {
+			return
+		}
+	}
+}
+
+// Marshal serializes the edited document.
+func (e *Editor) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(e.indent)
+	if err := enc.Encode(&e.doc); err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	return restoreBlankLines(e.original, buf.Bytes()), nil
+}
+
+func (e *Editor) root() *yaml.Node {
+	return e.doc.Content[0]
+}
+
+func (e *Editor) lookup(path []string) *yaml.Node {
+	node := e.root()
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		_, node = findKey(node, key)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func (e *Editor) set(value *yaml.Node, path []string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("set manifest value: path is empty")
+	}
+	node := e.root()
+	for i, key := range path[:len(path)-1] {
+		_, child := findKey(node, key)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			appendKey(node, key, child)
+		}
+		if isNull(child) {
+			// A key such as "variables:" without any values underneath it.
+			child.Kind, child.Tag, child.Value = yaml.MappingNode, "!!map", ""
+		}
+		if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("set manifest value: %s is not a mapping", strings.Join(path[:i+1], "."))
+		}
+		node = child
+	}
+	key := path[len(path)-1]
+	if keyNode, old := findKey(node, key); old != nil {
+		if old.Kind == yaml.ScalarNode && !isNull(old) && value.Kind == yaml.ScalarNode {
+			value.Style = old.Style
+		}
+		value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+		if value.Kind == yaml.MappingNode && keyNode.LineComment == "" {
+			// The comment at the end of a scalar's line stays on the key's line once the value spans several lines.
+			keyNode.LineComment, value.LineComment = value.LineComment, ""
+		}
+		*old = *value
+		return nil
+	}
+	appendKey(node, key, value)
+	return nil
+}
+
+func findKey(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// appendKey adds the key to the end of the mapping.
+// If the mapping is a flow mapping such as "{}", it's turned into a block mapping.
+func appendKey(mapping *yaml.Node, key string, value *yaml.Node) {
+	mapping.Style &^= yaml.FlowStyle
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// removeKey deletes the key, along with the comments attached to it, from the mapping.
+// It returns true if the key existed.
+func removeKey(mapping *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+		return true
+	}
+	return false
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// detectIndent returns the number of spaces used to indent nested mappings in the document.
+func detectIndent(root *yaml.Node) int {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if value.Kind != yaml.MappingNode || value.Style&yaml.FlowStyle != 0 || len(value.Content) == 0 {
+			continue
+		}
+		if indent := value.Content[0].Column - key.Column; indent > 0 {
+			return indent
+		}
+	}
+	return defaultIndent
+}
+
+// restoreBlankLines adds back the blank lines of the original document that the encoder drops.
+// Lines of the output that match a line of the original document, in order, are preceded by the same
+// number of blank lines as in the original. Lines that were added by an edit are not preceded by any.
+func restoreBlankLines(original, out []byte) []byte {
+	origLines, origBlanks := splitNonBlankLines(original)
+	outLines, _ := splitNonBlankLines(out)
+
+	// lcs[i][j] holds the length of the longest common subsequence of origLines[i:] and outLines[j:].
+	lcs := make([][]int, len(origLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(outLines)+1)
+	}
+	for i := len(origLines) - 1; i >= 0; i-- {
+		for j := len(outLines) - 1; j >= 0; j-- {
+			switch {
+			case origLines[i] == outLines[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	i := 0
+	for j, line := range outLines {
+		for i < len(origLines) && origLines[i] != line && lcs[i+1][j] >= lcs[i][j+1] {
+			i++
+		}
+		if i < len(origLines) && origLines[i] == line && lcs[i][j] == lcs[i+1][j+1]+1 {
+			buf.WriteString(strings.Repeat("\n", origBlanks[i]))
+			i++
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// splitNonBlankLines returns the non-blank lines of the document along with the number of blank lines preceding each.
+func splitNonBlankLines(doc []byte) (lines []string, blanks []int) {
+	count := 0
+	for _, line := range strings.Split(strings.TrimRight(string(doc), "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			count++
+			continue
+		}
+		lines = append(lines, line)
+		blanks = append(blanks, count)
+		count = 0
+	}
+	return lines, blanks
+}
@@ -0,0 +1,209 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const editorTestManifest = `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+  DB_NAME: frontenddb
+
+secrets:
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    variables:
+      LOG_LEVEL: warn
+`
+
+func TestEditor(t *testing.T) {
+	testCases := map[string]struct {
+		edit func(e *Editor) error
+
+		wantedContent string
+	}{
+		"no edits": {
+			edit: func(e *Editor) error { return nil },
+
+			wantedContent: editorTestManifest,
+		},
+		"sets values without touching the rest of the document": {
+			edit: func(e *Editor) error {
+				if err := e.Set("debug", "variables", "LOG_LEVEL"); err != nil {
+					return err
+				}
+				if err := e.Set("/secrets/github", "secrets", "GITHUB_TOKEN"); err != nil {
+					return err
+				}
+				if err := e.SetInt(2, EnvPath("prod", "count")...); err != nil {
+					return err
+				}
+				if err := e.SetSecret(Secret{From: "frontend-database", Backend: SecretBackendSecretsManager}, "secrets", "DB_PASSWORD"); err != nil {
+					return err
+				}
+				return e.Set("1", EnvPath("test", "variables", "DEBUG")...)
+			},
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: debug # Overridden in prod.
+  DB_NAME: frontenddb
+
+secrets:
+  GITHUB_TOKEN: /secrets/github
+  DB_PASSWORD:
+    from: frontend-database
+    backend: secretsmanager
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    variables:
+      LOG_LEVEL: warn
+    count: 2
+  test:
+    variables:
+      DEBUG: "1"
+`,
+		},
+		"deletes values and prunes empty mappings": {
+			edit: func(e *Editor) error {
+				e.Delete("variables", "DB_NAME")
+				e.Delete("secrets", "DB_PASSWORD")
+				e.Delete(EnvPath("prod", "variables", "LOG_LEVEL")...)
+				return nil
+			},
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+# Pass environment variables as key value pairs.
+variables:
+  LOG_LEVEL: info # Overridden in prod.
+
+secrets:
+`,
+		},
+		"deletes a key along with its comments": {
+			edit: func(e *Editor) error {
+				e.Delete("variables")
+				return nil
+			},
+
+			wantedContent: `# The manifest for the "frontend" application.
+name: frontend
+type: Load Balanced Web App
+
+image:
+  # Path to your application's Dockerfile.
+  build: frontend/Dockerfile
+  port: 80
+
+secrets:
+
+# You can override any of the values defined above by environment.
+environments:
+  prod:
+    variables:
+      LOG_LEVEL: warn
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			e, err := NewEditor([]byte(editorTestManifest))
+			require.NoError(t, err)
+
+			// WHEN
+			require.NoError(t, tc.edit(e))
+			out, err := e.Marshal()
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, string(out))
+		})
+	}
+}
+
+func TestEditor_Read(t *testing.T) {
+	e, err := NewEditor([]byte(editorTestManifest))
+	require.NoError(t, err)
+
+	value, ok := e.Get(EnvPath("prod", "variables", "LOG_LEVEL")...)
+	require.True(t, ok)
+	require.Equal(t, "warn", value)
+
+	_, ok = e.Get("image")
+	require.False(t, ok)
+
+	require.Equal(t, []string{"LOG_LEVEL", "DB_NAME"}, e.Keys("variables"))
+	require.Nil(t, e.Keys("secrets"))
+	require.Nil(t, e.Keys(EnvPath("test", "variables")...))
+
+	require.NoError(t, e.SetSecret(Secret{From: "frontend-database", Backend: SecretBackendSecretsManager}, "secrets", "DB_PASSWORD"))
+	secret, ok := e.GetSecret("secrets", "DB_PASSWORD")
+	require.True(t, ok)
+	require.Equal(t, Secret{From: "frontend-database", Backend: SecretBackendSecretsManager}, secret)
+
+	_, ok = e.GetSecret("secrets", "GITHUB_TOKEN")
+	require.False(t, ok)
+}
+
+func TestEditor_SetNonMapping(t *testing.T) {
+	e, err := NewEditor([]byte(editorTestManifest))
+	require.NoError(t, err)
+
+	err = e.Set("value", "image", "build", "context")
+
+	require.EqualError(t, err, "set manifest value: image.build is not a mapping")
+}
+
+func TestNewEditor(t *testing.T) {
+	testCases := map[string]string{
+		"malformed yaml":     "name: [",
+		"empty document":     "",
+		"document is a list": "- name: frontend",
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewEditor([]byte(content))
+
+			var unmarshalErr *ErrUnmarshalAppManifest
+			require.True(t, errors.As(err, &unmarshalErr), "expected ErrUnmarshalAppManifest, got %v", err)
+		})
+	}
+}
@@ -5,6 +5,7 @@ package manifest
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ErrInvalidAppManifestType occurs when a user requested a manifest template type that doesn't exist.
@@ -78,3 +79,186 @@ func (e *ErrUnmarshalLBFargateManifest) Is(target error) bool {
 	_, ok := target.(*ErrUnmarshalLBFargateManifest)
 	return ok
 }
+
+// ErrUnmarshalBackendAppManifest occurs if a byte stream cannot be unmarshalled into a backend application manifest.
+type ErrUnmarshalBackendAppManifest struct {
+	parent error
+}
+
+func (e *ErrUnmarshalBackendAppManifest) Error() string {
+	return fmt.Sprintf("unmarshal to backend application: %v", e.parent)
+}
+
+func (e *ErrUnmarshalBackendAppManifest) Is(target error) bool {
+	_, ok := target.(*ErrUnmarshalBackendAppManifest)
+	return ok
+}
+
+// ErrUnmarshalScheduledJobManifest occurs if a byte stream cannot be unmarshalled into a scheduled job manifest.
+type ErrUnmarshalScheduledJobManifest struct {
+	parent error
+}
+
+func (e *ErrUnmarshalScheduledJobManifest) Error() string {
+	return fmt.Sprintf("unmarshal to scheduled job: %v", e.parent)
+}
+
+func (e *ErrUnmarshalScheduledJobManifest) Is(target error) bool {
+	_, ok := target.(*ErrUnmarshalScheduledJobManifest)
+	return ok
+}
+
+// ErrInvalidSchedule occurs when a scheduled job's schedule is not a "cron(...)" or "rate(...)" expression.
+type ErrInvalidSchedule struct {
+	Schedule string
+}
+
+func (e *ErrInvalidSchedule) Error() string {
+	return fmt.Sprintf(`invalid schedule "%s": must be a "cron(...)" or "rate(...)" expression`, e.Schedule)
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrInvalidSchedule) Is(target error) bool {
+	t, ok := target.(*ErrInvalidSchedule)
+	return ok && t.Schedule == e.Schedule
+}
+
+// ErrInvalidJobTimeout occurs when a scheduled job's timeout is not a duration between 1m and 24h.
+type ErrInvalidJobTimeout struct {
+	Timeout string
+	parent  error
+}
+
+func (e *ErrInvalidJobTimeout) Error() string {
+	if e.parent != nil {
+		return fmt.Sprintf(`invalid timeout "%s": %v`, e.Timeout, e.parent)
+	}
+	return fmt.Sprintf(`invalid timeout "%s": must be between %s and %s`, e.Timeout, minJobTimeout, maxJobTimeout)
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrInvalidJobTimeout) Is(target error) bool {
+	t, ok := target.(*ErrInvalidJobTimeout)
+	return ok && t.Timeout == e.Timeout
+}
+
+// ErrInvalidSecretBackend occurs when a secret is stored in a backend that is not one of SecretBackends.
+type ErrInvalidSecretBackend struct {
+	Name    string
+	Backend string
+}
+
+func (e *ErrInvalidSecretBackend) Error() string {
+	return fmt.Sprintf(`secret %s backend "%s" must be one of %s`, e.Name, e.Backend, strings.Join(SecretBackends, ", "))
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrInvalidSecretBackend) Is(target error) bool {
+	t, ok := target.(*ErrInvalidSecretBackend)
+	return ok && t.Name == e.Name && t.Backend == e.Backend
+}
+
+// ErrInvalidScaling occurs when the minimum number of tasks of a service is greater than its maximum.
+type ErrInvalidScaling struct {
+	MinCount int
+	MaxCount int
+}
+
+func (e *ErrInvalidScaling) Error() string {
+	return fmt.Sprintf("scaling minCount %d must be less than or equal to maxCount %d", e.MinCount, e.MaxCount)
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrInvalidScaling) Is(target error) bool {
+	t, ok := target.(*ErrInvalidScaling)
+	return ok && t.MinCount == e.MinCount && t.MaxCount == e.MaxCount
+}
+
+// ErrUnmarshalWorkerServiceManifest occurs if a byte stream cannot be unmarshalled into a worker service manifest.
+type ErrUnmarshalWorkerServiceManifest struct {
+	parent error
+}
+
+func (e *ErrUnmarshalWorkerServiceManifest) Error() string {
+	return fmt.Sprintf("unmarshal to worker service: %v", e.parent)
+}
+
+func (e *ErrUnmarshalWorkerServiceManifest) Is(target error) bool {
+	_, ok := target.(*ErrUnmarshalWorkerServiceManifest)
+	return ok
+}
+
+// ErrInvalidSidecar occurs when a sidecar container is misconfigured.
+type ErrInvalidSidecar struct {
+	Name   string
+	Reason string
+}
+
+func (e *ErrInvalidSidecar) Error() string {
+	return fmt.Sprintf("invalid sidecar %s: %s", e.Name, e.Reason)
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrInvalidSidecar) Is(target error) bool {
+	t, ok := target.(*ErrInvalidSidecar)
+	return ok && t.Name == e.Name && t.Reason == e.Reason
+}
+
+// ErrSidecarPortConflict occurs when a sidecar exposes a port that is already used by another container in the task.
+type ErrSidecarPortConflict struct {
+	Name      string
+	Port      int
+	Container string
+}
+
+func (e *ErrSidecarPortConflict) Error() string {
+	return fmt.Sprintf("sidecar %s port %d is already used by container %s", e.Name, e.Port, e.Container)
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrSidecarPortConflict) Is(target error) bool {
+	t, ok := target.(*ErrSidecarPortConflict)
+	return ok && t.Name == e.Name && t.Port == e.Port && t.Container == e.Container
+}
+
+// ErrInvalidAppManifest occurs when an application manifest has problems that would prevent it from being deployed.
+type ErrInvalidAppManifest struct {
+	Errors []*ValidationError
+}
+
+func (e *ErrInvalidAppManifest) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid application manifest:\n%s", strings.Join(msgs, "\n"))
+}
+
+func (e *ErrInvalidAppManifest) Is(target error) bool {
+	_, ok := target.(*ErrInvalidAppManifest)
+	return ok
+}
+
+// ErrUnresolvedReference occurs when a "${...}" reference in a manifest value can't be interpolated.
+type ErrUnresolvedReference struct {
+	Key       string // Path to the value in the manifest, e.g. "environments.prod.variables.SUBNETS".
+	Reference string
+	parent    error
+}
+
+func (e *ErrUnresolvedReference) Error() string {
+	return fmt.Sprintf("cannot resolve %s in %s: %v", e.Reference, e.Key, e.parent)
+}
+
+// Is compares the 2 errors. Only returns true if the errors are of the same
+// type and contain the same information.
+func (e *ErrUnresolvedReference) Is(target error) bool {
+	t, ok := target.(*ErrUnresolvedReference)
+	return ok && t.Key == e.Key && t.Reference == e.Reference
+}
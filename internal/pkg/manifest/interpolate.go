@@ -0,0 +1,230 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in references that can be interpolated in manifest values.
+const (
+	projectNameRef = "project.name"
+	envNameRef     = "env.name"
+	appNameRef     = "app.name"
+
+	envOutputsRefPrefix = "env.outputs."
+)
+
+var (
+	// referencePattern matches "${...}" references in a manifest value.
+	referencePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+	// shellVarPattern matches the name of an environment variable from the shell.
+	shellVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// builtinRefPattern matches the dotted references such as "env.name" or "env.outputs.PrivateSubnets".
+	builtinRefPattern = regexp.MustCompile(`^(project|env|app)\.[A-Za-z0-9_.]+$`)
+)
+
+// Interpolator substitutes the "${...}" references in the values of an application manifest.
+//
+// The following references are supported:
+//
+//	${ENV_VAR}                  the value of an environment variable from the shell.
+//	${project.name}             the name of the project.
+//	${env.name}                 the name of the environment the application is packaged for.
+//	${app.name}                 the name of the application.
+//	${env.outputs.OutputName}   an output of the environment's CloudFormation stack, see ResolveEnvOutputs.
+//
+// Any other "${...}" expression, such as the CloudFormation pseudo parameter "${AWS::Region}", is left untouched.
+type Interpolator struct {
+	project string
+	env     string
+	app     string
+
+	lookupEnv func(key string) (string, bool)
+}
+
+// NewInterpolator returns an Interpolator for an application packaged for an environment of a project.
+func NewInterpolator(project, env, app string) *Interpolator {
+	return &Interpolator{
+		project:   project,
+		env:       env,
+		app:       app,
+		lookupEnv: os.LookupEnv,
+	}
+}
+
+// Interpolate substitutes the shell environment variables and built-in references in the manifest values.
+// References to the environment stack's outputs are kept so that they're resolved once the stack outputs are known.
+func (i *Interpolator) Interpolate(in []byte) ([]byte, error) {
+	// Go through the editor so that the interpolated manifest keeps the same lines as the original.
+	e, err := NewEditor(in)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := interpolateNode(&e.doc, "", i.resolve)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return in, nil
+	}
+	return e.Marshal()
+}
+
+func (i *Interpolator) resolve(ref string) (string, bool, error) {
+	switch {
+	case ref == projectNameRef:
+		return i.project, true, nil
+	case ref == envNameRef:
+		return i.env, true, nil
+	case ref == appNameRef:
+		return i.app, true, nil
+	case strings.HasPrefix(ref, envOutputsRefPrefix):
+		return "", false, nil
+	case builtinRefPattern.MatchString(ref):
+		return "", false, fmt.Errorf("unknown reference, must be one of %s, %s, %s or %s<OutputName>",
+			projectNameRef, envNameRef, appNameRef, envOutputsRefPrefix)
+	case shellVarPattern.MatchString(ref):
+		value, ok := i.lookupEnv(ref)
+		if !ok {
+			return "", false, fmt.Errorf("environment variable %s is not set", ref)
+		}
+		return value, true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// ReferencesEnvOutputs returns true if the manifest refers to any output of the environment's CloudFormation stack.
+func ReferencesEnvOutputs(in []byte) bool {
+	for _, match := range referencePattern.FindAllSubmatch(in, -1) {
+		if strings.HasPrefix(strings.TrimSpace(string(match[1])), envOutputsRefPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveEnvOutputs substitutes the "${env.outputs.OutputName}" references in the values of conf,
+// a pointer to an application configuration, with the outputs of the environment's CloudFormation stack.
+func ResolveEnvOutputs(conf interface{}, outputs map[string]string) error {
+	raw, err := yaml.Marshal(conf)
+	if err != nil {
+		return fmt.Errorf("marshal configuration: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("unmarshal configuration: %w", err)
+	}
+	changed, err := interpolateNode(&doc, "", func(ref string) (string, bool, error) {
+		if !strings.HasPrefix(ref, envOutputsRefPrefix) {
+			return "", false, nil
+		}
+		name := strings.TrimPrefix(ref, envOutputsRefPrefix)
+		value, ok := outputs[name]
+		if !ok {
+			return "", false, fmt.Errorf("environment stack has no output named %s", name)
+		}
+		return value, true, nil
+	})
+	if err != nil || !changed {
+		return err
+	}
+	if err := doc.Decode(conf); err != nil {
+		return fmt.Errorf("decode interpolated configuration: %w", err)
+	}
+	return nil
+}
+
+// resolveFunc returns the value of a reference, and true if the reference should be substituted.
+type resolveFunc func(ref string) (value string, ok bool, err error)
+
+// interpolateNode substitutes the references in the scalar values under the node.
+// It returns true if any value was modified.
+func interpolateNode(node *yaml.Node, path string, resolve resolveFunc) (bool, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		changed := false
+		for _, child := range node.Content {
+			c, err := interpolateNode(child, path, resolve)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+		return changed, nil
+	case yaml.MappingNode:
+		changed := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c, err := interpolateNode(node.Content[i+1], joinPath(path, node.Content[i].Value), resolve)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+		return changed, nil
+	case yaml.SequenceNode:
+		changed := false
+		for i, child := range node.Content {
+			c, err := interpolateNode(child, fmt.Sprintf("%s[%d]", path, i), resolve)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+		return changed, nil
+	case yaml.ScalarNode:
+		return interpolateScalar(node, path, resolve)
+	default:
+		return false, nil
+	}
+}
+
+func interpolateScalar(node *yaml.Node, path string, resolve resolveFunc) (bool, error) {
+	if !strings.Contains(node.Value, "${") {
+		return false, nil
+	}
+	var resolveErr error
+	changed := false
+	value := referencePattern.ReplaceAllStringFunc(node.Value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		ref := strings.TrimSpace(match[2 : len(match)-1])
+		resolved, ok, err := resolve(ref)
+		if err != nil {
+			resolveErr = &ErrUnresolvedReference{Key: path, Reference: match, parent: err}
+			return match
+		}
+		if !ok {
+			return match
+		}
+		changed = true
+		return resolved
+	})
+	if resolveErr != nil {
+		return false, resolveErr
+	}
+	if !changed {
+		return false, nil
+	}
+	node.Value = value
+	if node.Style == 0 {
+		// Let the type of unquoted values be resolved again so that "${PORT}" can become an integer.
+		node.Tag = ""
+	}
+	return true, nil
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
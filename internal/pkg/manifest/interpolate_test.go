@@ -0,0 +1,174 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolator_Interpolate(t *testing.T) {
+	testCases := map[string]struct {
+		in      string
+		shell   map[string]string
+		wanted  string
+		wantErr error
+	}{
+		"leaves a manifest without references untouched": {
+			in: `name: frontend
+type: Load Balanced Web App
+
+# The port exposed by the container.
+image:
+  port: 80
+`,
+			wanted: `name: frontend
+type: Load Balanced Web App
+
+# The port exposed by the container.
+image:
+  port: 80
+`,
+		},
+		"substitutes shell variables and built-ins": {
+			in: `name: frontend
+type: Load Balanced Web App
+
+image:
+  port: ${PORT} # From the shell.
+variables:
+  LOG_GROUP: /${project.name}/${env.name}/${app.name}
+  REGION: ${AWS::Region}
+  SUBNETS: ${env.outputs.PrivateSubnets}
+`,
+			shell: map[string]string{
+				"PORT": "8080",
+			},
+			wanted: `name: frontend
+type: Load Balanced Web App
+
+image:
+  port: 8080 # From the shell.
+variables:
+  LOG_GROUP: /phonetool/test/frontend
+  REGION: ${AWS::Region}
+  SUBNETS: ${env.outputs.PrivateSubnets}
+`,
+		},
+		"fails on unset shell variable": {
+			in: `name: frontend
+environments:
+  prod:
+    variables:
+      TOKEN: "${API_TOKEN}"
+`,
+			wantErr: &ErrUnresolvedReference{
+				Key:       "environments.prod.variables.TOKEN",
+				Reference: "${API_TOKEN}",
+			},
+		},
+		"fails on unknown built-in": {
+			in: `name: frontend
+variables:
+  OWNER: ${project.owner}
+`,
+			wantErr: &ErrUnresolvedReference{
+				Key:       "variables.OWNER",
+				Reference: "${project.owner}",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			i := NewInterpolator("phonetool", "test", "frontend")
+			i.lookupEnv = func(key string) (string, bool) {
+				v, ok := tc.shell[key]
+				return v, ok
+			}
+
+			// WHEN
+			out, err := i.Interpolate([]byte(tc.in))
+
+			// THEN
+			if tc.wantErr != nil {
+				require.True(t, errors.Is(err, tc.wantErr), "expected: %v, got: %v", tc.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, string(out))
+		})
+	}
+}
+
+func TestResolveEnvOutputs(t *testing.T) {
+	testCases := map[string]struct {
+		conf    LBFargateConfig
+		outputs map[string]string
+
+		wanted  LBFargateConfig
+		wantErr error
+	}{
+		"substitutes the outputs": {
+			conf: LBFargateConfig{
+				RoutingRule: RoutingRule{Path: "*"},
+				ContainersConfig: ContainersConfig{
+					Variables: map[string]string{
+						"SUBNETS": "${env.outputs.PrivateSubnets}",
+						"VPC":     "vpc=${ env.outputs.VpcId }",
+					},
+				},
+			},
+			outputs: map[string]string{
+				"PrivateSubnets": "subnet-1,subnet-2",
+				"VpcId":          "vpc-1",
+			},
+			wanted: LBFargateConfig{
+				RoutingRule: RoutingRule{Path: "*"},
+				ContainersConfig: ContainersConfig{
+					Variables: map[string]string{
+						"SUBNETS": "subnet-1,subnet-2",
+						"VPC":     "vpc=vpc-1",
+					},
+				},
+			},
+		},
+		"fails on missing output": {
+			conf: LBFargateConfig{
+				ContainersConfig: ContainersConfig{
+					Secrets: map[string]Secret{
+						"DB_SECRET": {From: "${env.outputs.DBSecret}"},
+					},
+				},
+			},
+			wantErr: &ErrUnresolvedReference{
+				Key:       "secrets.DB_SECRET",
+				Reference: "${env.outputs.DBSecret}",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := ResolveEnvOutputs(&tc.conf, tc.outputs)
+
+			// THEN
+			if tc.wantErr != nil {
+				require.True(t, errors.Is(err, tc.wantErr), "expected: %v, got: %v", tc.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, tc.conf)
+		})
+	}
+}
+
+func TestReferencesEnvOutputs(t *testing.T) {
+	require.True(t, ReferencesEnvOutputs([]byte("SUBNETS: ${env.outputs.PrivateSubnets}")))
+	require.False(t, ReferencesEnvOutputs([]byte("ENV: ${env.name}")))
+}
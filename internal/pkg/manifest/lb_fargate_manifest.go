@@ -5,6 +5,7 @@ package manifest
 
 import (
 	"bytes"
+	"fmt"
 	"text/template"
 
 	"github.com/aws/amazon-ecs-cli-v2/templates"
@@ -28,10 +29,13 @@ type ImageWithPort struct {
 // LBFargateConfig represents a load balanced web application with AWS Fargate as compute.
 type LBFargateConfig struct {
 	RoutingRule      `yaml:"http,omitempty"`
-	HealthCheck      HealthCheck        `yaml:"healthcheck,omitempty"`
+	HealthCheck      HealthCheck `yaml:"healthcheck,omitempty"`
 	ContainersConfig `yaml:",inline,omitempty"`
-	Database         *DatabaseConfig    `yaml:",omitempty"`
-	Scaling          *AutoScalingConfig `yaml:",omitempty"`
+	Database         *DatabaseConfig           `yaml:",omitempty"`
+	Scaling          *AutoScalingConfig        `yaml:",omitempty"`
+	Sidecars         map[string]*SidecarConfig `yaml:"sidecars,omitempty"`
+	Deployment       DeploymentConfig          `yaml:"deployment,omitempty"`
+	Hooks            DeployHooks               `yaml:"hooks,omitempty"`
 }
 
 // ContainersConfig represents the resource boundaries and environment variables for the containers in the service.
@@ -40,7 +44,7 @@ type ContainersConfig struct {
 	Memory    int               `yaml:"memory,omitempty"`
 	Count     int               `yaml:"count,omitempty"`
 	Variables map[string]string `yaml:"variables,omitempty"`
-	Secrets   map[string]string `yaml:"secrets,omitempty"`
+	Secrets   map[string]Secret `yaml:"secrets,omitempty"`
 }
 
 // DatabaseConfig represents the resource boundaries for the database in the service.
@@ -49,16 +53,56 @@ type DatabaseConfig struct {
 
 	MinCapacity int `yaml:"minCapacity,omitempty"`
 	MaxCapacity int `yaml:"maxCapacity,omitempty"`
+
+	// RotationDays is the number of days after which the master password is rotated, it is never rotated if 0.
+	RotationDays int `yaml:"rotationDays,omitempty"`
 }
 
 // HealthCheck holds the health check info for the service.
+// The load balancer's target group checks the path, while the container check runs a command inside the task.
 type HealthCheck struct {
-	Path string `yaml:"path,omitempty"`
+	Path               string                `yaml:"path,omitempty"`
+	HealthyThreshold   int                   `yaml:"healthyThreshold,omitempty"`
+	UnhealthyThreshold int                   `yaml:"unhealthyThreshold,omitempty"`
+	Interval           int                   `yaml:"interval,omitempty"`     // In seconds.
+	Timeout            int                   `yaml:"timeout,omitempty"`      // In seconds.
+	SuccessCodes       string                `yaml:"successCodes,omitempty"` // HTTP codes such as "200" or "200-299".
+	GracePeriod        int                   `yaml:"gracePeriod,omitempty"`  // In seconds, before failed checks stop a new task.
+	Container          *ContainerHealthCheck `yaml:"container,omitempty"`
+}
+
+// ContainerHealthCheck holds the command that ECS runs inside the application's container to check that it's healthy.
+type ContainerHealthCheck struct {
+	Command     []string `yaml:"command,omitempty"`  // Starts with "CMD" or "CMD-SHELL".
+	Interval    int      `yaml:"interval,omitempty"` // In seconds.
+	Timeout     int      `yaml:"timeout,omitempty"`  // In seconds.
+	Retries     int      `yaml:"retries,omitempty"`
+	StartPeriod int      `yaml:"startPeriod,omitempty"` // In seconds.
 }
 
-// RoutingRule holds the path to route requests to the service.
+// RoutingRule holds the path to route requests to the service, along with any additional rules.
 type RoutingRule struct {
-	Path string `yaml:"path,omitempty"`
+	Path  string  `yaml:"path,omitempty"`
+	Rules []Route `yaml:"rules,omitempty"`
+}
+
+// Route holds the conditions that a request must match to be forwarded to the service.
+// Each route is a separate listener rule of the load balancer. Routes are also serialized
+// to JSON in the outputs of the application's stack.
+type Route struct {
+	Paths        []string            `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Hosts        []string            `yaml:"hosts,omitempty" json:"hosts,omitempty"`
+	Headers      map[string][]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	QueryStrings map[string]string   `yaml:"queryStrings,omitempty" json:"queryStrings,omitempty"`
+}
+
+// Routes returns the routes to the service, starting with the one matching Path if it's set.
+func (r RoutingRule) Routes() []Route {
+	var routes []Route
+	if r.Path != "" {
+		routes = append(routes, Route{Paths: []string{r.Path}})
+	}
+	return append(routes, r.Rules...)
 }
 
 // AutoScalingConfig is the configuration to scale the service with target tracking scaling policies.
@@ -80,7 +124,9 @@ func NewLoadBalancedFargateManifest(appName, dockerfile string, port int) *LBFar
 		},
 		Image: ImageWithPort{
 			AppImage: AppImage{
-				Build: dockerfile,
+				Build: BuildArgsOrString{
+					BuildString: dockerfile,
+				},
 			},
 			Port: port,
 		},
@@ -120,7 +166,27 @@ func (m *LBFargateManifest) Marshal() ([]byte, error) {
 
 // DockerfilePath returns the image build path.
 func (m LBFargateManifest) DockerfilePath() string {
-	return m.Image.Build
+	return m.Image.dockerfilePath()
+}
+
+// BuildConfig returns the options to build the image with, or nil if the image isn't built from a Dockerfile.
+func (m LBFargateManifest) BuildConfig() *DockerBuildArgs {
+	return m.Image.BuildConfig()
+}
+
+// ImageLocation returns the URI of the pre-built image, or an empty string if the image is built from the Dockerfile.
+func (m LBFargateManifest) ImageLocation() string {
+	return m.Image.Location
+}
+
+// DeploymentConfig returns the settings of the application's deployments to the environment.
+func (m *LBFargateManifest) DeploymentConfig(envName string) DeploymentConfig {
+	return m.EnvConf(envName).Deployment
+}
+
+// DeployHooks returns the one-off tasks run while deploying the application to the environment.
+func (m *LBFargateManifest) DeployHooks(envName string) DeployHooks {
+	return m.EnvConf(envName).Hooks
 }
 
 // EnvConf returns the application configuration with environment overrides.
@@ -135,7 +201,7 @@ func (m *LBFargateManifest) EnvConf(envName string) LBFargateConfig {
 	for k, v := range m.Variables {
 		envVars[k] = v
 	}
-	secrets := make(map[string]string, len(m.Secrets))
+	secrets := make(map[string]Secret, len(m.Secrets))
 	for k, v := range m.Secrets {
 		secrets[k] = v
 	}
@@ -151,18 +217,18 @@ func (m *LBFargateManifest) EnvConf(envName string) LBFargateConfig {
 	var database *DatabaseConfig
 	if m.Database != nil {
 		database = &DatabaseConfig{
-			Engine:      m.Database.Engine,
-			MinCapacity: m.Database.MinCapacity,
-			MaxCapacity: m.Database.MaxCapacity,
+			Engine:       m.Database.Engine,
+			MinCapacity:  m.Database.MinCapacity,
+			MaxCapacity:  m.Database.MaxCapacity,
+			RotationDays: m.Database.RotationDays,
 		}
 	}
 	conf := LBFargateConfig{
 		RoutingRule: RoutingRule{
-			Path: m.Path,
-		},
-		HealthCheck: HealthCheck{
-			Path: m.HealthCheck.Path,
+			Path:  m.Path,
+			Rules: m.Rules,
 		},
+		HealthCheck: m.HealthCheck.copy(),
 		ContainersConfig: ContainersConfig{
 			CPU:       m.CPU,
 			Memory:    m.Memory,
@@ -170,8 +236,11 @@ func (m *LBFargateManifest) EnvConf(envName string) LBFargateConfig {
 			Variables: envVars,
 			Secrets:   secrets,
 		},
-		Database: database,
-		Scaling:  scaling,
+		Database:   database,
+		Scaling:    scaling,
+		Sidecars:   copySidecars(m.Sidecars),
+		Deployment: m.Deployment,
+		Hooks:      m.Hooks,
 	}
 
 	// Override with fields set in the environment.
@@ -179,9 +248,11 @@ func (m *LBFargateManifest) EnvConf(envName string) LBFargateConfig {
 	if target.RoutingRule.Path != "" {
 		conf.RoutingRule.Path = target.RoutingRule.Path
 	}
-	if target.HealthCheck.Path != "" {
-		conf.HealthCheck.Path = target.HealthCheck.Path
+	if target.RoutingRule.Rules != nil {
+		// The rules of an environment replace the default ones, since listener rules can't be merged.
+		conf.RoutingRule.Rules = target.RoutingRule.Rules
 	}
+	conf.HealthCheck.override(target.HealthCheck)
 	if target.CPU != 0 {
 		conf.CPU = target.CPU
 	}
@@ -227,10 +298,95 @@ func (m *LBFargateManifest) EnvConf(envName string) LBFargateConfig {
 		if target.Database.Engine != "" {
 			conf.Database.Engine = target.Database.Engine
 		}
+		if target.Database.RotationDays != 0 {
+			conf.Database.RotationDays = target.Database.RotationDays
+		}
 	}
+	conf.Sidecars = overrideSidecars(conf.Sidecars, target.Sidecars)
+	conf.Deployment.override(target.Deployment)
+	conf.Hooks.override(target.Hooks)
 	return conf
 }
 
+func (h HealthCheck) copy() HealthCheck {
+	if h.Container != nil {
+		container := *h.Container
+		container.Command = append([]string(nil), h.Container.Command...)
+		h.Container = &container
+	}
+	return h
+}
+
+// override replaces the fields of the health check with the ones set in target.
+func (h *HealthCheck) override(target HealthCheck) {
+	if target.Path != "" {
+		h.Path = target.Path
+	}
+	if target.HealthyThreshold != 0 {
+		h.HealthyThreshold = target.HealthyThreshold
+	}
+	if target.UnhealthyThreshold != 0 {
+		h.UnhealthyThreshold = target.UnhealthyThreshold
+	}
+	if target.Interval != 0 {
+		h.Interval = target.Interval
+	}
+	if target.Timeout != 0 {
+		h.Timeout = target.Timeout
+	}
+	if target.SuccessCodes != "" {
+		h.SuccessCodes = target.SuccessCodes
+	}
+	if target.GracePeriod != 0 {
+		h.GracePeriod = target.GracePeriod
+	}
+	if target.Container == nil {
+		return
+	}
+	if h.Container == nil {
+		h.Container = &ContainerHealthCheck{}
+	}
+	if len(target.Container.Command) != 0 {
+		h.Container.Command = target.Container.Command
+	}
+	if target.Container.Interval != 0 {
+		h.Container.Interval = target.Container.Interval
+	}
+	if target.Container.Timeout != 0 {
+		h.Container.Timeout = target.Container.Timeout
+	}
+	if target.Container.Retries != 0 {
+		h.Container.Retries = target.Container.Retries
+	}
+	if target.Container.StartPeriod != 0 {
+		h.Container.StartPeriod = target.Container.StartPeriod
+	}
+}
+
+// validate returns an error if the secrets, the scaling or the sidecars of the default configuration
+// or of any environment can't be deployed.
+func (m *LBFargateManifest) validate() error {
+	if err := m.LBFargateConfig.validate(m.Name, m.Image.Port); err != nil {
+		return err
+	}
+	for envName := range m.Environments {
+		if err := m.EnvConf(envName).validate(m.Name, m.Image.Port); err != nil {
+			return fmt.Errorf("environment %s: %w", envName, err)
+		}
+	}
+	return nil
+}
+
+func (c LBFargateConfig) validate(appName string, appPort int) error {
+	if err := validateSecrets(c.Secrets); err != nil {
+		return err
+	}
+	if c.Scaling != nil && c.Scaling.MinCount > c.Scaling.MaxCount {
+		return &ErrInvalidScaling{MinCount: c.Scaling.MinCount, MaxCount: c.Scaling.MaxCount}
+	}
+	return validateSidecars(appName, appPort, c.Sidecars)
+}
+
 // CFNTemplate serializes the manifest object into a CloudFormation template.
 func (m *LBFargateManifest) CFNTemplate() (string, error) {
 	return "", nil
@@ -12,7 +12,8 @@ import (
 
 func TestLoadBalancedFargateManifest_Marshal(t *testing.T) {
 	// GIVEN
-	wantedContent := `# The manifest for the "frontend" application.
+	wantedContent := `# yaml-language-server: $schema=./app.schema.json
+# The manifest for the "frontend" application.
 # Read the full specification for the "Load Balanced Web App" type at:
 #  https://github.com/aws/amazon-ecs-cli-v2/wiki/Manifests#load-balanced-web-app
 
@@ -24,15 +25,46 @@ type: Load Balanced Web App
 image:
   # Path to your application's Dockerfile.
   build: frontend/Dockerfile
+  # Or, the options of the "docker build" command, with paths relative to the root of your workspace.
+  # Build args can take their value from your shell, for example to pass secrets from your CI system.
+  # build:
+  #   dockerfile: frontend/Dockerfile
+  #   context: .
+  #   target: release
+  #   args:
+  #     NPM_TOKEN: ${NPM_TOKEN}
+  #   cache_from:
+  #     - 12345.dkr.ecr.us-west-2.amazonaws.com/project/app:latest
+  # Or, instead of building the Dockerfile, the URI of a pre-built image to deploy as is.
+  # location: nginx:latest
   # Port exposed through your container to route traffic to it.
   port: 80
 
 http:
   # Requests to this path will be forwarded to your service.
   path: '*'
+  # You can add more routes to your service, each one is a separate rule of the load balancer.
+  #rules:
+  #  - paths: ['/v2/*']          # Path patterns of the requests.
+  #    hosts: ['api.example.com']  # Host headers of the requests.
+  #    headers:                  # HTTP headers of the requests.
+  #      X-Version: ['2']
+  #    queryStrings:             # Query string parameters of the requests.
+  #      version: v2
 
 healthcheck:
+  # Requests to this path must return a successful status code for the task to receive traffic.
   path: '/'
+  # Optional settings for applications that take a while to start or respond.
+  #healthyThreshold: 2         # Number of consecutive successful checks before the task is healthy.
+  #unhealthyThreshold: 2       # Number of consecutive failed checks before the task is unhealthy.
+  #interval: 10                # Seconds between two checks.
+  #timeout: 5                  # Seconds to wait for a response, must be less than the interval.
+  #successCodes: '200'         # HTTP codes of a healthy response, e.g. '200-299'.
+  #gracePeriod: 60             # Seconds to ignore failed checks after a task starts.
+  #container:                  # Command run inside the container to check that it's healthy.
+  #  command: ["CMD-SHELL", "curl -f http://localhost/ || exit 1"]
+  #  startPeriod: 30
 
 # Number of CPU units for the task.
 cpu: 512
@@ -55,6 +87,21 @@ count: 1
 #
 #  # If the target value is crossed, ECS starts adding or removing tasks.
 #  targetCPU: 75.0               # Target average CPU utilization percentage.
+#
+#sidecars:                     # Containers that run next to your application in the same task.
+#  nginx:
+#    image: nginx:latest         # Image URI of the sidecar container.
+#    port: 8080                  # Port exposed by the sidecar, must be different from your application's port.
+#    essential: false            # Stop the task if the sidecar stops, defaults to true.
+#    dependsOn:                  # Wait for other containers in the task before starting the sidecar.
+#      frontend: START
+#
+#hooks:                        # One-off tasks running your application's image while it's deployed.
+#  pre_deploy:                   # Runs with the new image before the service is updated, the deployment stops if it fails.
+#    command: ["./migrate"]
+#  post_deploy:                  # Runs once the new tasks reach a steady state.
+#    command: ["./smoke-test"]
+#    timeout: 300                # Seconds to wait for the task to stop, defaults to 600.
 
 # You can override any of the values defined above by environment.
 #environments:
@@ -110,9 +157,9 @@ func TestLBFargateManifest_EnvConf(t *testing.T) {
 						"LOG_LEVEL":      "DEBUG",
 						"DDB_TABLE_NAME": "awards",
 					},
-					Secrets: map[string]string{
-						"GITHUB_TOKEN": "1111",
-						"TWILIO_TOKEN": "1111",
+					Secrets: map[string]Secret{
+						"GITHUB_TOKEN": {From: "1111"},
+						"TWILIO_TOKEN": {From: "1111"},
 					},
 				},
 				Scaling: &AutoScalingConfig{
@@ -147,9 +194,9 @@ func TestLBFargateManifest_EnvConf(t *testing.T) {
 						"LOG_LEVEL":      "DEBUG",
 						"DDB_TABLE_NAME": "awards-prod",
 					},
-					Secrets: map[string]string{
-						"GITHUB_TOKEN": "1111",
-						"TWILIO_TOKEN": "1111",
+					Secrets: map[string]Secret{
+						"GITHUB_TOKEN": {From: "1111"},
+						"TWILIO_TOKEN": {From: "1111"},
 					},
 				},
 				Scaling: &AutoScalingConfig{
@@ -159,6 +206,111 @@ func TestLBFargateManifest_EnvConf(t *testing.T) {
 				},
 			},
 		},
+		"with health check overrides": {
+			inDefaultConfig: LBFargateConfig{
+				RoutingRule: RoutingRule{Path: "/awards/*"},
+				HealthCheck: HealthCheck{
+					Path:     "/health",
+					Interval: 10,
+					Timeout:  5,
+					Container: &ContainerHealthCheck{
+						Command: []string{"CMD-SHELL", "curl -f http://localhost/health || exit 1"},
+						Retries: 3,
+					},
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+			inEnvOverride: map[string]LBFargateConfig{
+				"prod-iad": {
+					HealthCheck: HealthCheck{
+						Interval:    30,
+						GracePeriod: 300,
+						Container: &ContainerHealthCheck{
+							StartPeriod: 120,
+						},
+					},
+				},
+			},
+
+			wantedConfig: LBFargateConfig{
+				RoutingRule: RoutingRule{Path: "/awards/*"},
+				HealthCheck: HealthCheck{
+					Path:        "/health",
+					Interval:    30,
+					Timeout:     5,
+					GracePeriod: 300,
+					Container: &ContainerHealthCheck{
+						Command:     []string{"CMD-SHELL", "curl -f http://localhost/health || exit 1"},
+						Retries:     3,
+						StartPeriod: 120,
+					},
+				},
+				ContainersConfig: ContainersConfig{
+					Variables: map[string]string{},
+					Secrets:   map[string]Secret{},
+				},
+			},
+		},
+		"with sidecar overrides": {
+			inDefaultConfig: LBFargateConfig{
+				RoutingRule: RoutingRule{Path: "/awards/*"},
+				ContainersConfig: ContainersConfig{
+					CPU:    1024,
+					Memory: 1024,
+					Count:  1,
+				},
+				Sidecars: map[string]*SidecarConfig{
+					"nginx": {
+						Image: "nginx:latest",
+						Port:  8080,
+						Variables: map[string]string{
+							"LOG_LEVEL": "DEBUG",
+						},
+					},
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+			inEnvOverride: map[string]LBFargateConfig{
+				"prod-iad": {
+					Sidecars: map[string]*SidecarConfig{
+						"nginx": {
+							Image: "nginx:1.17",
+							Variables: map[string]string{
+								"LOG_LEVEL": "WARN",
+							},
+						},
+						"xray": {
+							Image: "amazon/aws-xray-daemon",
+							Port:  2000,
+						},
+					},
+				},
+			},
+
+			wantedConfig: LBFargateConfig{
+				RoutingRule: RoutingRule{Path: "/awards/*"},
+				ContainersConfig: ContainersConfig{
+					CPU:       1024,
+					Memory:    1024,
+					Count:     1,
+					Variables: map[string]string{},
+					Secrets:   map[string]Secret{},
+				},
+				Sidecars: map[string]*SidecarConfig{
+					"nginx": {
+						Image: "nginx:1.17",
+						Port:  8080,
+						Variables: map[string]string{
+							"LOG_LEVEL": "WARN",
+						},
+					},
+					"xray": {
+						Image: "amazon/aws-xray-daemon",
+						Port:  2000,
+					},
+				},
+			},
+		},
 		"with complete override": {
 			inDefaultConfig: LBFargateConfig{
 				RoutingRule: RoutingRule{Path: "/awards/*"},
@@ -186,9 +338,9 @@ func TestLBFargateManifest_EnvConf(t *testing.T) {
 							"LOG_LEVEL":      "WARN",
 							"DDB_TABLE_NAME": "awards-prod",
 						},
-						Secrets: map[string]string{
-							"GITHUB_TOKEN": "2222",
-							"TWILIO_TOKEN": "2222",
+						Secrets: map[string]Secret{
+							"GITHUB_TOKEN": {From: "2222"},
+							"TWILIO_TOKEN": {From: "2222"},
 						},
 					},
 					Scaling: &AutoScalingConfig{
@@ -210,9 +362,9 @@ func TestLBFargateManifest_EnvConf(t *testing.T) {
 						"LOG_LEVEL":      "WARN",
 						"DDB_TABLE_NAME": "awards-prod",
 					},
-					Secrets: map[string]string{
-						"GITHUB_TOKEN": "2222",
-						"TWILIO_TOKEN": "2222",
+					Secrets: map[string]Secret{
+						"GITHUB_TOKEN": {From: "2222"},
+						"TWILIO_TOKEN": {From: "2222"},
 					},
 				},
 				Scaling: &AutoScalingConfig{
@@ -239,6 +391,53 @@ func TestLBFargateManifest_EnvConf(t *testing.T) {
 			// THEN
 			require.Equal(t, tc.wantedConfig, conf, "returned configuration should have overrides from the environment")
 			require.Equal(t, m.LBFargateConfig, tc.inDefaultConfig, "values in the default configuration should not be overwritten")
+			for name, sidecar := range m.Sidecars {
+				require.NotSame(t, sidecar, conf.Sidecars[name], "sidecars in the default configuration should not be shared")
+			}
+		})
+	}
+}
+
+func TestRoutingRule_Routes(t *testing.T) {
+	testCases := map[string]struct {
+		in     RoutingRule
+		wanted []Route
+	}{
+		"path only": {
+			in: RoutingRule{Path: "*"},
+			wanted: []Route{
+				{Paths: []string{"*"}},
+			},
+		},
+		"path and rules": {
+			in: RoutingRule{
+				Path: "/api/*",
+				Rules: []Route{
+					{Paths: []string{"/v2/*"}},
+					{Hosts: []string{"api.example.com"}},
+				},
+			},
+			wanted: []Route{
+				{Paths: []string{"/api/*"}},
+				{Paths: []string{"/v2/*"}},
+				{Hosts: []string{"api.example.com"}},
+			},
+		},
+		"rules only": {
+			in: RoutingRule{
+				Rules: []Route{
+					{Headers: map[string][]string{"X-Version": {"2"}}},
+				},
+			},
+			wanted: []Route{
+				{Headers: map[string][]string{"X-Version": {"2"}}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.Routes())
 		})
 	}
 }
@@ -89,7 +89,8 @@ func TestCreatePipeline(t *testing.T) {
 
 func TestPipelineManifestMarshal(t *testing.T) {
 	const pipelineName = "pipepiper"
-	wantedContent := `# This YAML file defines the relationship and deployment ordering of your environments.
+	wantedContent := `# yaml-language-server: $schema=./pipeline.schema.json
+# This YAML file defines the relationship and deployment ordering of your environments.
 
 # The name of the pipeline
 name: pipepiper
@@ -0,0 +1,199 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/templates"
+)
+
+const (
+	defaultJobSchedule = "rate(1 day)"
+
+	// EventBridge only accepts a maximum event age between one minute and 24 hours.
+	minJobTimeout = time.Minute
+	maxJobTimeout = 24 * time.Hour
+)
+
+// ScheduledJobManifest holds the configuration to build a container image that runs on a schedule
+// with AWS Fargate as the compute engine.
+type ScheduledJobManifest struct {
+	AppManifest        `yaml:",inline,omitempty"`
+	Image              AppImage `yaml:",omitempty"`
+	ScheduledJobConfig `yaml:",inline,omitempty"`
+	Environments       map[string]ScheduledJobConfig `yaml:",omitempty"` // Fields to override per environment.
+}
+
+// ScheduledJobConfig represents a job triggered by an EventBridge rule with AWS Fargate as compute.
+type ScheduledJobConfig struct {
+	ContainersConfig `yaml:",inline,omitempty"`
+	Schedule         string `yaml:"schedule,omitempty"` // A "cron(...)" or "rate(...)" expression.
+	Retries          int    `yaml:"retries,omitempty"`
+	Timeout          string `yaml:"timeout,omitempty"` // A duration such as "1h30m".
+}
+
+// NewScheduledJobManifest creates a new scheduled job that runs a single task once a day
+// with minimal CPU and Memory thresholds.
+func NewScheduledJobManifest(appName, dockerfile string) *ScheduledJobManifest {
+	return &ScheduledJobManifest{
+		AppManifest: AppManifest{
+			Name: appName,
+			Type: ScheduledJob,
+		},
+		Image: AppImage{
+			Build: BuildArgsOrString{
+				BuildString: dockerfile,
+			},
+		},
+		ScheduledJobConfig: ScheduledJobConfig{
+			ContainersConfig: ContainersConfig{
+				CPU:    256,
+				Memory: 512,
+				Count:  1,
+			},
+			Schedule: defaultJobSchedule,
+		},
+	}
+}
+
+// Marshal serializes the manifest object into a YAML document.
+func (m *ScheduledJobManifest) Marshal() ([]byte, error) {
+	box := templates.Box()
+	content, err := box.FindString("scheduled-job/manifest.yml")
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := template.New("template").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, *m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DockerfilePath returns the image build path.
+func (m ScheduledJobManifest) DockerfilePath() string {
+	return m.Image.dockerfilePath()
+}
+
+// BuildConfig returns the options to build the image with, or nil if the image isn't built from a Dockerfile.
+func (m ScheduledJobManifest) BuildConfig() *DockerBuildArgs {
+	return m.Image.BuildConfig()
+}
+
+// ImageLocation returns the URI of the pre-built image, or an empty string if the image is built from the Dockerfile.
+func (m ScheduledJobManifest) ImageLocation() string {
+	return m.Image.Location
+}
+
+// EnvConf returns the job configuration with environment overrides.
+// If the environment passed in does not have any overrides then we return the default values.
+func (m *ScheduledJobManifest) EnvConf(envName string) ScheduledJobConfig {
+	if _, ok := m.Environments[envName]; !ok {
+		return m.ScheduledJobConfig
+	}
+
+	// We don't want to modify the default settings, so deep copy into a "conf" variable.
+	envVars := make(map[string]string, len(m.Variables))
+	for k, v := range m.Variables {
+		envVars[k] = v
+	}
+	secrets := make(map[string]Secret, len(m.Secrets))
+	for k, v := range m.Secrets {
+		secrets[k] = v
+	}
+	conf := ScheduledJobConfig{
+		ContainersConfig: ContainersConfig{
+			CPU:       m.CPU,
+			Memory:    m.Memory,
+			Count:     m.Count,
+			Variables: envVars,
+			Secrets:   secrets,
+		},
+		Schedule: m.Schedule,
+		Retries:  m.Retries,
+		Timeout:  m.Timeout,
+	}
+
+	// Override with fields set in the environment.
+	target := m.Environments[envName]
+	if target.CPU != 0 {
+		conf.CPU = target.CPU
+	}
+	if target.Memory != 0 {
+		conf.Memory = target.Memory
+	}
+	if target.Count != 0 {
+		conf.Count = target.Count
+	}
+	for k, v := range target.Variables {
+		conf.Variables[k] = v
+	}
+	for k, v := range target.Secrets {
+		conf.Secrets[k] = v
+	}
+	if target.Schedule != "" {
+		conf.Schedule = target.Schedule
+	}
+	if target.Retries != 0 {
+		conf.Retries = target.Retries
+	}
+	if target.Timeout != "" {
+		conf.Timeout = target.Timeout
+	}
+	return conf
+}
+
+// TimeoutSeconds returns the job's timeout in seconds, or 0 if no timeout is set.
+func (c ScheduledJobConfig) TimeoutSeconds() (int, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, &ErrInvalidJobTimeout{Timeout: c.Timeout, parent: err}
+	}
+	if d < minJobTimeout || d > maxJobTimeout {
+		return 0, &ErrInvalidJobTimeout{Timeout: c.Timeout}
+	}
+	return int(d.Seconds()), nil
+}
+
+// validate returns an error if the schedule or timeout of the default configuration
+// or of any environment override is malformed.
+func (m *ScheduledJobManifest) validate() error {
+	configs := []ScheduledJobConfig{m.ScheduledJobConfig}
+	for _, conf := range m.Environments {
+		configs = append(configs, conf)
+	}
+	for i, conf := range configs {
+		// Environments without a schedule inherit the default one.
+		inherited := i > 0 && conf.Schedule == ""
+		if !inherited && !isScheduleExpression(conf.Schedule) {
+			return &ErrInvalidSchedule{Schedule: conf.Schedule}
+		}
+		if _, err := conf.TimeoutSeconds(); err != nil {
+			return err
+		}
+		if err := validateSecrets(conf.Secrets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isScheduleExpression returns true if the expression is an EventBridge "cron(...)" or "rate(...)" expression.
+func isScheduleExpression(expr string) bool {
+	if !strings.HasSuffix(expr, ")") {
+		return false
+	}
+	return strings.HasPrefix(expr, "cron(") || strings.HasPrefix(expr, "rate(")
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledJobManifest_Marshal(t *testing.T) {
+	// GIVEN
+	wantedContent := `# yaml-language-server: $schema=./app.schema.json
+# The manifest for the "reports" application.
+# Read the full specification for the "Scheduled Job" type at:
+#  https://github.com/aws/amazon-ecs-cli-v2/wiki/Manifests#scheduled-job
+
+# Your application name will be used in naming your resources like log groups, services, etc.
+name: reports
+# The "architecture" of the application you're running.
+type: Scheduled Job
+
+image:
+  # Path to your application's Dockerfile.
+  build: reports/Dockerfile
+  # Or, the options of the "docker build" command, with paths relative to the root of your workspace.
+  # Build args can take their value from your shell, for example to pass secrets from your CI system.
+  # build:
+  #   dockerfile: reports/Dockerfile
+  #   context: .
+  #   target: release
+  #   args:
+  #     NPM_TOKEN: ${NPM_TOKEN}
+  #   cache_from:
+  #     - 12345.dkr.ecr.us-west-2.amazonaws.com/project/app:latest
+  # Or, instead of building the Dockerfile, the URI of a pre-built image to deploy as is.
+  # location: nginx:latest
+
+# When the job runs, as an EventBridge "cron(...)" or "rate(...)" expression.
+# For example, "cron(0 3 * * ? *)" runs the job every night at 03:00 UTC.
+schedule: rate(1 day)
+
+# Number of CPU units for the task.
+cpu: 256
+# Amount of memory in MiB used by the task.
+memory: 512
+# Number of tasks started every time the job runs.
+count: 1
+
+# Optional fields for more advanced use-cases.
+#
+#retries: 3                    # Number of times to retry starting the job if the task fails to launch.
+#timeout: 1h                   # How long to keep retrying to start the job, between 1m and 24h.
+#
+#variables:                    # Pass environment variables as key value pairs.
+#  LOG_LEVEL: info
+#
+#secrets:                      # Pass secrets from AWS Systems Manager (SSM) Parameter Store.
+#  GITHUB_TOKEN: GITHUB_TOKEN  # The key is the name of the environment variable, the value is the name of the SSM parameter.
+
+# You can override any of the values defined above by environment.
+#environments:
+#  test:
+#    schedule: rate(1 hour)   # Run the job more often in the "test" environment.
+`
+	m := NewScheduledJobManifest("reports", "reports/Dockerfile")
+
+	// WHEN
+	b, err := m.Marshal()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, wantedContent, strings.Replace(string(b), "\r\n", "\n", -1))
+}
+
+func TestScheduledJobManifest_EnvConf(t *testing.T) {
+	testCases := map[string]struct {
+		inDefaultConfig  ScheduledJobConfig
+		inEnvNameToQuery string
+		inEnvOverride    map[string]ScheduledJobConfig
+
+		wantedConfig ScheduledJobConfig
+	}{
+		"with no existing environments": {
+			inDefaultConfig: ScheduledJobConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+				Schedule: "rate(1 day)",
+			},
+			inEnvNameToQuery: "prod-iad",
+
+			wantedConfig: ScheduledJobConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+				Schedule: "rate(1 day)",
+			},
+		},
+		"with partial overrides": {
+			inDefaultConfig: ScheduledJobConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+					Variables: map[string]string{
+						"LOG_LEVEL": "DEBUG",
+					},
+				},
+				Schedule: "rate(1 day)",
+				Retries:  1,
+				Timeout:  "30m",
+			},
+			inEnvNameToQuery: "prod-iad",
+			inEnvOverride: map[string]ScheduledJobConfig{
+				"prod-iad": {
+					ContainersConfig: ContainersConfig{
+						Memory: 1024,
+						Variables: map[string]string{
+							"LOG_LEVEL": "WARN",
+						},
+					},
+					Schedule: "cron(0 3 * * ? *)",
+					Retries:  3,
+				},
+			},
+
+			wantedConfig: ScheduledJobConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 1024,
+					Count:  1,
+					Variables: map[string]string{
+						"LOG_LEVEL": "WARN",
+					},
+					Secrets: map[string]Secret{},
+				},
+				Schedule: "cron(0 3 * * ? *)",
+				Retries:  3,
+				Timeout:  "30m",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mft := &ScheduledJobManifest{
+				ScheduledJobConfig: tc.inDefaultConfig,
+				Environments:       tc.inEnvOverride,
+			}
+
+			// WHEN
+			conf := mft.EnvConf(tc.inEnvNameToQuery)
+
+			// THEN
+			require.Equal(t, tc.wantedConfig, conf, "returned configuration should have overrides from the environment")
+		})
+	}
+}
+
+func TestScheduledJobConfig_TimeoutSeconds(t *testing.T) {
+	testCases := map[string]struct {
+		inTimeout string
+
+		wantedSeconds int
+		wantedErr     error
+	}{
+		"no timeout": {
+			wantedSeconds: 0,
+		},
+		"valid timeout": {
+			inTimeout:     "1h30m",
+			wantedSeconds: 5400,
+		},
+		"malformed timeout": {
+			inTimeout: "forever",
+			wantedErr: &ErrInvalidJobTimeout{Timeout: "forever"},
+		},
+		"timeout too short": {
+			inTimeout: "30s",
+			wantedErr: &ErrInvalidJobTimeout{Timeout: "30s"},
+		},
+		"timeout too long": {
+			inTimeout: "25h",
+			wantedErr: &ErrInvalidJobTimeout{Timeout: "25h"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			conf := ScheduledJobConfig{Timeout: tc.inTimeout}
+
+			// WHEN
+			seconds, err := conf.TimeoutSeconds()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.True(t, errors.Is(err, tc.wantedErr), "expected: %v, got: %v", tc.wantedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedSeconds, seconds)
+			}
+		})
+	}
+}
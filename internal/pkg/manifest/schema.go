@@ -0,0 +1,155 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// AppSchemaFileName is the name of the JSON Schema file for application manifests.
+	AppSchemaFileName = "app.schema.json"
+	// PipelineSchemaFileName is the name of the JSON Schema file for the pipeline manifest.
+	PipelineSchemaFileName = "pipeline.schema.json"
+
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+)
+
+// schema is a JSON Schema document or subschema.
+type schema map[string]interface{}
+
+// AppSchema returns the JSON Schema of the application manifests.
+// The properties allowed in the manifest depend on the value of its "type" field.
+func AppSchema() ([]byte, error) {
+	var variants []interface{}
+	for _, appType := range AppTypes {
+		variants = append(variants, schema{
+			"if": schema{
+				"properties": schema{
+					"type": schema{"const": appType},
+				},
+			},
+			"then": appTypeSchema(manifestTypes[appType]),
+		})
+	}
+	return marshalSchema(schema{
+		"$schema":  jsonSchemaDraft,
+		"title":    "Application manifest",
+		"type":     "object",
+		"required": []string{"name", "type"},
+		"properties": schema{
+			"name": schema{"type": "string", "description": "The name of the application."},
+			"type": schema{"type": "string", "description": "The architecture of the application.", "enum": AppTypes},
+		},
+		"allOf": variants,
+	})
+}
+
+// PipelineSchema returns the JSON Schema of the pipeline manifest.
+func PipelineSchema() ([]byte, error) {
+	s := typeSchema(reflect.TypeOf(PipelineManifest{}))
+	s["$schema"] = jsonSchemaDraft
+	s["title"] = "Pipeline manifest"
+	s["required"] = []string{"name", "version", "source", "stages"}
+	props := s["properties"].(schema)
+	props["version"] = schema{"type": "integer", "enum": []PipelineSchemaMajorVersion{Ver1}}
+	props["source"].(schema)["properties"].(schema)["provider"] = schema{"type": "string", "enum": []string{GithubProviderName}}
+	return marshalSchema(s)
+}
+
+// appTypeSchema returns the schema of a manifest struct along with the values allowed for its well-known fields.
+func appTypeSchema(t reflect.Type) schema {
+	s := typeSchema(t)
+	constrainConfig(s)
+	if envs, ok := s["properties"].(schema)["environments"]; ok {
+		constrainConfig(envs.(schema)["additionalProperties"].(schema))
+	}
+	return s
+}
+
+// constrainConfig restricts the values of the fields of an application configuration that only accept a fixed set.
+func constrainConfig(s schema) {
+	props := s["properties"].(schema)
+	if cpu, ok := props["cpu"]; ok {
+		cpu.(schema)["enum"] = fargateCPUs
+	}
+	if db, ok := props["database"]; ok {
+		db.(schema)["properties"].(schema)["engine"].(schema)["enum"] = databaseEngines
+	}
+}
+
+// typeSchema returns the schema of the values that decode into a Go type.
+func typeSchema(t reflect.Type) schema {
+	if t == buildArgsOrStringType {
+		return schema{
+			"oneOf": []schema{
+				{"type": "string"},
+				typeSchema(reflect.TypeOf(DockerBuildArgs{})),
+			},
+		}
+	}
+	if t == secretType {
+		return schema{
+			"oneOf": []schema{
+				{"type": "string"},
+				{
+					"type":     "object",
+					"required": []string{"from"},
+					"properties": schema{
+						"from":    schema{"type": "string"},
+						"backend": schema{"type": "string", "enum": SecretBackends},
+					},
+					"additionalProperties": false,
+				},
+			},
+		}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem())
+	case reflect.Struct:
+		fields := yamlFields(t)
+		props := make(schema, len(fields))
+		for name, ft := range fields {
+			props[name] = typeSchema(ft)
+		}
+		return schema{
+			"type":                 "object",
+			"properties":           props,
+			"additionalProperties": false,
+		}
+	case reflect.Map:
+		return schema{
+			"type":                 "object",
+			"additionalProperties": typeSchema(t.Elem()),
+		}
+	case reflect.Slice, reflect.Array:
+		return schema{
+			"type":  "array",
+			"items": typeSchema(t.Elem()),
+		}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	default:
+		// Interfaces accept any value.
+		return schema{}
+	}
+}
+
+func marshalSchema(s schema) ([]byte, error) {
+	out, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal JSON schema: %w", err)
+	}
+	return append(out, '\n'), nil
+}
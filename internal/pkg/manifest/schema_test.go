@@ -0,0 +1,90 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppSchema(t *testing.T) {
+	// WHEN
+	b, err := AppSchema()
+
+	// THEN
+	require.NoError(t, err)
+	var s map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &s))
+	require.Equal(t, jsonSchemaDraft, s["$schema"])
+	require.ElementsMatch(t, []interface{}{"name", "type"}, s["required"])
+
+	variants := s["allOf"].([]interface{})
+	require.Len(t, variants, len(AppTypes))
+	for i, appType := range AppTypes {
+		variant := variants[i].(map[string]interface{})
+		typeCond := variant["if"].(map[string]interface{})["properties"].(map[string]interface{})["type"]
+		require.Equal(t, map[string]interface{}{"const": appType}, typeCond)
+
+		then := variant["then"].(map[string]interface{})
+		require.Equal(t, false, then["additionalProperties"], "unknown fields are not allowed in a %s manifest", appType)
+		props := then["properties"].(map[string]interface{})
+		for _, field := range []string{"name", "type", "image", "cpu", "memory", "variables", "secrets", "environments"} {
+			require.Contains(t, props, field, "%s manifest is missing field %s", appType, field)
+		}
+		cpu := props["cpu"].(map[string]interface{})
+		require.Equal(t, "integer", cpu["type"])
+		require.Equal(t, []interface{}{256.0, 512.0, 1024.0, 2048.0, 4096.0}, cpu["enum"])
+		envCPU := props["environments"].(map[string]interface{})["additionalProperties"].(map[string]interface{})["properties"].(map[string]interface{})["cpu"]
+		require.Equal(t, cpu, envCPU)
+	}
+
+	lbProps := variants[0].(map[string]interface{})["then"].(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"minCount":     map[string]interface{}{"type": "integer"},
+			"maxCount":     map[string]interface{}{"type": "integer"},
+			"targetCPU":    map[string]interface{}{"type": "number"},
+			"targetMemory": map[string]interface{}{"type": "number"},
+		},
+	}, lbProps["scaling"])
+	require.Equal(t, map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"mysql", "postgresql"},
+	}, lbProps["database"].(map[string]interface{})["properties"].(map[string]interface{})["engine"])
+	require.Equal(t, map[string]interface{}{"type": "boolean"},
+		lbProps["sidecars"].(map[string]interface{})["additionalProperties"].(map[string]interface{})["properties"].(map[string]interface{})["essential"])
+}
+
+func TestPipelineSchema(t *testing.T) {
+	// WHEN
+	b, err := PipelineSchema()
+
+	// THEN
+	require.NoError(t, err)
+	var s map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &s))
+	require.Equal(t, jsonSchemaDraft, s["$schema"])
+	require.Equal(t, false, s["additionalProperties"])
+	require.Equal(t, []interface{}{"name", "version", "source", "stages"}, s["required"])
+
+	props := s["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"type": "integer", "enum": []interface{}{1.0}}, props["version"])
+	require.Equal(t, map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "string"},
+			},
+		},
+	}, props["stages"])
+	source := props["source"].(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"GitHub"}}, source["provider"])
+	require.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{}}, source["properties"])
+}
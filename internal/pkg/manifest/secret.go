@@ -0,0 +1,109 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Backends storing the values of secrets.
+const (
+	SecretBackendSSM            = "ssm"            // SSM Parameter Store SecureString parameters.
+	SecretBackendSecretsManager = "secretsmanager" // Secrets Manager secrets.
+)
+
+// DatabasePasswordSecret is the secret holding the master password of the application's database.
+const DatabasePasswordSecret = "DB_PASSWORD"
+
+// SecretBackends are the supported values of the "backend" field of a secret.
+var SecretBackends = []string{SecretBackendSSM, SecretBackendSecretsManager}
+
+// secretType is decoded from either a string or a mapping.
+var secretType = reflect.TypeOf(Secret{})
+
+// Secret is where the value of a secret environment variable is stored. In the manifest, it is either the name
+// of an SSM parameter, or a mapping with the name of the secret and the backend storing it.
+type Secret struct {
+	From    string `yaml:"from"`              // Name or ARN of the secret in its backend.
+	Backend string `yaml:"backend,omitempty"` // One of SecretBackends, SSM if empty.
+}
+
+// UnmarshalYAML decodes either a string or a mapping into the secret.
+func (s *Secret) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = Secret{}
+		return value.Decode(&s.From)
+	}
+	type plain Secret // Without the UnmarshalYAML method to avoid recursing.
+	return value.Decode((*plain)(s))
+}
+
+// MarshalYAML encodes the secret as a string unless its backend is set explicitly.
+func (s Secret) MarshalYAML() (interface{}, error) {
+	if s.Backend == "" {
+		return s.From, nil
+	}
+	type plain Secret
+	return plain(s), nil
+}
+
+// StoredIn returns the backend storing the secret's value.
+func (s Secret) StoredIn() string {
+	if s.Backend == "" {
+		return SecretBackendSSM
+	}
+	return s.Backend
+}
+
+// validateSecrets returns an ErrInvalidSecretBackend if a secret is stored in an unknown backend.
+func validateSecrets(secrets map[string]Secret) error {
+	var names []string
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if backend := secrets[name].Backend; backend != "" && !containsString(SecretBackends, backend) {
+			return &ErrInvalidSecretBackend{Name: name, Backend: backend}
+		}
+	}
+	return nil
+}
+
+// migrateDatabasePassword sets the backend of the database password in manifests written before secrets had a
+// backend, which store it in Secrets Manager without saying so. The password of an environment is migrated if a
+// database is configured for the application or for that environment. It returns whether the manifest changed.
+func migrateDatabasePassword(e *Editor) (bool, error) {
+	_, appDatabase := e.Get(DatabaseKey, "engine")
+	var paths [][]string
+	anyDatabase := appDatabase
+	for _, env := range e.Keys(environmentsKey) {
+		_, envDatabase := e.Get(EnvPath(env, DatabaseKey, "engine")...)
+		if appDatabase || envDatabase {
+			paths = append(paths, EnvPath(env, SecretsKey, DatabasePasswordSecret))
+		}
+		// Environments without their own password inherit the default one.
+		anyDatabase = anyDatabase || envDatabase
+	}
+	if anyDatabase {
+		paths = append([][]string{{SecretsKey, DatabasePasswordSecret}}, paths...)
+	}
+	var migrated bool
+	for _, path := range paths {
+		password, ok := e.GetSecret(path...)
+		if !ok || password.Backend != "" {
+			continue
+		}
+		password.Backend = SecretBackendSecretsManager
+		if err := e.SetSecret(password, path...); err != nil {
+			return false, fmt.Errorf("set the backend of secret %s: %w", DatabasePasswordSecret, err)
+		}
+		migrated = true
+	}
+	return migrated, nil
+}
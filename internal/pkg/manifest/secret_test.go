@@ -0,0 +1,168 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSecret_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent string
+
+		wantedSecrets map[string]Secret
+		wantedBackend string
+	}{
+		"name of an SSM parameter": {
+			inContent: `API_KEY: /ecs-cli-v2/phonetool/applications/api/secrets/api-key`,
+			wantedSecrets: map[string]Secret{
+				"API_KEY": {From: "/ecs-cli-v2/phonetool/applications/api/secrets/api-key"},
+			},
+			wantedBackend: SecretBackendSSM,
+		},
+		"secret with a backend": {
+			inContent: `
+API_KEY:
+  from: phonetool-api-key
+  backend: secretsmanager`,
+			wantedSecrets: map[string]Secret{
+				"API_KEY": {From: "phonetool-api-key", Backend: SecretBackendSecretsManager},
+			},
+			wantedBackend: SecretBackendSecretsManager,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var secrets map[string]Secret
+
+			// WHEN
+			err := yaml.Unmarshal([]byte(tc.inContent), &secrets)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedSecrets, secrets)
+			require.Equal(t, tc.wantedBackend, secrets["API_KEY"].StoredIn())
+
+			// Secrets are marshaled in the same form they were written in.
+			out, err := yaml.Marshal(secrets)
+			require.NoError(t, err)
+			var roundTrip map[string]Secret
+			require.NoError(t, yaml.Unmarshal(out, &roundTrip))
+			require.Equal(t, tc.wantedSecrets, roundTrip)
+		})
+	}
+}
+
+func TestMigrateDatabasePassword(t *testing.T) {
+	testCases := map[string]struct {
+		inContent string
+
+		wantedMigrated bool
+		wantedContent  string
+	}{
+		"sets the backend of the database password": {
+			inContent: `name: api
+secrets:
+  API_KEY: /ecs-cli-v2/phonetool/applications/api/secrets/api-key
+  DB_PASSWORD: phonetool-api-database # Created by "database create".
+database:
+  engine: postgresql
+environments:
+  prod:
+    secrets:
+      DB_PASSWORD: phonetool-api-database-prod
+`,
+
+			wantedMigrated: true,
+			wantedContent: `name: api
+secrets:
+  API_KEY: /ecs-cli-v2/phonetool/applications/api/secrets/api-key
+  DB_PASSWORD: # Created by "database create".
+    from: phonetool-api-database
+    backend: secretsmanager
+database:
+  engine: postgresql
+environments:
+  prod:
+    secrets:
+      DB_PASSWORD:
+        from: phonetool-api-database-prod
+        backend: secretsmanager
+`,
+		},
+		"sets the backend of the password of an environment with a database": {
+			inContent: `name: api
+secrets:
+  DB_PASSWORD: phonetool-api-database
+environments:
+  test:
+    secrets:
+      DB_PASSWORD: /ecs-cli-v2/phonetool/applications/api/secrets/db-password-test
+  prod:
+    database:
+      engine: mysql
+    secrets:
+      DB_PASSWORD: phonetool-api-database-prod
+`,
+
+			wantedMigrated: true,
+			wantedContent: `name: api
+secrets:
+  DB_PASSWORD:
+    from: phonetool-api-database
+    backend: secretsmanager
+environments:
+  test:
+    secrets:
+      DB_PASSWORD: /ecs-cli-v2/phonetool/applications/api/secrets/db-password-test
+  prod:
+    database:
+      engine: mysql
+    secrets:
+      DB_PASSWORD:
+        from: phonetool-api-database-prod
+        backend: secretsmanager
+`,
+		},
+		"leaves explicit backends": {
+			inContent: `name: api
+secrets:
+  DB_PASSWORD:
+    from: phonetool-api-database
+    backend: secretsmanager
+database:
+  engine: postgresql
+`,
+		},
+		"leaves secrets named like the password without a database": {
+			inContent: `name: api
+secrets:
+  DB_PASSWORD: /ecs-cli-v2/phonetool/applications/api/secrets/db-password
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			e, err := NewEditor([]byte(tc.inContent))
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedMigrated, e.Migrated())
+			out, err := e.Marshal()
+			require.NoError(t, err)
+			wanted := tc.wantedContent
+			if !tc.wantedMigrated {
+				wanted = tc.inContent
+			}
+			require.Equal(t, wanted, string(out))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Conditions a sidecar can wait on for the containers it depends on.
+const (
+	SidecarDependsOnStart    = "START"
+	SidecarDependsOnComplete = "COMPLETE"
+	SidecarDependsOnSuccess  = "SUCCESS"
+	SidecarDependsOnHealthy  = "HEALTHY"
+)
+
+var sidecarDependsOnConditions = []string{
+	SidecarDependsOnStart,
+	SidecarDependsOnComplete,
+	SidecarDependsOnSuccess,
+	SidecarDependsOnHealthy,
+}
+
+// SidecarConfig represents a container that runs next to the application's container in the same task.
+type SidecarConfig struct {
+	Image     string            `yaml:"image,omitempty"`
+	Port      int               `yaml:"port,omitempty"`
+	Essential *bool             `yaml:"essential,omitempty"` // Defaults to true when omitted.
+	Variables map[string]string `yaml:"variables,omitempty"`
+	Secrets   map[string]Secret `yaml:"secrets,omitempty"`
+	DependsOn map[string]string `yaml:"dependsOn,omitempty"` // Container name to the condition to wait for.
+}
+
+// copySidecars deep copies the sidecars so that environment overrides don't modify the default settings.
+func copySidecars(sidecars map[string]*SidecarConfig) map[string]*SidecarConfig {
+	if sidecars == nil {
+		return nil
+	}
+	out := make(map[string]*SidecarConfig, len(sidecars))
+	for name, sidecar := range sidecars {
+		out[name] = &SidecarConfig{}
+		out[name].override(sidecar)
+	}
+	return out
+}
+
+// overrideSidecars applies the environment's sidecar settings on top of the default ones.
+// Sidecars that only exist in the environment are added.
+func overrideSidecars(sidecars, target map[string]*SidecarConfig) map[string]*SidecarConfig {
+	if len(target) == 0 {
+		return sidecars
+	}
+	if sidecars == nil {
+		sidecars = make(map[string]*SidecarConfig, len(target))
+	}
+	for name, sidecar := range target {
+		if _, ok := sidecars[name]; !ok {
+			sidecars[name] = &SidecarConfig{}
+		}
+		sidecars[name].override(sidecar)
+	}
+	return sidecars
+}
+
+func (s *SidecarConfig) override(target *SidecarConfig) {
+	if target == nil {
+		return
+	}
+	if target.Image != "" {
+		s.Image = target.Image
+	}
+	if target.Port != 0 {
+		s.Port = target.Port
+	}
+	if target.Essential != nil {
+		essential := *target.Essential
+		s.Essential = &essential
+	}
+	s.Variables = mergeStringMaps(s.Variables, target.Variables)
+	s.Secrets = mergeSecretMaps(s.Secrets, target.Secrets)
+	s.DependsOn = mergeStringMaps(s.DependsOn, target.DependsOn)
+}
+
+func mergeStringMaps(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+func mergeSecretMaps(dst, src map[string]Secret) map[string]Secret {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]Secret, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// validateSidecars returns an error if a sidecar has no image, exposes a port already used by
+// the application's container or by another sidecar, or depends on an unknown container or condition.
+func validateSidecars(appName string, appPort int, sidecars map[string]*SidecarConfig) error {
+	// Iterate in a stable order so that the same manifest always reports the same error.
+	names := make([]string, 0, len(sidecars))
+	for name := range sidecars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	portOwners := map[int]string{appPort: appName}
+	for _, name := range names {
+		sidecar := sidecars[name]
+		if sidecar == nil || sidecar.Image == "" {
+			return &ErrInvalidSidecar{Name: name, Reason: "image is required"}
+		}
+		if sidecar.Port != 0 {
+			if owner, ok := portOwners[sidecar.Port]; ok {
+				return &ErrSidecarPortConflict{Name: name, Port: sidecar.Port, Container: owner}
+			}
+			portOwners[sidecar.Port] = name
+		}
+		for container, condition := range sidecar.DependsOn {
+			if _, ok := sidecars[container]; !ok && container != appName {
+				return &ErrInvalidSidecar{Name: name, Reason: fmt.Sprintf("depends on unknown container %s", container)}
+			}
+			if !isSidecarDependsOnCondition(condition) {
+				return &ErrInvalidSidecar{Name: name, Reason: fmt.Sprintf("invalid condition %s for container %s", condition, container)}
+			}
+		}
+	}
+	return nil
+}
+
+func isSidecarDependsOnCondition(condition string) bool {
+	for _, c := range sidecarDependsOnConditions {
+		if condition == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSidecars(t *testing.T) {
+	testCases := map[string]struct {
+		inSidecars map[string]*SidecarConfig
+
+		wantedErr error
+	}{
+		"no sidecars": {},
+		"valid sidecars": {
+			inSidecars: map[string]*SidecarConfig{
+				"nginx": {
+					Image: "nginx:latest",
+					Port:  8080,
+					DependsOn: map[string]string{
+						"frontend": SidecarDependsOnStart,
+					},
+				},
+				"xray": {
+					Image: "amazon/aws-xray-daemon",
+					Port:  2000,
+					DependsOn: map[string]string{
+						"nginx": SidecarDependsOnHealthy,
+					},
+				},
+			},
+		},
+		"missing image": {
+			inSidecars: map[string]*SidecarConfig{
+				"nginx": {
+					Port: 8080,
+				},
+			},
+			wantedErr: &ErrInvalidSidecar{Name: "nginx", Reason: "image is required"},
+		},
+		"port used by the application": {
+			inSidecars: map[string]*SidecarConfig{
+				"nginx": {
+					Image: "nginx:latest",
+					Port:  80,
+				},
+			},
+			wantedErr: &ErrSidecarPortConflict{Name: "nginx", Port: 80, Container: "frontend"},
+		},
+		"port used by another sidecar": {
+			inSidecars: map[string]*SidecarConfig{
+				"envoy": {
+					Image: "envoy:latest",
+					Port:  8080,
+				},
+				"nginx": {
+					Image: "nginx:latest",
+					Port:  8080,
+				},
+			},
+			wantedErr: &ErrSidecarPortConflict{Name: "nginx", Port: 8080, Container: "envoy"},
+		},
+		"depends on unknown container": {
+			inSidecars: map[string]*SidecarConfig{
+				"nginx": {
+					Image: "nginx:latest",
+					DependsOn: map[string]string{
+						"backend": SidecarDependsOnStart,
+					},
+				},
+			},
+			wantedErr: &ErrInvalidSidecar{Name: "nginx", Reason: "depends on unknown container backend"},
+		},
+		"invalid depends on condition": {
+			inSidecars: map[string]*SidecarConfig{
+				"nginx": {
+					Image: "nginx:latest",
+					DependsOn: map[string]string{
+						"frontend": "READY",
+					},
+				},
+			},
+			wantedErr: &ErrInvalidSidecar{Name: "nginx", Reason: "invalid condition READY for container frontend"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := validateSidecars("frontend", 80, tc.inSidecars)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.True(t, errors.Is(err, tc.wantedErr), "expected: %v, got: %v", tc.wantedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,579 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Database engines supported by the "database" field of a load balanced web application.
+var databaseEngines = []string{"mysql", "postgresql"}
+
+// fargateCPUs are the CPU units a Fargate task can be configured with.
+var fargateCPUs = []int{256, 512, 1024, 2048, 4096}
+
+// successCodesPattern matches the HTTP codes of a target group's health check, such as "200", "200,202" or "200-299".
+var successCodesPattern = regexp.MustCompile(`^[1-5][0-9]{2}(-[1-5][0-9]{2})?(,[1-5][0-9]{2}(-[1-5][0-9]{2})?)*$`)
+
+// fargateMemory returns the memory values in MiB that can be paired with the CPU units on Fargate.
+func fargateMemory(cpu int) []int {
+	switch cpu {
+	case 256:
+		return []int{512, 1024, 2048}
+	case 512:
+		return memoryRange(1024, 4096)
+	case 1024:
+		return memoryRange(2048, 8192)
+	case 2048:
+		return memoryRange(4096, 16384)
+	case 4096:
+		return memoryRange(8192, 30720)
+	default:
+		return nil
+	}
+}
+
+// memoryRange returns the multiples of 1024 between min and max.
+func memoryRange(min, max int) []int {
+	var values []int
+	for v := min; v <= max; v += 1024 {
+		values = append(values, v)
+	}
+	return values
+}
+
+// manifestTypes maps each application type to the struct its manifest is decoded into.
+var manifestTypes = map[string]reflect.Type{
+	LoadBalancedWebApplication: reflect.TypeOf(LBFargateManifest{}),
+	BackendApplication:         reflect.TypeOf(BackendAppManifest{}),
+	ScheduledJob:               reflect.TypeOf(ScheduledJobManifest{}),
+	WorkerService:              reflect.TypeOf(WorkerServiceManifest{}),
+}
+
+// buildArgsOrStringType is decoded from either a string or a mapping.
+var buildArgsOrStringType = reflect.TypeOf(BuildArgsOrString{})
+
+// ValidationError is a problem found in a manifest at a given position.
+type ValidationError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ValidateApp strictly decodes the YAML input stream of an application manifest and checks
+// that its values can be deployed.
+// If the input can't be read as an application manifest, then returns an ErrUnmarshalAppManifest.
+// If the application type in the manifest is invalid, then returns an ErrInvalidAppManifestType.
+// Otherwise, returns an ErrInvalidAppManifest listing every problem found, or nil if there are none.
+// Manifests written by older versions of the CLI are migrated in memory first.
+func ValidateApp(in []byte) error {
+	e, err := NewEditor(in)
+	if err != nil {
+		return err
+	}
+	am := AppManifest{}
+	if err := e.root().Decode(&am); err != nil {
+		return &ErrUnmarshalAppManifest{parent: err}
+	}
+	t, ok := manifestTypes[am.Type]
+	if !ok {
+		return &ErrInvalidAppManifestType{Type: am.Type}
+	}
+
+	v := &validator{
+		root: e.root(),
+	}
+	v.checkNode(v.root, t)
+	if len(v.errs) == 0 {
+		// Only check the values once we know that the manifest decodes into its type.
+		mft := reflect.New(t)
+		if err := v.root.Decode(mft.Interface()); err != nil {
+			return &ErrUnmarshalAppManifest{parent: err}
+		}
+		switch m := mft.Interface().(type) {
+		case *LBFargateManifest:
+			v.checkLBFargate(m)
+		case *BackendAppManifest:
+			v.checkBackendApp(m)
+		case *ScheduledJobManifest:
+			v.checkScheduledJob(m)
+		case *WorkerServiceManifest:
+			v.checkWorkerService(m)
+		}
+	}
+	if len(v.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		if v.errs[i].Line != v.errs[j].Line {
+			return v.errs[i].Line < v.errs[j].Line
+		}
+		return v.errs[i].Column < v.errs[j].Column
+	})
+	return &ErrInvalidAppManifest{Errors: v.errs}
+}
+
+// validator collects the problems found in a manifest with their position in the document.
+type validator struct {
+	root *yaml.Node
+	errs []*ValidationError
+}
+
+func (v *validator) addf(n *yaml.Node, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Line:   n.Line,
+		Column: n.Column,
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+// checkNode reports the keys that don't match any field of the type and the values that can't be decoded into it.
+func (v *validator) checkNode(n *yaml.Node, t reflect.Type) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null" {
+		return
+	}
+	if t == buildArgsOrStringType {
+		if n.Kind != yaml.ScalarNode {
+			v.checkNode(n, reflect.TypeOf(DockerBuildArgs{}))
+		}
+		return
+	}
+	if t == secretType && n.Kind == yaml.ScalarNode {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			v.addf(n, "expected a mapping")
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			fieldType, ok := fields[key.Value]
+			if !ok {
+				v.addf(key, "unknown field %q", key.Value)
+				continue
+			}
+			v.checkNode(value, fieldType)
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			v.addf(n, "expected a mapping")
+			return
+		}
+		for i := 1; i < len(n.Content); i += 2 {
+			v.checkNode(n.Content[i], t.Elem())
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			v.addf(n, "expected a list")
+			return
+		}
+		for _, item := range n.Content {
+			v.checkNode(item, t.Elem())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!int" {
+			v.addf(n, "cannot use %s as an integer", describeNode(n))
+		}
+	case reflect.Float32, reflect.Float64:
+		if n.Kind != yaml.ScalarNode || (n.ShortTag() != "!!int" && n.ShortTag() != "!!float") {
+			v.addf(n, "cannot use %s as a number", describeNode(n))
+		}
+	case reflect.Bool:
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!bool" {
+			v.addf(n, "cannot use %s as a boolean", describeNode(n))
+		}
+	case reflect.String:
+		if n.Kind != yaml.ScalarNode {
+			v.addf(n, "cannot use %s as a string", describeNode(n))
+		}
+	}
+}
+
+// yamlFields returns the types of the fields of a struct by their YAML key, including the fields of inlined structs.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("yaml"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		inline := false
+		for _, opt := range tag[1:] {
+			if opt == "inline" {
+				inline = true
+			}
+		}
+		if inline && f.Type.Kind() == reflect.Struct {
+			for k, v := range yamlFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			// Unexported fields are ignored by the decoder.
+			continue
+		}
+		name := tag[0]
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func describeNode(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", n.Value)
+	}
+}
+
+// find returns the node at the path of keys, or nil if the path doesn't exist.
+func (v *validator) find(path ...string) *yaml.Node {
+	n := v.root
+	for _, key := range path {
+		if n.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			if n.Content[i].Value == key {
+				next = n.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		n = next
+	}
+	return n
+}
+
+// locate returns the node where a field of an environment's configuration is set.
+// Fields that are not overridden by the environment are located in the default configuration.
+func (v *validator) locate(envName string, path ...string) *yaml.Node {
+	if envName != "" {
+		if n := v.find(append([]string{"environments", envName}, path...)...); n != nil {
+			return n
+		}
+	}
+	if n := v.find(path...); n != nil {
+		return n
+	}
+	if envName != "" {
+		if n := v.find("environments", envName); n != nil {
+			return n
+		}
+	}
+	return v.root
+}
+
+// overrides returns true if the configuration is the default one, represented by an empty environment name,
+// or if the environment sets one of the keys. The problems of the values that an environment inherits are only
+// reported for the default configuration.
+func (v *validator) overrides(envName string, keys ...string) bool {
+	if envName == "" {
+		return true
+	}
+	for _, key := range keys {
+		if v.find("environments", envName, key) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// envNames returns the default configuration, represented by an empty name, followed by the sorted environment names.
+func envNames(envs []string) []string {
+	sort.Strings(envs)
+	return append([]string{""}, envs...)
+}
+
+func (v *validator) checkLBFargate(m *LBFargateManifest) {
+	v.checkImage(m.Image.AppImage)
+	var envs []string
+	for name := range m.Environments {
+		envs = append(envs, name)
+	}
+	for _, env := range envNames(envs) {
+		conf := m.LBFargateConfig
+		if env != "" {
+			conf = m.EnvConf(env)
+		}
+		v.checkContainers(env, conf.ContainersConfig)
+		if v.overrides(env, "http") {
+			v.checkRoutes(env, conf.RoutingRule)
+		}
+		if v.overrides(env, "healthcheck") {
+			v.checkHealthCheck(env, conf.HealthCheck)
+		}
+		if conf.Scaling != nil && v.overrides(env, "scaling") && conf.Scaling.MinCount > conf.Scaling.MaxCount {
+			v.addf(v.locate(env, "scaling", "minCount"), "%v",
+				&ErrInvalidScaling{MinCount: conf.Scaling.MinCount, MaxCount: conf.Scaling.MaxCount})
+		}
+		if conf.Database != nil && v.overrides(env, "database") {
+			if conf.Database.Engine != "" && !containsString(databaseEngines, conf.Database.Engine) {
+				v.addf(v.locate(env, "database", "engine"), "database engine %q must be one of %s",
+					conf.Database.Engine, strings.Join(databaseEngines, ", "))
+			}
+			v.checkRange(env, conf.Database.RotationDays, 1, 1000, "database", "rotationDays")
+		}
+		if conf.Database != nil && conf.Database.Engine != "" {
+			v.checkDatabasePassword(env, conf.Secrets)
+		}
+		if !v.overrides(env, "sidecars") {
+			continue
+		}
+		if err := validateSidecars(m.Name, m.Image.Port, conf.Sidecars); err != nil {
+			path := []string{"sidecars"}
+			var sidecarErr *ErrInvalidSidecar
+			var portErr *ErrSidecarPortConflict
+			if errors.As(err, &sidecarErr) {
+				path = append(path, sidecarErr.Name)
+			} else if errors.As(err, &portErr) {
+				path = append(path, portErr.Name, "port")
+			}
+			v.addf(v.locate(env, path...), "%v", err)
+		}
+	}
+}
+
+func (v *validator) checkBackendApp(m *BackendAppManifest) {
+	v.checkImage(m.Image.AppImage)
+	var envs []string
+	for name := range m.Environments {
+		envs = append(envs, name)
+	}
+	for _, env := range envNames(envs) {
+		conf := m.BackendAppConfig
+		if env != "" {
+			conf = m.EnvConf(env)
+		}
+		v.checkContainers(env, conf.ContainersConfig)
+	}
+}
+
+func (v *validator) checkScheduledJob(m *ScheduledJobManifest) {
+	v.checkImage(m.Image)
+	var envs []string
+	for name := range m.Environments {
+		envs = append(envs, name)
+	}
+	for _, env := range envNames(envs) {
+		conf := m.ScheduledJobConfig
+		if env != "" {
+			conf = m.EnvConf(env)
+		}
+		v.checkContainers(env, conf.ContainersConfig)
+		if v.overrides(env, "schedule") && !isScheduleExpression(conf.Schedule) {
+			v.addf(v.locate(env, "schedule"), "%v", &ErrInvalidSchedule{Schedule: conf.Schedule})
+		}
+		if _, err := conf.TimeoutSeconds(); err != nil && v.overrides(env, "timeout") {
+			v.addf(v.locate(env, "timeout"), "%v", err)
+		}
+	}
+}
+
+func (v *validator) checkWorkerService(m *WorkerServiceManifest) {
+	v.checkImage(m.Image)
+	var envs []string
+	for name := range m.Environments {
+		envs = append(envs, name)
+	}
+	for _, env := range envNames(envs) {
+		conf := m.WorkerServiceConfig
+		if env != "" {
+			conf = m.EnvConf(env)
+		}
+		v.checkContainers(env, conf.ContainersConfig)
+		if conf.Scaling != nil && v.overrides(env, "scaling") && conf.Scaling.MinCount > conf.Scaling.MaxCount {
+			v.addf(v.locate(env, "scaling", "minCount"), "%v",
+				&ErrInvalidScaling{MinCount: conf.Scaling.MinCount, MaxCount: conf.Scaling.MaxCount})
+		}
+	}
+}
+
+// checkImage reports an image that is both built from a Dockerfile and pulled from a location.
+func (v *validator) checkImage(img AppImage) {
+	if !img.Build.IsZero() && img.Location != "" {
+		v.addf(v.locate("", "image", "location"), "image build and location are mutually exclusive, remove one of them")
+	}
+}
+
+// maxRouteConditionValues is the number of values that the conditions of a listener rule can match.
+const maxRouteConditionValues = 5
+
+// defaultRouteHosts is the number of hosts that the HTTPS listener rule of a route without hosts matches:
+// the application's subdomain of the environment and of the project.
+const defaultRouteHosts = 2
+
+// checkRoutes reports the routes that can't be turned into listener rules of the load balancer.
+func (v *validator) checkRoutes(env string, rule RoutingRule) {
+	if rule.Path != "" && rule.Path != "*" && !strings.HasPrefix(rule.Path, "/") {
+		v.addf(v.locate(env, "http", "path"), "http path %q must be * or start with /", rule.Path)
+	}
+	for i, route := range rule.Rules {
+		n := v.locate(env, "http", "rules")
+		if n.Kind == yaml.SequenceNode && i < len(n.Content) {
+			n = n.Content[i]
+		}
+		values := len(route.Paths) + len(route.Hosts) + len(route.QueryStrings)
+		for _, headerValues := range route.Headers {
+			values += len(headerValues)
+		}
+		switch {
+		case values == 0:
+			v.addf(n, "http rule %d must have at least one of paths, hosts, headers or queryStrings", i+1)
+		case len(route.Hosts) == 0 && values+defaultRouteHosts > maxRouteConditionValues:
+			v.addf(n, "http rule %d matches %d values with the %d default hosts, must be at most %d",
+				i+1, values+defaultRouteHosts, defaultRouteHosts, maxRouteConditionValues)
+		case values > maxRouteConditionValues:
+			v.addf(n, "http rule %d matches %d values, must be at most %d", i+1, values, maxRouteConditionValues)
+		}
+		for _, path := range route.Paths {
+			if path != "*" && !strings.HasPrefix(path, "/") {
+				v.addf(n, "http rule %d path %q must be * or start with /", i+1, path)
+			}
+		}
+	}
+}
+
+// checkHealthCheck reports the health check settings that are outside of the ranges accepted
+// by Elastic Load Balancing and ECS.
+func (v *validator) checkHealthCheck(env string, hc HealthCheck) {
+	if hc.Path != "" && !strings.HasPrefix(hc.Path, "/") {
+		v.addf(v.locate(env, "healthcheck", "path"), "health check path %q must start with /", hc.Path)
+	}
+	v.checkRange(env, hc.HealthyThreshold, 2, 10, "healthcheck", "healthyThreshold")
+	v.checkRange(env, hc.UnhealthyThreshold, 2, 10, "healthcheck", "unhealthyThreshold")
+	v.checkRange(env, hc.Interval, 5, 300, "healthcheck", "interval")
+	v.checkRange(env, hc.Timeout, 2, 120, "healthcheck", "timeout")
+	if hc.Interval != 0 && hc.Timeout >= hc.Interval {
+		v.addf(v.locate(env, "healthcheck", "timeout"), "health check timeout %d must be less than interval %d",
+			hc.Timeout, hc.Interval)
+	}
+	if hc.SuccessCodes != "" && !successCodesPattern.MatchString(hc.SuccessCodes) {
+		v.addf(v.locate(env, "healthcheck", "successCodes"),
+			`health check successCodes %q must be HTTP codes such as "200", "200,202" or "200-299"`, hc.SuccessCodes)
+	}
+	if hc.GracePeriod < 0 {
+		v.addf(v.locate(env, "healthcheck", "gracePeriod"), "health check gracePeriod %d must not be negative", hc.GracePeriod)
+	}
+	if hc.Container == nil {
+		return
+	}
+	if cmd := hc.Container.Command; len(cmd) < 2 || (cmd[0] != "CMD" && cmd[0] != "CMD-SHELL") {
+		v.addf(v.locate(env, "healthcheck", "container", "command"),
+			`container health check command must start with "CMD" or "CMD-SHELL" followed by the command to run`)
+	}
+	v.checkRange(env, hc.Container.Interval, 5, 300, "healthcheck", "container", "interval")
+	v.checkRange(env, hc.Container.Timeout, 2, 60, "healthcheck", "container", "timeout")
+	v.checkRange(env, hc.Container.Retries, 1, 10, "healthcheck", "container", "retries")
+	v.checkRange(env, hc.Container.StartPeriod, 0, 300, "healthcheck", "container", "startPeriod")
+}
+
+// checkRange reports a value that is set but outside of [min, max].
+func (v *validator) checkRange(env string, value, min, max int, path ...string) {
+	if value == 0 || (value >= min && value <= max) {
+		return
+	}
+	v.addf(v.locate(env, path...), "%s %d must be between %d and %d", strings.Join(path, "."), value, min, max)
+}
+
+// checkContainers reports CPU and memory values that can't be paired on Fargate.
+func (v *validator) checkContainers(env string, conf ContainersConfig) {
+	v.checkSecrets(env, conf.Secrets)
+	if conf.CPU == 0 || conf.Memory == 0 || !v.overrides(env, "cpu", "memory") {
+		return
+	}
+	memories := fargateMemory(conf.CPU)
+	if memories == nil {
+		var cpus []string
+		for _, cpu := range fargateCPUs {
+			cpus = append(cpus, strconv.Itoa(cpu))
+		}
+		v.addf(v.locate(env, "cpu"), "cpu %d must be one of %s", conf.CPU, strings.Join(cpus, ", "))
+		return
+	}
+	for _, memory := range memories {
+		if memory == conf.Memory {
+			return
+		}
+	}
+	if conf.CPU == 256 {
+		v.addf(v.locate(env, "memory"), "memory %d is not supported with cpu %d, must be one of 512, 1024, 2048",
+			conf.Memory, conf.CPU)
+		return
+	}
+	v.addf(v.locate(env, "memory"), "memory %d is not supported with cpu %d, must be a multiple of 1024 between %d and %d",
+		conf.Memory, conf.CPU, memories[0], memories[len(memories)-1])
+}
+
+// checkSecrets reports the secrets stored in an unknown backend.
+// The secrets that an environment inherits are only reported for the default configuration.
+func (v *validator) checkSecrets(env string, secrets map[string]Secret) {
+	var names []string
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if env != "" && v.find("environments", env, "secrets", name) == nil {
+			continue
+		}
+		if backend := secrets[name].Backend; backend != "" && !containsString(SecretBackends, backend) {
+			v.addf(v.locate(env, "secrets", name, "backend"), "%v", &ErrInvalidSecretBackend{Name: name, Backend: backend})
+		}
+	}
+}
+
+// checkDatabasePassword reports a database password that isn't stored in Secrets Manager, where the database reads it from.
+func (v *validator) checkDatabasePassword(env string, secrets map[string]Secret) {
+	password, ok := secrets[DatabasePasswordSecret]
+	if !ok || password.StoredIn() == SecretBackendSecretsManager {
+		return
+	}
+	if env != "" && v.find("environments", env, "secrets", DatabasePasswordSecret) == nil {
+		return
+	}
+	v.addf(v.locate(env, "secrets", DatabasePasswordSecret), "secret %s must set backend %q since the database reads its password from Secrets Manager",
+		DatabasePasswordSecret, SecretBackendSecretsManager)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,391 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateApp(t *testing.T) {
+	testCases := map[string]struct {
+		inContent string
+
+		wantedErr      error
+		wantedProblems []*ValidationError
+	}{
+		"valid load balanced web application": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '*'
+healthcheck:
+  path: '/'
+cpu: 256
+memory: 512
+count: 1
+scaling:
+  minCount: 1
+  maxCount: 3
+  targetCPU: 75
+database:
+  engine: postgresql
+sidecars:
+  nginx:
+    image: nginx:latest
+    port: 8080
+    essential: false
+environments:
+  prod:
+    cpu: 1024
+    memory: 2048
+`,
+		},
+		"malformed yaml": {
+			inContent: `
+name: frontend
+type: [`,
+			wantedErr: &ErrUnmarshalAppManifest{},
+		},
+		"invalid app type": {
+			inContent: `
+name: frontend
+type: Web App
+`,
+			wantedErr: &ErrInvalidAppManifestType{Type: "Web App"},
+		},
+		"unknown fields and wrong types": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: eighty
+cpu: lots
+minCount: 1
+scaling:
+  minCount: 1
+  maxcount: 3
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 6, Column: 9, Msg: `cannot use "eighty" as an integer`},
+				{Line: 7, Column: 6, Msg: `cannot use "lots" as an integer`},
+				{Line: 8, Column: 1, Msg: `unknown field "minCount"`},
+				{Line: 11, Column: 3, Msg: `unknown field "maxcount"`},
+			},
+		},
+		"invalid values in the default configuration": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+healthcheck:
+  path: 'health'
+cpu: 256
+memory: 4096
+database:
+  engine: oracle
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 8, Column: 9, Msg: `health check path "health" must start with /`},
+				{Line: 10, Column: 9, Msg: `memory 4096 is not supported with cpu 256, must be one of 512, 1024, 2048`},
+				{Line: 12, Column: 11, Msg: `database engine "oracle" must be one of mysql, postgresql`},
+			},
+		},
+		"invalid database rotation": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+database:
+  engine: postgresql
+  rotationDays: 90
+environments:
+  test:
+    database:
+      rotationDays: 2000
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 13, Column: 21, Msg: `database.rotationDays 2000 must be between 1 and 1000`},
+			},
+		},
+		"invalid values in an environment override": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+cpu: 512
+memory: 1024
+scaling:
+  minCount: 1
+  maxCount: 3
+sidecars:
+  nginx:
+    image: nginx:latest
+environments:
+  prod:
+    cpu: 300
+    scaling:
+      minCount: 5
+    sidecars:
+      nginx:
+        port: 80
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 17, Column: 10, Msg: `cpu 300 must be one of 256, 512, 1024, 2048, 4096`},
+				{Line: 19, Column: 17, Msg: `scaling minCount 5 must be less than or equal to maxCount 3`},
+				{Line: 22, Column: 15, Msg: `sidecar nginx port 80 is already used by container frontend`},
+			},
+		},
+		"invalid secrets": {
+			inContent: `
+name: api
+type: Backend App
+image:
+  build: api/Dockerfile
+  port: 8080
+secrets:
+  API_KEY: /ecs-cli-v2/phonetool/applications/api/secrets/api-key
+  DB_PASSWORD:
+    from: phonetool-api-database
+    backend: secretsmanager
+environments:
+  prod:
+    secrets:
+      API_KEY:
+        from: api-key
+        backend: secretsmanager
+      TOKEN:
+        form: token
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 19, Column: 9, Msg: `unknown field "form"`},
+			},
+		},
+		"unknown secret backend": {
+			inContent: `
+name: api
+type: Backend App
+image:
+  build: api/Dockerfile
+  port: 8080
+secrets:
+  DB_PASSWORD:
+    from: phonetool-api-database
+    backend: vault
+environments:
+  prod:
+    secrets:
+      API_KEY:
+        from: api-key
+        backend: ssm
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 10, Column: 14, Msg: `secret DB_PASSWORD backend "vault" must be one of ssm, secretsmanager`},
+			},
+		},
+		"database password outside of Secrets Manager": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+secrets:
+  DB_PASSWORD:
+    from: /ecs-cli-v2/phonetool/applications/frontend/secrets/db-password
+    backend: ssm
+database:
+  engine: postgresql
+environments:
+  prod:
+    secrets:
+      DB_PASSWORD:
+        from: phonetool-frontend-database-prod
+        backend: ssm
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 9, Column: 5, Msg: `secret DB_PASSWORD must set backend "secretsmanager" since the database reads its password from Secrets Manager`},
+				{Line: 17, Column: 9, Msg: `secret DB_PASSWORD must set backend "secretsmanager" since the database reads its password from Secrets Manager`},
+			},
+		},
+		"unknown build option": {
+			inContent: `
+name: reports
+type: Scheduled Job
+image:
+  build:
+    dockerfile: reports/Dockerfile
+    cacheFrom: reports:latest
+schedule: rate(1 day)
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 7, Column: 5, Msg: `unknown field "cacheFrom"`},
+			},
+		},
+		"image with both build and location": {
+			inContent: `
+name: reports
+type: Scheduled Job
+image:
+  build: reports/Dockerfile
+  location: 12345.dkr.ecr.us-west-2.amazonaws.com/reports:latest
+schedule: rate(1 day)
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 6, Column: 13, Msg: "image build and location are mutually exclusive, remove one of them"},
+			},
+		},
+		"invalid routes": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: api/*
+  rules:
+    - paths: ['/v2/*']
+    - hosts: []
+    - paths: ['/a', '/b', 'c']
+      hosts: ['a.example.com', 'b.example.com', 'c.example.com']
+    - paths: ['/a', '/b', '/c', '/d']
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 8, Column: 9, Msg: `http path "api/*" must be * or start with /`},
+				{Line: 11, Column: 7, Msg: `http rule 2 must have at least one of paths, hosts, headers or queryStrings`},
+				{Line: 12, Column: 7, Msg: `http rule 3 matches 6 values, must be at most 5`},
+				{Line: 12, Column: 7, Msg: `http rule 3 path "c" must be * or start with /`},
+				{Line: 14, Column: 7, Msg: `http rule 4 matches 6 values with the 2 default hosts, must be at most 5`},
+			},
+		},
+		"routes at the limit of values": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+  rules:
+    - paths: ['/a', '/b', '/c']
+    - paths: ['/a', '/b']
+      hosts: ['a.example.com', 'b.example.com', 'c.example.com']
+`,
+		},
+		"invalid health check": {
+			inContent: `
+name: frontend
+type: Load Balanced Web App
+image:
+  build: frontend/Dockerfile
+  port: 80
+healthcheck:
+  path: /health
+  healthyThreshold: 1
+  interval: 10
+  timeout: 10
+  successCodes: 2xx
+  container:
+    command: ["curl", "localhost"]
+    retries: 20
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 9, Column: 21, Msg: `healthcheck.healthyThreshold 1 must be between 2 and 10`},
+				{Line: 11, Column: 12, Msg: `health check timeout 10 must be less than interval 10`},
+				{Line: 12, Column: 17, Msg: `health check successCodes "2xx" must be HTTP codes such as "200", "200,202" or "200-299"`},
+				{Line: 14, Column: 14, Msg: `container health check command must start with "CMD" or "CMD-SHELL" followed by the command to run`},
+				{Line: 15, Column: 14, Msg: `healthcheck.container.retries 20 must be between 1 and 10`},
+			},
+		},
+		"invalid scheduled job": {
+			inContent: `
+name: reports
+type: Scheduled Job
+image:
+  build: reports/Dockerfile
+schedule: every night
+timeout: 2d
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 6, Column: 11, Msg: `invalid schedule "every night": must be a "cron(...)" or "rate(...)" expression`},
+				{Line: 7, Column: 10, Msg: `invalid timeout "2d": time: unknown unit "d" in duration "2d"`},
+			},
+		},
+		"invalid worker service scaling": {
+			inContent: `
+name: thumbnails
+type: Worker Service
+image:
+  build: thumbnails/Dockerfile
+cpu: 2048
+memory: 2048
+scaling:
+  minCount: 3
+  maxCount: 1
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 7, Column: 9, Msg: `memory 2048 is not supported with cpu 2048, must be a multiple of 1024 between 4096 and 16384`},
+				{Line: 9, Column: 13, Msg: `scaling minCount 3 must be less than or equal to maxCount 1`},
+			},
+		},
+		"inherited problems are reported once": {
+			inContent: `
+name: thumbnails
+type: Worker Service
+image:
+  build: thumbnails/Dockerfile
+cpu: 2048
+memory: 2048
+scaling:
+  minCount: 3
+  maxCount: 1
+environments:
+  test:
+    count: 1
+  prod:
+    variables:
+      LOG_LEVEL: info
+`,
+			wantedProblems: []*ValidationError{
+				{Line: 7, Column: 9, Msg: `memory 2048 is not supported with cpu 2048, must be a multiple of 1024 between 4096 and 16384`},
+				{Line: 9, Column: 13, Msg: `scaling minCount 3 must be less than or equal to maxCount 1`},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := ValidateApp([]byte(tc.inContent))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.IsType(t, tc.wantedErr, err)
+				return
+			}
+			if tc.wantedProblems == nil {
+				require.NoError(t, err)
+				return
+			}
+			var invalidErr *ErrInvalidAppManifest
+			require.True(t, errors.As(err, &invalidErr), "expected an ErrInvalidAppManifest, got: %v", err)
+			require.Equal(t, tc.wantedProblems, invalidErr.Errors)
+		})
+	}
+}
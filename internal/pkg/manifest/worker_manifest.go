@@ -0,0 +1,219 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/aws/amazon-ecs-cli-v2/templates"
+)
+
+// WorkerServiceManifest holds the configuration to build a container image that processes messages
+// from an SQS queue with AWS Fargate as the compute engine.
+type WorkerServiceManifest struct {
+	AppManifest         `yaml:",inline,omitempty"`
+	Image               AppImage `yaml:",omitempty"`
+	WorkerServiceConfig `yaml:",inline,omitempty"`
+	Environments        map[string]WorkerServiceConfig `yaml:",omitempty"` // Fields to override per environment.
+}
+
+// WorkerServiceConfig represents a queue-driven service with AWS Fargate as compute.
+type WorkerServiceConfig struct {
+	ContainersConfig `yaml:",inline,omitempty"`
+	Queue            QueueConfig         `yaml:"queue,omitempty"`
+	Scaling          *QueueScalingConfig `yaml:",omitempty"`
+	Deployment       DeploymentConfig    `yaml:"deployment,omitempty"`
+	Hooks            DeployHooks         `yaml:"hooks,omitempty"`
+}
+
+// QueueConfig holds the settings of the SQS queue the worker service reads messages from.
+type QueueConfig struct {
+	VisibilityTimeout int `yaml:"visibilityTimeout,omitempty"` // In seconds.
+	MaxReceiveCount   int `yaml:"maxReceiveCount,omitempty"`   // Number of receives before a message is moved to the dead-letter queue.
+}
+
+// QueueScalingConfig is the configuration to scale the service with a target tracking scaling policy
+// on the number of visible messages in its queue.
+type QueueScalingConfig struct {
+	MinCount int `yaml:"minCount,omitempty"`
+	MaxCount int `yaml:"maxCount,omitempty"`
+
+	TargetBacklog float64 `yaml:"targetBacklog,omitempty"` // Number of visible messages to maintain in the queue.
+}
+
+// NewWorkerServiceManifest creates a new worker service that has a single task with minimal CPU and Memory
+// thresholds and reads from a queue with the default SQS settings.
+func NewWorkerServiceManifest(appName, dockerfile string) *WorkerServiceManifest {
+	return &WorkerServiceManifest{
+		AppManifest: AppManifest{
+			Name: appName,
+			Type: WorkerService,
+		},
+		Image: AppImage{
+			Build: BuildArgsOrString{
+				BuildString: dockerfile,
+			},
+		},
+		WorkerServiceConfig: WorkerServiceConfig{
+			ContainersConfig: ContainersConfig{
+				CPU:    256,
+				Memory: 512,
+				Count:  1,
+			},
+			Queue: QueueConfig{
+				VisibilityTimeout: 30,
+				MaxReceiveCount:   3,
+			},
+		},
+	}
+}
+
+// Marshal serializes the manifest object into a YAML document.
+func (m *WorkerServiceManifest) Marshal() ([]byte, error) {
+	box := templates.Box()
+	content, err := box.FindString("worker-service/manifest.yml")
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := template.New("template").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, *m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DockerfilePath returns the image build path.
+func (m WorkerServiceManifest) DockerfilePath() string {
+	return m.Image.dockerfilePath()
+}
+
+// BuildConfig returns the options to build the image with, or nil if the image isn't built from a Dockerfile.
+func (m WorkerServiceManifest) BuildConfig() *DockerBuildArgs {
+	return m.Image.BuildConfig()
+}
+
+// ImageLocation returns the URI of the pre-built image, or an empty string if the image is built from the Dockerfile.
+func (m WorkerServiceManifest) ImageLocation() string {
+	return m.Image.Location
+}
+
+// DeploymentConfig returns the settings of the service's deployments to the environment.
+func (m *WorkerServiceManifest) DeploymentConfig(envName string) DeploymentConfig {
+	return m.EnvConf(envName).Deployment
+}
+
+// DeployHooks returns the one-off tasks run while deploying the service to the environment.
+func (m *WorkerServiceManifest) DeployHooks(envName string) DeployHooks {
+	return m.EnvConf(envName).Hooks
+}
+
+// EnvConf returns the service configuration with environment overrides.
+// If the environment passed in does not have any overrides then we return the default values.
+func (m *WorkerServiceManifest) EnvConf(envName string) WorkerServiceConfig {
+	if _, ok := m.Environments[envName]; !ok {
+		return m.WorkerServiceConfig
+	}
+
+	// We don't want to modify the default settings, so deep copy into a "conf" variable.
+	envVars := make(map[string]string, len(m.Variables))
+	for k, v := range m.Variables {
+		envVars[k] = v
+	}
+	secrets := make(map[string]Secret, len(m.Secrets))
+	for k, v := range m.Secrets {
+		secrets[k] = v
+	}
+	var scaling *QueueScalingConfig
+	if m.Scaling != nil {
+		scaling = &QueueScalingConfig{
+			MinCount:      m.Scaling.MinCount,
+			MaxCount:      m.Scaling.MaxCount,
+			TargetBacklog: m.Scaling.TargetBacklog,
+		}
+	}
+	conf := WorkerServiceConfig{
+		ContainersConfig: ContainersConfig{
+			CPU:       m.CPU,
+			Memory:    m.Memory,
+			Count:     m.Count,
+			Variables: envVars,
+			Secrets:   secrets,
+		},
+		Queue:      m.Queue,
+		Scaling:    scaling,
+		Deployment: m.Deployment,
+		Hooks:      m.Hooks,
+	}
+
+	// Override with fields set in the environment.
+	target := m.Environments[envName]
+	if target.CPU != 0 {
+		conf.CPU = target.CPU
+	}
+	if target.Memory != 0 {
+		conf.Memory = target.Memory
+	}
+	if target.Count != 0 {
+		conf.Count = target.Count
+	}
+	for k, v := range target.Variables {
+		conf.Variables[k] = v
+	}
+	for k, v := range target.Secrets {
+		conf.Secrets[k] = v
+	}
+	if target.Queue.VisibilityTimeout != 0 {
+		conf.Queue.VisibilityTimeout = target.Queue.VisibilityTimeout
+	}
+	if target.Queue.MaxReceiveCount != 0 {
+		conf.Queue.MaxReceiveCount = target.Queue.MaxReceiveCount
+	}
+	if target.Scaling != nil {
+		if conf.Scaling == nil {
+			conf.Scaling = &QueueScalingConfig{}
+		}
+		if target.Scaling.MinCount != 0 {
+			conf.Scaling.MinCount = target.Scaling.MinCount
+		}
+		if target.Scaling.MaxCount != 0 {
+			conf.Scaling.MaxCount = target.Scaling.MaxCount
+		}
+		if target.Scaling.TargetBacklog != 0 {
+			conf.Scaling.TargetBacklog = target.Scaling.TargetBacklog
+		}
+	}
+	conf.Deployment.override(target.Deployment)
+	conf.Hooks.override(target.Hooks)
+	return conf
+}
+
+// validate returns an error if the secrets or the scaling of the default configuration or of any environment
+// can't be deployed.
+func (m *WorkerServiceManifest) validate() error {
+	if err := m.WorkerServiceConfig.validate(); err != nil {
+		return err
+	}
+	for envName := range m.Environments {
+		if err := m.EnvConf(envName).validate(); err != nil {
+			return fmt.Errorf("environment %s: %w", envName, err)
+		}
+	}
+	return nil
+}
+
+func (c WorkerServiceConfig) validate() error {
+	if err := validateSecrets(c.Secrets); err != nil {
+		return err
+	}
+	if c.Scaling != nil && c.Scaling.MinCount > c.Scaling.MaxCount {
+		return &ErrInvalidScaling{MinCount: c.Scaling.MinCount, MaxCount: c.Scaling.MaxCount}
+	}
+	return nil
+}
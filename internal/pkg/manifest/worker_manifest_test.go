@@ -0,0 +1,212 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerServiceManifest_Marshal(t *testing.T) {
+	// GIVEN
+	wantedContent := `# yaml-language-server: $schema=./app.schema.json
+# The manifest for the "thumbnails" application.
+# Read the full specification for the "Worker Service" type at:
+#  https://github.com/aws/amazon-ecs-cli-v2/wiki/Manifests#worker-service
+
+# Your application name will be used in naming your resources like log groups, services, etc.
+name: thumbnails
+# The "architecture" of the application you're running.
+type: Worker Service
+
+image:
+  # Path to your application's Dockerfile.
+  build: thumbnails/Dockerfile
+  # Or, the options of the "docker build" command, with paths relative to the root of your workspace.
+  # Build args can take their value from your shell, for example to pass secrets from your CI system.
+  # build:
+  #   dockerfile: thumbnails/Dockerfile
+  #   context: .
+  #   target: release
+  #   args:
+  #     NPM_TOKEN: ${NPM_TOKEN}
+  #   cache_from:
+  #     - 12345.dkr.ecr.us-west-2.amazonaws.com/project/app:latest
+  # Or, instead of building the Dockerfile, the URI of a pre-built image to deploy as is.
+  # location: nginx:latest
+
+# The SQS queue your service reads messages from, its URL is available in the ECS_CLI_QUEUE_URL variable.
+queue:
+  # Number of seconds a received message is hidden from other consumers.
+  visibilityTimeout: 30
+  # Number of times a message is received before it's moved to the dead-letter queue.
+  maxReceiveCount: 3
+
+# Number of CPU units for the task.
+cpu: 256
+# Amount of memory in MiB used by the task.
+memory: 512
+# Number of tasks that should be running in your service.
+count: 1
+
+# Optional fields for more advanced use-cases.
+#
+#scaling:                      # Scale the number of tasks on the number of visible messages in the queue.
+#  minCount: 0
+#  maxCount: 10
+#  targetBacklog: 100
+#
+#variables:                    # Pass environment variables as key value pairs.
+#  LOG_LEVEL: info
+#
+#secrets:                      # Pass secrets from AWS Systems Manager (SSM) Parameter Store.
+#  GITHUB_TOKEN: GITHUB_TOKEN  # The key is the name of the environment variable, the value is the name of the SSM parameter.
+
+# You can override any of the values defined above by environment.
+#environments:
+#  test:
+#    count: 2               # Number of tasks to run for the "test" environment.
+`
+	m := NewWorkerServiceManifest("thumbnails", "thumbnails/Dockerfile")
+
+	// WHEN
+	b, err := m.Marshal()
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, wantedContent, strings.Replace(string(b), "\r\n", "\n", -1))
+}
+
+func TestWorkerServiceManifest_EnvConf(t *testing.T) {
+	testCases := map[string]struct {
+		inDefaultConfig  WorkerServiceConfig
+		inEnvNameToQuery string
+		inEnvOverride    map[string]WorkerServiceConfig
+
+		wantedConfig WorkerServiceConfig
+	}{
+		"with no existing environments": {
+			inDefaultConfig: WorkerServiceConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+				Queue: QueueConfig{
+					VisibilityTimeout: 30,
+					MaxReceiveCount:   3,
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+
+			wantedConfig: WorkerServiceConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+				Queue: QueueConfig{
+					VisibilityTimeout: 30,
+					MaxReceiveCount:   3,
+				},
+			},
+		},
+		"with partial overrides": {
+			inDefaultConfig: WorkerServiceConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+				Queue: QueueConfig{
+					VisibilityTimeout: 30,
+					MaxReceiveCount:   3,
+				},
+				Scaling: &QueueScalingConfig{
+					MinCount:      1,
+					MaxCount:      5,
+					TargetBacklog: 100,
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+			inEnvOverride: map[string]WorkerServiceConfig{
+				"prod-iad": {
+					Queue: QueueConfig{
+						VisibilityTimeout: 120,
+					},
+					Scaling: &QueueScalingConfig{
+						MaxCount: 20,
+					},
+				},
+			},
+
+			wantedConfig: WorkerServiceConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:       256,
+					Memory:    512,
+					Count:     1,
+					Variables: map[string]string{},
+					Secrets:   map[string]Secret{},
+				},
+				Queue: QueueConfig{
+					VisibilityTimeout: 120,
+					MaxReceiveCount:   3,
+				},
+				Scaling: &QueueScalingConfig{
+					MinCount:      1,
+					MaxCount:      20,
+					TargetBacklog: 100,
+				},
+			},
+		},
+		"with scaling only in the environment": {
+			inDefaultConfig: WorkerServiceConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  1,
+				},
+			},
+			inEnvNameToQuery: "prod-iad",
+			inEnvOverride: map[string]WorkerServiceConfig{
+				"prod-iad": {
+					Scaling: &QueueScalingConfig{
+						MaxCount: 10,
+					},
+				},
+			},
+
+			wantedConfig: WorkerServiceConfig{
+				ContainersConfig: ContainersConfig{
+					CPU:       256,
+					Memory:    512,
+					Count:     1,
+					Variables: map[string]string{},
+					Secrets:   map[string]Secret{},
+				},
+				Scaling: &QueueScalingConfig{
+					MaxCount: 10,
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mft := &WorkerServiceManifest{
+				WorkerServiceConfig: tc.inDefaultConfig,
+				Environments:        tc.inEnvOverride,
+			}
+
+			// WHEN
+			conf := mft.EnvConf(tc.inEnvNameToQuery)
+
+			// THEN
+			require.Equal(t, tc.wantedConfig, conf, "returned configuration should have overrides from the environment")
+		})
+	}
+}
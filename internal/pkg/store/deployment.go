@@ -0,0 +1,78 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// maxDeployments is the number of deployments kept per application and environment.
+const maxDeployments = 20
+
+// CreateDeployment records a deployment of an application to an environment with the next ID in its history.
+// The oldest deployments are removed so that at most maxDeployments are kept.
+func (s *Store) CreateDeployment(d *archer.Deployment) error {
+	deployments, err := s.ListDeployments(d.Project, d.App, d.Env)
+	if err != nil {
+		return err
+	}
+	d.ID = 1
+	if len(deployments) > 0 {
+		d.ID = deployments[len(deployments)-1].ID + 1
+	}
+
+	data, err := marshal(d)
+	if err != nil {
+		return fmt.Errorf("serializing deployment %d of application %s: %w", d.ID, d.App, err)
+	}
+	_, err = s.ssmClient.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(fmt.Sprintf(fmtDeploymentParamPath, d.Project, d.App, d.Env, d.ID)),
+		Description: aws.String(fmt.Sprintf("Deployment %d of application %s to environment %s", d.ID, d.App, d.Env)),
+		// The interpolated manifest can hold values of the deployer's environment variables.
+		Type: aws.String(ssm.ParameterTypeSecureString),
+		// Manifests can exceed the size limit of standard parameters.
+		Tier:  aws.String(ssm.ParameterTierIntelligentTiering),
+		Value: aws.String(data),
+	})
+	if err != nil {
+		return fmt.Errorf("create deployment %d of application %s to environment %s: %w", d.ID, d.App, d.Env, err)
+	}
+
+	if extra := len(deployments) + 1 - maxDeployments; extra > 0 {
+		for _, old := range deployments[:extra] {
+			if _, err := s.ssmClient.DeleteParameter(&ssm.DeleteParameterInput{
+				Name: aws.String(fmt.Sprintf(fmtDeploymentParamPath, old.Project, old.App, old.Env, old.ID)),
+			}); err != nil {
+				return fmt.Errorf("delete deployment %d of application %s to environment %s: %w", old.ID, old.App, old.Env, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ListDeployments returns the recorded deployments of an application to an environment sorted by ID.
+func (s *Store) ListDeployments(projectName, appName, envName string) ([]*archer.Deployment, error) {
+	serializedDeployments, err := s.listParams(fmt.Sprintf(rootDeploymentParamPath, projectName, appName, envName))
+	if err != nil {
+		return nil, fmt.Errorf("list deployments of application %s to environment %s: %w", appName, envName, err)
+	}
+	var deployments []*archer.Deployment
+	for _, serialized := range serializedDeployments {
+		var d archer.Deployment
+		if err := json.Unmarshal([]byte(*serialized), &d); err != nil {
+			return nil, fmt.Errorf("read deployment details of application %s to environment %s: %w", appName, envName, err)
+		}
+		deployments = append(deployments, &d)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].ID < deployments[j].ID
+	})
+	return deployments, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_CreateDeployment(t *testing.T) {
+	deploymentsPath := fmt.Sprintf(rootDeploymentParamPath, "phonetool", "frontend", "test")
+	serializedDeployments := func(ids ...int) []*ssm.Parameter {
+		var params []*ssm.Parameter
+		for _, id := range ids {
+			data, err := marshal(archer.Deployment{Project: "phonetool", App: "frontend", Env: "test", ID: id})
+			require.NoError(t, err)
+			params = append(params, &ssm.Parameter{
+				Name:  aws.String(fmt.Sprintf(fmtDeploymentParamPath, "phonetool", "frontend", "test", id)),
+				Value: aws.String(data),
+			})
+		}
+		return params
+	}
+
+	testCases := map[string]struct {
+		existing []*ssm.Parameter
+		putErr   error
+
+		wantedID      int
+		wantedDeleted []string
+		wantedErr     error
+	}{
+		"first deployment": {
+			wantedID: 1,
+		},
+		"follows the latest deployment": {
+			existing: serializedDeployments(3, 1, 2),
+			wantedID: 4,
+		},
+		"removes the oldest deployments": {
+			existing: serializedDeployments(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20),
+			wantedID: 21,
+			wantedDeleted: []string{
+				fmt.Sprintf(fmtDeploymentParamPath, "phonetool", "frontend", "test", 1),
+			},
+		},
+		"with SSM error": {
+			putErr:    errors.New("broken"),
+			wantedID:  1,
+			wantedErr: errors.New("create deployment 1 of application frontend to environment test: broken"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var deleted []string
+			store := &Store{
+				ssmClient: &mockSSM{
+					t: t,
+					mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+						require.Equal(t, deploymentsPath, *param.Path)
+						require.True(t, aws.BoolValue(param.WithDecryption))
+						return &ssm.GetParametersByPathOutput{Parameters: tc.existing}, nil
+					},
+					mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+						require.Equal(t, fmt.Sprintf(fmtDeploymentParamPath, "phonetool", "frontend", "test", tc.wantedID), *param.Name)
+						require.Equal(t, ssm.ParameterTierIntelligentTiering, *param.Tier)
+						require.Equal(t, ssm.ParameterTypeSecureString, *param.Type)
+						return &ssm.PutParameterOutput{}, tc.putErr
+					},
+					mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+						deleted = append(deleted, *param.Name)
+						return &ssm.DeleteParameterOutput{}, nil
+					},
+				},
+			}
+			d := &archer.Deployment{Project: "phonetool", App: "frontend", Env: "test", ImageTag: "v1.2"}
+
+			// WHEN
+			err := store.CreateDeployment(d)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedID, d.ID)
+			require.Equal(t, tc.wantedDeleted, deleted)
+		})
+	}
+}
@@ -3,7 +3,12 @@
 
 package store
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+)
 
 // ErrNoSuchProject means a project couldn't be found within a specific account and region.
 type ErrNoSuchProject struct {
@@ -130,3 +135,31 @@ func (e *ErrNoSuchApplication) Error() string {
 	return fmt.Sprintf("couldn't find application %s in the project %s",
 		e.ApplicationName, e.ProjectName)
 }
+
+// ErrDeploymentLocked means that an application is already being deployed to an environment.
+type ErrDeploymentLocked struct {
+	Lock *archer.DeploymentLock
+}
+
+func (e *ErrDeploymentLocked) Error() string {
+	return fmt.Sprintf("application %s is being deployed to environment %s by %s since %s",
+		e.Lock.App, e.Lock.Env, e.Lock.Owner, e.Lock.AcquiredAt.Local().Format(time.RFC3339))
+}
+
+// ErrNoSuchSecret means a secret couldn't be found.
+type ErrNoSuchSecret struct {
+	SecretName string
+}
+
+// Is returns whether the provided error equals this error.
+func (e *ErrNoSuchSecret) Is(target error) bool {
+	t, ok := target.(*ErrNoSuchSecret)
+	if !ok {
+		return false
+	}
+	return e.SecretName == t.SecretName
+}
+
+func (e *ErrNoSuchSecret) Error() string {
+	return fmt.Sprintf("couldn't find secret %s", e.SecretName)
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// AcquireDeploymentLock locks the deployments of an application to an environment.
+// If another deployment holds a lock that hasn't expired yet, it returns ErrDeploymentLocked.
+func (s *Store) AcquireDeploymentLock(lock *archer.DeploymentLock) error {
+	err := s.putDeploymentLock(lock)
+	if err == nil {
+		return nil
+	}
+	if !isParameterAlreadyExists(err) {
+		return fmt.Errorf("acquire deployment lock of application %s in environment %s: %w", lock.App, lock.Env, err)
+	}
+
+	existing, err := s.GetDeploymentLock(lock.Project, lock.App, lock.Env)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		// The lock was released since we tried to acquire it.
+		return s.AcquireDeploymentLock(lock)
+	}
+	if !existing.Expired(time.Now()) {
+		return &ErrDeploymentLocked{Lock: existing}
+	}
+	return s.takeOverDeploymentLock(lock, existing)
+}
+
+// takeOverDeploymentLock replaces a stale lock. Other deployments may be taking it over too, so the first one
+// to claim the stale lock deletes it, and the lock is only created if it doesn't exist: the other deployments
+// find the new lock and return ErrDeploymentLocked.
+func (s *Store) takeOverDeploymentLock(lock, stale *archer.DeploymentLock) error {
+	claim := fmt.Sprintf(fmtDeploymentLockTakeoverParamPath, lock.Project, lock.App, lock.Env, stale.AcquiredAt.UnixNano())
+	_, err := s.ssmClient.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(claim),
+		Description: aws.String(fmt.Sprintf("Takeover of the stale deployment lock of application %s in environment %s", lock.App, lock.Env)),
+		Type:        aws.String(ssm.ParameterTypeString),
+		Value:       aws.String(lock.Owner),
+		Overwrite:   aws.Bool(false),
+	})
+	if isParameterAlreadyExists(err) {
+		return s.lostDeploymentLock(lock)
+	}
+	if err != nil {
+		return fmt.Errorf("claim stale deployment lock of application %s in environment %s: %w", lock.App, lock.Env, err)
+	}
+	defer s.ssmClient.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(claim),
+	})
+
+	// A deployment that claimed the lock before us may have replaced it already.
+	current, err := s.GetDeploymentLock(lock.Project, lock.App, lock.Env)
+	if err != nil {
+		return err
+	}
+	if current == nil || current.Owner != stale.Owner || !current.AcquiredAt.Equal(stale.AcquiredAt) {
+		return s.lostDeploymentLock(lock)
+	}
+	if err := s.ReleaseDeploymentLock(lock.Project, lock.App, lock.Env); err != nil {
+		return err
+	}
+	err = s.putDeploymentLock(lock)
+	if isParameterAlreadyExists(err) {
+		return s.lostDeploymentLock(lock)
+	}
+	if err != nil {
+		return fmt.Errorf("acquire deployment lock of application %s in environment %s: %w", lock.App, lock.Env, err)
+	}
+	return nil
+}
+
+// lostDeploymentLock returns ErrDeploymentLocked with the lock of the deployment taking over the stale lock.
+func (s *Store) lostDeploymentLock(lock *archer.DeploymentLock) error {
+	winner, err := s.GetDeploymentLock(lock.Project, lock.App, lock.Env)
+	if err != nil {
+		return err
+	}
+	if winner == nil {
+		// The deployment that took over the lock is done already.
+		return s.AcquireDeploymentLock(lock)
+	}
+	return &ErrDeploymentLocked{Lock: winner}
+}
+
+// GetDeploymentLock returns the deployment lock of an application in an environment, or nil if the application isn't locked.
+func (s *Store) GetDeploymentLock(projectName, appName, envName string) (*archer.DeploymentLock, error) {
+	param, err := s.ssmClient.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(fmt.Sprintf(fmtDeploymentLockParamPath, projectName, appName, envName)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case ssm.ErrCodeParameterNotFound:
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("get deployment lock of application %s in environment %s: %w", appName, envName, err)
+	}
+
+	var lock archer.DeploymentLock
+	if err := json.Unmarshal([]byte(*param.Parameter.Value), &lock); err != nil {
+		return nil, fmt.Errorf("read deployment lock of application %s in environment %s: %w", appName, envName, err)
+	}
+	return &lock, nil
+}
+
+// ReleaseDeploymentLock removes the deployment lock of an application in an environment, if there is one.
+func (s *Store) ReleaseDeploymentLock(projectName, appName, envName string) error {
+	_, err := s.ssmClient.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(fmt.Sprintf(fmtDeploymentLockParamPath, projectName, appName, envName)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case ssm.ErrCodeParameterNotFound:
+				return nil
+			}
+		}
+		return fmt.Errorf("release deployment lock of application %s in environment %s: %w", appName, envName, err)
+	}
+	return nil
+}
+
+// putDeploymentLock creates the lock parameter, it fails with ParameterAlreadyExists if the application is locked.
+func (s *Store) putDeploymentLock(lock *archer.DeploymentLock) error {
+	data, err := marshal(lock)
+	if err != nil {
+		return fmt.Errorf("serializing deployment lock of application %s: %w", lock.App, err)
+	}
+	_, err = s.ssmClient.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(fmt.Sprintf(fmtDeploymentLockParamPath, lock.Project, lock.App, lock.Env)),
+		Description: aws.String(fmt.Sprintf("Deployment lock of application %s in environment %s", lock.App, lock.Env)),
+		Type:        aws.String(ssm.ParameterTypeString),
+		Value:       aws.String(data),
+		Overwrite:   aws.Bool(false),
+	})
+	return err
+}
+
+func isParameterAlreadyExists(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == ssm.ErrCodeParameterAlreadyExists
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLockParameters is an in-memory SSM parameter store, to interleave the deployments taking over a lock.
+type fakeLockParameters struct {
+	values map[string]string
+	putErr error
+	onPut  func(name string) // Called once a parameter is created.
+}
+
+func (f *fakeLockParameters) client(t *testing.T) *mockSSM {
+	return &mockSSM{
+		t: t,
+		mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+			require.False(t, aws.BoolValue(param.Overwrite), "the lock parameters are never overwritten")
+			if f.putErr != nil {
+				return nil, f.putErr
+			}
+			if _, ok := f.values[*param.Name]; ok {
+				return nil, awserr.New(ssm.ErrCodeParameterAlreadyExists, "exists", nil)
+			}
+			f.values[*param.Name] = *param.Value
+			if f.onPut != nil {
+				f.onPut(*param.Name)
+			}
+			return &ssm.PutParameterOutput{}, nil
+		},
+		mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+			value, ok := f.values[*param.Name]
+			if !ok {
+				return nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+			}
+			return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String(value)}}, nil
+		},
+		mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+			if _, ok := f.values[*param.Name]; !ok {
+				return nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+			}
+			delete(f.values, *param.Name)
+			return &ssm.DeleteParameterOutput{}, nil
+		},
+	}
+}
+
+func TestStore_AcquireDeploymentLock(t *testing.T) {
+	lockPath := fmt.Sprintf(fmtDeploymentLockParamPath, "phonetool", "frontend", "prod")
+	lockedBy := func(owner string, acquiredAt time.Time) *archer.DeploymentLock {
+		return &archer.DeploymentLock{
+			Project:    "phonetool",
+			App:        "frontend",
+			Env:        "prod",
+			Owner:      owner,
+			AcquiredAt: acquiredAt,
+			TTL:        time.Hour,
+		}
+	}
+	serialized := func(lock *archer.DeploymentLock) string {
+		data, err := marshal(lock)
+		require.NoError(t, err)
+		return data
+	}
+	heldSince := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	staleSince := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	bob := lockedBy("arn:aws:iam::1234:user/bob", time.Now().UTC().Truncate(time.Second))
+
+	testCases := map[string]struct {
+		inValues map[string]string
+		putErr   error
+
+		wantedValues map[string]string
+		wantedErr    error
+	}{
+		"not locked": {
+			inValues: map[string]string{},
+
+			wantedValues: map[string]string{lockPath: serialized(bob)},
+		},
+		"locked by another deployment": {
+			inValues: map[string]string{lockPath: serialized(lockedBy("arn:aws:iam::1234:user/alice", heldSince))},
+
+			wantedValues: map[string]string{lockPath: serialized(lockedBy("arn:aws:iam::1234:user/alice", heldSince))},
+			wantedErr:    &ErrDeploymentLocked{Lock: lockedBy("arn:aws:iam::1234:user/alice", heldSince)},
+		},
+		"takes over a stale lock": {
+			inValues: map[string]string{lockPath: serialized(lockedBy("arn:aws:iam::1234:user/alice", staleSince))},
+
+			wantedValues: map[string]string{lockPath: serialized(bob)},
+		},
+		"stale lock claimed by another deployment": {
+			inValues: map[string]string{
+				lockPath: serialized(lockedBy("arn:aws:iam::1234:user/alice", staleSince)),
+				fmt.Sprintf(fmtDeploymentLockTakeoverParamPath, "phonetool", "frontend", "prod", staleSince.UnixNano()): "arn:aws:iam::1234:user/carol",
+			},
+
+			wantedValues: map[string]string{
+				lockPath: serialized(lockedBy("arn:aws:iam::1234:user/alice", staleSince)),
+				fmt.Sprintf(fmtDeploymentLockTakeoverParamPath, "phonetool", "frontend", "prod", staleSince.UnixNano()): "arn:aws:iam::1234:user/carol",
+			},
+			wantedErr: &ErrDeploymentLocked{Lock: lockedBy("arn:aws:iam::1234:user/alice", staleSince)},
+		},
+		"with SSM error": {
+			inValues: map[string]string{},
+			putErr:   errors.New("broken"),
+
+			wantedValues: map[string]string{},
+			wantedErr:    errors.New("acquire deployment lock of application frontend in environment prod: broken"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			params := &fakeLockParameters{values: tc.inValues, putErr: tc.putErr}
+			store := &Store{ssmClient: params.client(t)}
+
+			// WHEN
+			err := store.AcquireDeploymentLock(bob)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				var locked *ErrDeploymentLocked
+				if errors.As(tc.wantedErr, &locked) {
+					require.Equal(t, tc.wantedErr, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedValues, params.values)
+		})
+	}
+}
+
+func TestStore_AcquireDeploymentLockRace(t *testing.T) {
+	lockPath := fmt.Sprintf(fmtDeploymentLockParamPath, "phonetool", "frontend", "prod")
+	lockedBy := func(owner string, acquiredAt time.Time) *archer.DeploymentLock {
+		return &archer.DeploymentLock{
+			Project:    "phonetool",
+			App:        "frontend",
+			Env:        "prod",
+			Owner:      owner,
+			AcquiredAt: acquiredAt,
+			TTL:        time.Hour,
+		}
+	}
+	stale := lockedBy("arn:aws:iam::1234:user/alice", time.Now().Add(-2*time.Hour).UTC().Truncate(time.Second))
+	bob := lockedBy("arn:aws:iam::1234:user/bob", time.Now().UTC().Truncate(time.Second))
+	carol := lockedBy("arn:aws:iam::1234:user/carol", time.Now().UTC().Truncate(time.Second))
+	claimPath := fmt.Sprintf(fmtDeploymentLockTakeoverParamPath, "phonetool", "frontend", "prod", stale.AcquiredAt.UnixNano())
+
+	testCases := map[string]struct {
+		interleaveAfter string // Carol takes over the stale lock once Bob creates this parameter.
+
+		wantedOwner string
+	}{
+		"the other deployment claims the stale lock during the takeover": {
+			interleaveAfter: claimPath,
+
+			wantedOwner: bob.Owner,
+		},
+		"the other deployment read the stale lock before the takeover": {
+			interleaveAfter: lockPath,
+
+			wantedOwner: bob.Owner,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			data, err := marshal(stale)
+			require.NoError(t, err)
+			params := &fakeLockParameters{values: map[string]string{lockPath: data}}
+			store := &Store{ssmClient: params.client(t)}
+			var carolErr error
+			params.onPut = func(name string) {
+				if name != tc.interleaveAfter || carolErr != nil {
+					return
+				}
+				// Carol read the stale lock like Bob did, and takes it over at the same time.
+				carolErr = store.takeOverDeploymentLock(carol, stale)
+			}
+
+			// WHEN
+			bobErr := store.AcquireDeploymentLock(bob)
+
+			// THEN
+			require.NoError(t, bobErr)
+			var locked *ErrDeploymentLocked
+			require.True(t, errors.As(carolErr, &locked), "only one deployment takes over the stale lock, got %v", carolErr)
+			current, err := store.GetDeploymentLock("phonetool", "frontend", "prod")
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedOwner, current.Owner)
+			require.NotContains(t, params.values, claimPath, "the claim is removed")
+			lateErr := store.takeOverDeploymentLock(lockedBy("arn:aws:iam::1234:user/dave", time.Now()), stale)
+			require.Equal(t, &ErrDeploymentLocked{Lock: current}, lateErr, "a deployment that read the stale lock before the takeover finds the new lock")
+		})
+	}
+}
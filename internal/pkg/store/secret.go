@@ -1,7 +1,11 @@
 package store
 
 import (
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
 
@@ -24,3 +28,91 @@ func (s *Store) DeleteSecret(secretName string) error {
 	})
 	return err
 }
+
+// UpdateSecret overwrites the value of an existing SecureString parameter, which increments its version.
+func (s *Store) UpdateSecret(secretName, secretString string) error {
+	if _, err := s.getSecretParameter(secretName, false); err != nil {
+		return err
+	}
+	_, err := s.ssmClient.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(secretName),
+		Overwrite: aws.Bool(true),
+		Type:      aws.String(ssm.ParameterTypeSecureString),
+		Value:     aws.String(secretString),
+	})
+	if err != nil {
+		return fmt.Errorf("update secret %s: %w", secretName, err)
+	}
+	return nil
+}
+
+// ListSecrets returns the metadata of the SecureString parameters under the path, at any depth.
+func (s *Store) ListSecrets(path string) ([]*archer.Secret, error) {
+	var secrets []*archer.Secret
+	var nextToken *string
+	for {
+		out, err := s.ssmClient.GetParametersByPath(&ssm.GetParametersByPathInput{
+			Path:      aws.String(path),
+			Recursive: aws.Bool(true),
+			ParameterFilters: []*ssm.ParameterStringFilter{
+				{
+					Key:    aws.String("Type"),
+					Option: aws.String("Equals"),
+					Values: aws.StringSlice([]string{ssm.ParameterTypeSecureString}),
+				},
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list secrets under %s: %w", path, err)
+		}
+		for _, param := range out.Parameters {
+			secrets = append(secrets, secretMetadata(param))
+		}
+		nextToken = out.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+	return secrets, nil
+}
+
+// GetSecret returns the metadata of the SecureString parameter without decrypting it.
+func (s *Store) GetSecret(secretName string) (*archer.Secret, error) {
+	param, err := s.getSecretParameter(secretName, false)
+	if err != nil {
+		return nil, err
+	}
+	return secretMetadata(param), nil
+}
+
+// GetSecretValue returns the decrypted value of the SecureString parameter.
+func (s *Store) GetSecretValue(secretName string) (string, error) {
+	param, err := s.getSecretParameter(secretName, true)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(param.Value), nil
+}
+
+func (s *Store) getSecretParameter(secretName string, withDecryption bool) (*ssm.Parameter, error) {
+	out, err := s.ssmClient.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(secretName),
+		WithDecryption: aws.Bool(withDecryption),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+			return nil, &ErrNoSuchSecret{SecretName: secretName}
+		}
+		return nil, fmt.Errorf("get secret %s: %w", secretName, err)
+	}
+	return out.Parameter, nil
+}
+
+func secretMetadata(param *ssm.Parameter) *archer.Secret {
+	return &archer.Secret{
+		Name:         aws.StringValue(param.Name),
+		Version:      aws.Int64Value(param.Version),
+		LastModified: aws.TimeValue(param.LastModifiedDate),
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_ListSecrets(t *testing.T) {
+	const path = "/ecs-cli-v2/phonetool/applications/frontend/secrets"
+	modified := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		mockGetParametersByPath func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+
+		wantedSecrets []*archer.Secret
+		wantedErr     error
+	}{
+		"lists the secrets of all pages": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, path, aws.StringValue(param.Path))
+				require.True(t, aws.BoolValue(param.Recursive))
+				if param.NextToken == nil {
+					return &ssm.GetParametersByPathOutput{
+						Parameters: []*ssm.Parameter{
+							{Name: aws.String(path + "/api-key"), Version: aws.Int64(2), LastModifiedDate: aws.Time(modified)},
+						},
+						NextToken: aws.String("next"),
+					}, nil
+				}
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Name: aws.String(path + "/api-key-prod"), Version: aws.Int64(1), LastModifiedDate: aws.Time(modified)},
+					},
+				}, nil
+			},
+			wantedSecrets: []*archer.Secret{
+				{Name: path + "/api-key", Version: 2, LastModified: modified},
+				{Name: path + "/api-key-prod", Version: 1, LastModified: modified},
+			},
+		},
+		"with SSM error": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return nil, errors.New("broken")
+			},
+			wantedErr: errors.New("list secrets under " + path + ": broken"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			store := &Store{
+				ssmClient: &mockSSM{
+					t:                       t,
+					mockGetParametersByPath: tc.mockGetParametersByPath,
+				},
+			}
+
+			// WHEN
+			secrets, err := store.ListSecrets(path)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedSecrets, secrets)
+		})
+	}
+}
+
+func TestStore_GetSecret(t *testing.T) {
+	const secretName = "/ecs-cli-v2/phonetool/applications/frontend/secrets/api-key"
+	testCases := map[string]struct {
+		mockGetParameter func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+
+		wantedSecret *archer.Secret
+		wantedErr    error
+	}{
+		"does not decrypt the value": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				require.False(t, aws.BoolValue(param.WithDecryption))
+				return &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{Name: aws.String(secretName), Version: aws.Int64(3)},
+				}, nil
+			},
+			wantedSecret: &archer.Secret{Name: secretName, Version: 3},
+		},
+		"secret not found": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+			},
+			wantedErr: &ErrNoSuchSecret{SecretName: secretName},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			store := &Store{
+				ssmClient: &mockSSM{
+					t:                t,
+					mockGetParameter: tc.mockGetParameter,
+				},
+			}
+
+			// WHEN
+			secret, err := store.GetSecret(secretName)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.True(t, errors.Is(err, tc.wantedErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedSecret, secret)
+		})
+	}
+}
+
+func TestStore_UpdateSecret(t *testing.T) {
+	const secretName = "/ecs-cli-v2/phonetool/applications/frontend/secrets/api-key"
+	testCases := map[string]struct {
+		mockGetParameter func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+		mockPutParameter func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
+
+		wantedErr error
+	}{
+		"overwrites the value": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{Name: aws.String(secretName), Version: aws.Int64(3)},
+				}, nil
+			},
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				require.Equal(t, secretName, aws.StringValue(param.Name))
+				require.Equal(t, "s3cr3t", aws.StringValue(param.Value))
+				require.Equal(t, ssm.ParameterTypeSecureString, aws.StringValue(param.Type))
+				require.True(t, aws.BoolValue(param.Overwrite))
+				return &ssm.PutParameterOutput{Version: aws.Int64(4)}, nil
+			},
+		},
+		"secret not found": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+			},
+			wantedErr: &ErrNoSuchSecret{SecretName: secretName},
+		},
+		"with SSM error": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Name: aws.String(secretName)}}, nil
+			},
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				return nil, errors.New("broken")
+			},
+			wantedErr: errors.New("update secret " + secretName + ": broken"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			store := &Store{
+				ssmClient: &mockSSM{
+					t:                t,
+					mockGetParameter: tc.mockGetParameter,
+					mockPutParameter: tc.mockPutParameter,
+				},
+			}
+
+			// WHEN
+			err := store.UpdateSecret(secretName, "s3cr3t")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
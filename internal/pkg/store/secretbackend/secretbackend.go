@@ -0,0 +1,65 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secretbackend selects the store holding the values of secrets, based on the backend of each secret
+// in the manifest.
+package secretbackend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/store/secretsmanager"
+)
+
+// Backends holds a store for each of the supported secret backends.
+type Backends struct {
+	stores map[string]archer.SecretsManager
+}
+
+// New returns the secret backends configured with the default session.
+func New() (*Backends, error) {
+	ssmStore, err := store.New()
+	if err != nil {
+		return nil, fmt.Errorf("connect to SSM parameter store: %w", err)
+	}
+	smStore, err := secretsmanager.NewStore()
+	if err != nil {
+		return nil, fmt.Errorf("connect to secrets manager: %w", err)
+	}
+	return NewWithStores(ssmStore, smStore), nil
+}
+
+// NewWithStores returns the secret backends using the provided SSM Parameter Store and Secrets Manager stores.
+func NewWithStores(ssm, secretsManager archer.SecretsManager) *Backends {
+	return &Backends{
+		stores: map[string]archer.SecretsManager{
+			manifest.SecretBackendSSM:            ssm,
+			manifest.SecretBackendSecretsManager: secretsManager,
+		},
+	}
+}
+
+// SecretBackend returns the store of the backend. An empty name is the default backend, SSM.
+func (b *Backends) SecretBackend(name string) (archer.SecretsManager, error) {
+	if name == "" {
+		name = manifest.SecretBackendSSM
+	}
+	s, ok := b.stores[name]
+	if !ok {
+		return nil, &ErrUnknownBackend{Name: name}
+	}
+	return s, nil
+}
+
+// ErrUnknownBackend means the backend of a secret isn't supported.
+type ErrUnknownBackend struct {
+	Name string
+}
+
+func (e *ErrUnknownBackend) Error() string {
+	return fmt.Sprintf("unknown secret backend %q, must be one of %s", e.Name, strings.Join(manifest.SecretBackends, ", "))
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretbackend
+
+import (
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/amazon-ecs-cli-v2/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackends_SecretBackend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ssmStore := mocks.NewMockSecretsManager(ctrl)
+	smStore := mocks.NewMockSecretsManager(ctrl)
+
+	testCases := map[string]struct {
+		inName string
+
+		wantedStore archer.SecretsManager
+		wantedErr   string
+	}{
+		"defaults to SSM": {
+			inName:      "",
+			wantedStore: ssmStore,
+		},
+		"ssm": {
+			inName:      "ssm",
+			wantedStore: ssmStore,
+		},
+		"secrets manager": {
+			inName:      "secretsmanager",
+			wantedStore: smStore,
+		},
+		"unknown backend": {
+			inName:    "vault",
+			wantedErr: `unknown secret backend "vault", must be one of ssm, secretsmanager`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			backends := NewWithStores(ssmStore, smStore)
+
+			// WHEN
+			s, err := backends.SecretBackend(tc.inName)
+
+			// THEN
+			if tc.wantedErr != "" {
+				require.EqualError(t, err, tc.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tc.wantedStore == s, "expected the store of backend %q", tc.inName)
+		})
+	}
+}
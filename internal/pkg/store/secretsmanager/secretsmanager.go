@@ -6,7 +6,10 @@ package secretsmanager
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -14,6 +17,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 )
 
+const (
+	rotationPollInterval = 5 * time.Second
+	rotationTimeout      = 5 * time.Minute
+
+	versionStageCurrent = "AWSCURRENT"
+)
+
 // SecretsManager is in charge of fetching and creating projects, environment and pipeline
 // configuration in SecretsManager.
 type SecretsManager struct {
@@ -56,15 +66,133 @@ func (s *SecretsManager) CreateSecret(secretName, secretString string) (string,
 				}
 			}
 		}
+		return "", fmt.Errorf("create secret %s: %w", secretName, err)
 	}
 
 	return aws.StringValue(resp.ARN), nil
 }
 
+// DeleteSecret schedules the deletion of the secret after the default recovery window, during which it can be restored.
 func (s *SecretsManager) DeleteSecret(secretName string) error {
+	return s.deleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(secretName),
+	})
+}
+
+// ForceDeleteSecret deletes the secret immediately, without a recovery window, so that a secret with the same name
+// can be created again right away.
+func (s *SecretsManager) ForceDeleteSecret(secretName string) error {
+	return s.deleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(secretName),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+}
+
+func (s *SecretsManager) deleteSecret(in *secretsmanager.DeleteSecretInput) error {
+	if _, err := s.secretsManager.DeleteSecret(in); err != nil {
+		return fmt.Errorf("delete secret %s: %w", aws.StringValue(in.SecretId), err)
+	}
 	return nil
 }
 
+// UpdateSecret stores a new value of the secret, which becomes its current version.
+func (s *SecretsManager) UpdateSecret(secretName, secretString string) error {
+	_, err := s.secretsManager.PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretName),
+		SecretString: aws.String(secretString),
+	})
+	if err != nil {
+		return fmt.Errorf("update secret %s: %w", secretName, err)
+	}
+	return nil
+}
+
+// RotateSecret starts a rotation of the secret with its rotation function, and waits until the new version
+// of the secret is the current one.
+func (s *SecretsManager) RotateSecret(secretName string) error {
+	out, err := s.secretsManager.RotateSecret(&secretsmanager.RotateSecretInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		return fmt.Errorf("rotate secret %s: %w", secretName, err)
+	}
+	versionID := aws.StringValue(out.VersionId)
+	deadline := time.Now().Add(rotationTimeout)
+	for {
+		secret, err := s.secretsManager.DescribeSecret(&secretsmanager.DescribeSecretInput{
+			SecretId: aws.String(secretName),
+		})
+		if err != nil {
+			return fmt.Errorf("describe secret %s: %w", secretName, err)
+		}
+		for _, stage := range secret.VersionIdsToStages[versionID] {
+			if aws.StringValue(stage) == versionStageCurrent {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("rotate secret %s: version %s is not current after %s", secretName, versionID, rotationTimeout)
+		}
+		time.Sleep(rotationPollInterval)
+	}
+}
+
+// ListSecrets returns the metadata of the secrets whose names start with the path.
+// Secrets Manager doesn't number versions, so the version of the secrets is always 0.
+func (s *SecretsManager) ListSecrets(path string) ([]*archer.Secret, error) {
+	var secrets []*archer.Secret
+	in := &secretsmanager.ListSecretsInput{
+		Filters: []*secretsmanager.Filter{
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeName),
+				Values: aws.StringSlice([]string{path}),
+			},
+		},
+	}
+	err := s.secretsManager.ListSecretsPages(in, func(out *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		// The name filter also matches the words within the names, so the prefix is checked again.
+		for _, entry := range out.SecretList {
+			if !strings.HasPrefix(aws.StringValue(entry.Name), path) {
+				continue
+			}
+			secrets = append(secrets, &archer.Secret{
+				Name:         aws.StringValue(entry.Name),
+				LastModified: aws.TimeValue(entry.LastChangedDate),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list secrets under %s: %w", path, err)
+	}
+	return secrets, nil
+}
+
+// GetSecret returns the metadata of the secret.
+func (s *SecretsManager) GetSecret(secretName string) (*archer.Secret, error) {
+	out, err := s.secretsManager.DescribeSecret(&secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe secret %s: %w", secretName, err)
+	}
+	return &archer.Secret{
+		Name:         aws.StringValue(out.Name),
+		LastModified: aws.TimeValue(out.LastChangedDate),
+	}, nil
+}
+
+// GetSecretValue returns the current value of the secret.
+func (s *SecretsManager) GetSecretValue(secretName string) (string, error) {
+	out, err := s.secretsManager.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("get value of secret %s: %w", secretName, err)
+	}
+	return aws.StringValue(out.SecretString), nil
+}
+
 type ErrSecretAlreadyExists struct {
 	secretName string
 	parentErr  error
@@ -0,0 +1,119 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package secretsmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	t                    *testing.T
+	mockDeleteSecret     func(t *testing.T, in *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error)
+	mockListSecretsPages func(t *testing.T, in *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error
+}
+
+func (m *mockSecretsManager) DeleteSecret(in *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+	return m.mockDeleteSecret(m.t, in)
+}
+
+func (m *mockSecretsManager) ListSecretsPages(in *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+	return m.mockListSecretsPages(m.t, in, fn)
+}
+
+func TestSecretsManager_DeleteSecret(t *testing.T) {
+	testCases := map[string]struct {
+		inForce bool
+
+		wantedInput *secretsmanager.DeleteSecretInput
+	}{
+		"keeps the default recovery window": {
+			wantedInput: &secretsmanager.DeleteSecretInput{
+				SecretId: aws.String("phonetool-frontend-database"),
+			},
+		},
+		"force deletes without a recovery window": {
+			inForce: true,
+			wantedInput: &secretsmanager.DeleteSecretInput{
+				SecretId:                   aws.String("phonetool-frontend-database"),
+				ForceDeleteWithoutRecovery: aws.Bool(true),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			s := &SecretsManager{
+				secretsManager: &mockSecretsManager{
+					t: t,
+					mockDeleteSecret: func(t *testing.T, in *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+						require.Equal(t, tc.wantedInput, in)
+						return &secretsmanager.DeleteSecretOutput{}, nil
+					},
+				},
+			}
+
+			// WHEN
+			var err error
+			if tc.inForce {
+				err = s.ForceDeleteSecret("phonetool-frontend-database")
+			} else {
+				err = s.DeleteSecret("phonetool-frontend-database")
+			}
+
+			// THEN
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSecretsManager_ListSecrets(t *testing.T) {
+	// GIVEN
+	lastChanged := time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := &SecretsManager{
+		secretsManager: &mockSecretsManager{
+			t: t,
+			mockListSecretsPages: func(t *testing.T, in *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+				require.Equal(t, []*secretsmanager.Filter{
+					{
+						Key:    aws.String("name"),
+						Values: aws.StringSlice([]string{"/ecs-cli-v2/phonetool/applications/frontend/secrets"}),
+					},
+				}, in.Filters)
+				fn(&secretsmanager.ListSecretsOutput{
+					SecretList: []*secretsmanager.SecretListEntry{
+						{
+							Name:            aws.String("/ecs-cli-v2/phonetool/applications/frontend/secrets/api-key"),
+							LastChangedDate: aws.Time(lastChanged),
+						},
+						{
+							Name: aws.String("/ecs-cli-v2/other/applications/frontend/secrets/api-key"),
+						},
+					},
+				}, true)
+				return nil
+			},
+		},
+	}
+
+	// WHEN
+	secrets, err := s.ListSecrets("/ecs-cli-v2/phonetool/applications/frontend/secrets")
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, []*archer.Secret{
+		{
+			Name:         "/ecs-cli-v2/phonetool/applications/frontend/secrets/api-key",
+			LastModified: lastChanged,
+		},
+	}, secrets)
+}
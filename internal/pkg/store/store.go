@@ -44,6 +44,12 @@ const (
 	fmtEnvParamPath  = "/ecs-cli-v2/%s/environments/%s" // path for an environment in a project
 	rootAppParamPath = "/ecs-cli-v2/%s/applications/"
 	fmtAppParamPath  = "/ecs-cli-v2/%s/applications/%s" // path for an application in a project
+
+	rootDeploymentParamPath = "/ecs-cli-v2/%s/deployments/%s/%s/"
+	fmtDeploymentParamPath  = "/ecs-cli-v2/%s/deployments/%s/%s/%d" // path for a deployment of an application to an environment
+
+	fmtDeploymentLockParamPath         = "/ecs-cli-v2/%s/locks/%s/%s"             // path for the deployment lock of an application in an environment
+	fmtDeploymentLockTakeoverParamPath = "/ecs-cli-v2/%s/locks/%s/%s-takeover-%d" // path for the claim on a stale lock acquired at a given time
 )
 
 type identityService interface {
@@ -93,6 +99,8 @@ func (s *Store) listParams(path string) ([]*string, error) {
 			Path:      aws.String(path),
 			Recursive: aws.Bool(false),
 			NextToken: nextToken,
+			// Deployments are stored as SecureString parameters, the other parameters are returned as is.
+			WithDecryption: aws.Bool(true),
 		})
 
 		if err != nil {
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	archer "github.com/aws/amazon-ecs-cli-v2/internal/pkg/archer"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -47,6 +48,79 @@ func (mr *MockSecretsManagerMockRecorder) CreateSecret(secretName, secretString
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretsManager)(nil).CreateSecret), secretName, secretString)
 }
 
+// DeleteSecret mocks base method
+func (m *MockSecretsManager) DeleteSecret(secretName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", secretName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret
+func (mr *MockSecretsManagerMockRecorder) DeleteSecret(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretsManager)(nil).DeleteSecret), secretName)
+}
+
+// ListSecrets mocks base method
+func (m *MockSecretsManager) ListSecrets(path string) ([]*archer.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", path)
+	ret0, _ := ret[0].([]*archer.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets
+func (mr *MockSecretsManagerMockRecorder) ListSecrets(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecretsManager)(nil).ListSecrets), path)
+}
+
+// GetSecret mocks base method
+func (m *MockSecretsManager) GetSecret(secretName string) (*archer.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", secretName)
+	ret0, _ := ret[0].(*archer.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret
+func (mr *MockSecretsManagerMockRecorder) GetSecret(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretsManager)(nil).GetSecret), secretName)
+}
+
+// GetSecretValue mocks base method
+func (m *MockSecretsManager) GetSecretValue(secretName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretValue", secretName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretValue indicates an expected call of GetSecretValue
+func (mr *MockSecretsManagerMockRecorder) GetSecretValue(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockSecretsManager)(nil).GetSecretValue), secretName)
+}
+
+// UpdateSecret mocks base method
+func (m *MockSecretsManager) UpdateSecret(secretName, secretString string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", secretName, secretString)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret
+func (mr *MockSecretsManagerMockRecorder) UpdateSecret(secretName, secretString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretsManager)(nil).UpdateSecret), secretName, secretString)
+}
+
 // MockSecretCreator is a mock of SecretCreator interface
 type MockSecretCreator struct {
 	ctrl     *gomock.Controller
@@ -84,3 +158,280 @@ func (mr *MockSecretCreatorMockRecorder) CreateSecret(secretName, secretString i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretCreator)(nil).CreateSecret), secretName, secretString)
 }
+
+// MockSecretDeleter is a mock of SecretDeleter interface
+type MockSecretDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretDeleterMockRecorder
+}
+
+// MockSecretDeleterMockRecorder is the mock recorder for MockSecretDeleter
+type MockSecretDeleterMockRecorder struct {
+	mock *MockSecretDeleter
+}
+
+// NewMockSecretDeleter creates a new mock instance
+func NewMockSecretDeleter(ctrl *gomock.Controller) *MockSecretDeleter {
+	mock := &MockSecretDeleter{ctrl: ctrl}
+	mock.recorder = &MockSecretDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretDeleter) EXPECT() *MockSecretDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteSecret mocks base method
+func (m *MockSecretDeleter) DeleteSecret(secretName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", secretName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret
+func (mr *MockSecretDeleterMockRecorder) DeleteSecret(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretDeleter)(nil).DeleteSecret), secretName)
+}
+
+// MockSecretLister is a mock of SecretLister interface
+type MockSecretLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretListerMockRecorder
+}
+
+// MockSecretListerMockRecorder is the mock recorder for MockSecretLister
+type MockSecretListerMockRecorder struct {
+	mock *MockSecretLister
+}
+
+// NewMockSecretLister creates a new mock instance
+func NewMockSecretLister(ctrl *gomock.Controller) *MockSecretLister {
+	mock := &MockSecretLister{ctrl: ctrl}
+	mock.recorder = &MockSecretListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretLister) EXPECT() *MockSecretListerMockRecorder {
+	return m.recorder
+}
+
+// ListSecrets mocks base method
+func (m *MockSecretLister) ListSecrets(path string) ([]*archer.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", path)
+	ret0, _ := ret[0].([]*archer.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets
+func (mr *MockSecretListerMockRecorder) ListSecrets(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecretLister)(nil).ListSecrets), path)
+}
+
+// MockSecretGetter is a mock of SecretGetter interface
+type MockSecretGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretGetterMockRecorder
+}
+
+// MockSecretGetterMockRecorder is the mock recorder for MockSecretGetter
+type MockSecretGetterMockRecorder struct {
+	mock *MockSecretGetter
+}
+
+// NewMockSecretGetter creates a new mock instance
+func NewMockSecretGetter(ctrl *gomock.Controller) *MockSecretGetter {
+	mock := &MockSecretGetter{ctrl: ctrl}
+	mock.recorder = &MockSecretGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretGetter) EXPECT() *MockSecretGetterMockRecorder {
+	return m.recorder
+}
+
+// GetSecret mocks base method
+func (m *MockSecretGetter) GetSecret(secretName string) (*archer.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", secretName)
+	ret0, _ := ret[0].(*archer.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret
+func (mr *MockSecretGetterMockRecorder) GetSecret(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretGetter)(nil).GetSecret), secretName)
+}
+
+// GetSecretValue mocks base method
+func (m *MockSecretGetter) GetSecretValue(secretName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretValue", secretName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretValue indicates an expected call of GetSecretValue
+func (mr *MockSecretGetterMockRecorder) GetSecretValue(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockSecretGetter)(nil).GetSecretValue), secretName)
+}
+
+// MockSecretUpdater is a mock of SecretUpdater interface
+type MockSecretUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretUpdaterMockRecorder
+}
+
+// MockSecretUpdaterMockRecorder is the mock recorder for MockSecretUpdater
+type MockSecretUpdaterMockRecorder struct {
+	mock *MockSecretUpdater
+}
+
+// NewMockSecretUpdater creates a new mock instance
+func NewMockSecretUpdater(ctrl *gomock.Controller) *MockSecretUpdater {
+	mock := &MockSecretUpdater{ctrl: ctrl}
+	mock.recorder = &MockSecretUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretUpdater) EXPECT() *MockSecretUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateSecret mocks base method
+func (m *MockSecretUpdater) UpdateSecret(secretName, secretString string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", secretName, secretString)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret
+func (mr *MockSecretUpdaterMockRecorder) UpdateSecret(secretName, secretString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretUpdater)(nil).UpdateSecret), secretName, secretString)
+}
+
+// MockSecretRotator is a mock of SecretRotator interface
+type MockSecretRotator struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretRotatorMockRecorder
+}
+
+// MockSecretRotatorMockRecorder is the mock recorder for MockSecretRotator
+type MockSecretRotatorMockRecorder struct {
+	mock *MockSecretRotator
+}
+
+// NewMockSecretRotator creates a new mock instance
+func NewMockSecretRotator(ctrl *gomock.Controller) *MockSecretRotator {
+	mock := &MockSecretRotator{ctrl: ctrl}
+	mock.recorder = &MockSecretRotatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretRotator) EXPECT() *MockSecretRotatorMockRecorder {
+	return m.recorder
+}
+
+// RotateSecret mocks base method
+func (m *MockSecretRotator) RotateSecret(secretName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecret", secretName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSecret indicates an expected call of RotateSecret
+func (mr *MockSecretRotatorMockRecorder) RotateSecret(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockSecretRotator)(nil).RotateSecret), secretName)
+}
+
+// MockSecretForceDeleter is a mock of SecretForceDeleter interface
+type MockSecretForceDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretForceDeleterMockRecorder
+}
+
+// MockSecretForceDeleterMockRecorder is the mock recorder for MockSecretForceDeleter
+type MockSecretForceDeleterMockRecorder struct {
+	mock *MockSecretForceDeleter
+}
+
+// NewMockSecretForceDeleter creates a new mock instance
+func NewMockSecretForceDeleter(ctrl *gomock.Controller) *MockSecretForceDeleter {
+	mock := &MockSecretForceDeleter{ctrl: ctrl}
+	mock.recorder = &MockSecretForceDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretForceDeleter) EXPECT() *MockSecretForceDeleterMockRecorder {
+	return m.recorder
+}
+
+// ForceDeleteSecret mocks base method
+func (m *MockSecretForceDeleter) ForceDeleteSecret(secretName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDeleteSecret", secretName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceDeleteSecret indicates an expected call of ForceDeleteSecret
+func (mr *MockSecretForceDeleterMockRecorder) ForceDeleteSecret(secretName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDeleteSecret", reflect.TypeOf((*MockSecretForceDeleter)(nil).ForceDeleteSecret), secretName)
+}
+
+// MockSecretBackends is a mock of SecretBackends interface
+type MockSecretBackends struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretBackendsMockRecorder
+}
+
+// MockSecretBackendsMockRecorder is the mock recorder for MockSecretBackends
+type MockSecretBackendsMockRecorder struct {
+	mock *MockSecretBackends
+}
+
+// NewMockSecretBackends creates a new mock instance
+func NewMockSecretBackends(ctrl *gomock.Controller) *MockSecretBackends {
+	mock := &MockSecretBackends{ctrl: ctrl}
+	mock.recorder = &MockSecretBackendsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecretBackends) EXPECT() *MockSecretBackendsMockRecorder {
+	return m.recorder
+}
+
+// SecretBackend mocks base method
+func (m *MockSecretBackends) SecretBackend(name string) (archer.SecretsManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretBackend", name)
+	ret0, _ := ret[0].(archer.SecretsManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretBackend indicates an expected call of SecretBackend
+func (mr *MockSecretBackendsMockRecorder) SecretBackend(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretBackend", reflect.TypeOf((*MockSecretBackends)(nil).SecretBackend), name)
+}